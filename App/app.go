@@ -38,7 +38,7 @@ func NewApp() (*App, error) {
 		mcpModule:         mcp.NewMcpModule(bus, config.McpServiceConfig, logger),
 		toolModule:        tool.NewToolModule(bus, config.ToolServiceConfig, logger),
 		messageModule:     message.NewMessageModule(bus, logger),
-		environmentModule: environment.NewEnvironmentModule(bus, logger),
+		environmentModule: environment.NewEnvironmentModule(bus, config.McpServiceConfig.RepoMap, logger),
 		ollamaModule:      ollama.NewOllamaModule(bus, config.OllamaServiceConfig, logger),
 		logger:            logger,
 	}
@@ -63,6 +63,7 @@ func (instance *App) Run() {
 	)
 	instance.model.SetProgram(program)
 	defer func() {
+		instance.mcpModule.Close()
 		instance.bus.Close()
 	}()
 	if _, err := program.Run(); err != nil {
@@ -0,0 +1,33 @@
+package config
+
+const (
+	BackupShell          = "/bin/sh"
+	BackupDefaultTimeout = 120000
+	BackupMaxTimeout     = 600000
+	BackupMaxOutputSize  = 30000
+)
+
+type BashConfig struct {
+	Shell          string
+	DefaultTimeout int
+	MaxTimeout     int
+	MaxOutputSize  int
+}
+
+func (instance *BashConfig) Default() {
+	if instance.Shell == "" {
+		instance.Shell = BackupShell
+	}
+	if instance.DefaultTimeout <= 0 {
+		instance.DefaultTimeout = BackupDefaultTimeout
+	}
+	if instance.MaxTimeout <= 0 {
+		instance.MaxTimeout = BackupMaxTimeout
+	}
+	if instance.MaxTimeout < instance.DefaultTimeout {
+		instance.MaxTimeout = instance.DefaultTimeout
+	}
+	if instance.MaxOutputSize <= 0 {
+		instance.MaxOutputSize = BackupMaxOutputSize
+	}
+}
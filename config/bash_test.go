@@ -0,0 +1,69 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBashConfig_Default(t *testing.T) {
+	tests := []struct {
+		name     string
+		initial  BashConfig
+		expected BashConfig
+	}{
+		{
+			name:    "Empty config should use backup values",
+			initial: BashConfig{},
+			expected: BashConfig{
+				Shell:          BackupShell,
+				DefaultTimeout: BackupDefaultTimeout,
+				MaxTimeout:     BackupMaxTimeout,
+				MaxOutputSize:  BackupMaxOutputSize,
+			},
+		},
+		{
+			name: "Config with all values set should keep all",
+			initial: BashConfig{
+				Shell:          "/bin/bash",
+				DefaultTimeout: 1000,
+				MaxTimeout:     2000,
+				MaxOutputSize:  100,
+			},
+			expected: BashConfig{
+				Shell:          "/bin/bash",
+				DefaultTimeout: 1000,
+				MaxTimeout:     2000,
+				MaxOutputSize:  100,
+			},
+		},
+		{
+			name: "MaxTimeout below DefaultTimeout should be raised",
+			initial: BashConfig{
+				DefaultTimeout: 5000,
+				MaxTimeout:     1000,
+			},
+			expected: BashConfig{
+				Shell:          BackupShell,
+				DefaultTimeout: 5000,
+				MaxTimeout:     5000,
+				MaxOutputSize:  BackupMaxOutputSize,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.initial
+			config.Default()
+			assert.Equal(t, tt.expected, config)
+		})
+	}
+}
+
+func TestBashConfigConstants(t *testing.T) {
+	assert.Equal(t, "/bin/sh", BackupShell)
+	assert.Equal(t, 120000, BackupDefaultTimeout)
+	assert.Equal(t, 600000, BackupMaxTimeout)
+	assert.Equal(t, 30000, BackupMaxOutputSize)
+}
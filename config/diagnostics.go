@@ -0,0 +1,54 @@
+package config
+
+const (
+	BackupDiagnosticsTimeout    = 120000
+	BackupDiagnosticsMaxEntries = 50
+)
+
+type DiagnosticsCheckConfig struct {
+	Command  string `mapstructure:"command"`
+	Severity string `mapstructure:"severity"`
+}
+
+type DiagnosticsLanguageConfig struct {
+	Name    string                   `mapstructure:"name"`
+	Markers []string                 `mapstructure:"markers"`
+	Checks  []DiagnosticsCheckConfig `mapstructure:"checks"`
+}
+
+type DiagnosticsConfig struct {
+	Languages  []DiagnosticsLanguageConfig
+	Timeout    int
+	MaxEntries int
+}
+
+// BackupDiagnosticsLanguages are used when env.toml configures none.
+func BackupDiagnosticsLanguages() []DiagnosticsLanguageConfig {
+	return []DiagnosticsLanguageConfig{{
+		Name:    "go",
+		Markers: []string{"go.mod"},
+		Checks: []DiagnosticsCheckConfig{
+			{Command: "go build ./...", Severity: "error"},
+			{Command: "go vet ./...", Severity: "warning"},
+		},
+	}}
+}
+
+func (instance *DiagnosticsConfig) Default() {
+	if len(instance.Languages) == 0 {
+		instance.Languages = BackupDiagnosticsLanguages()
+	}
+	if instance.Timeout <= 0 {
+		instance.Timeout = BackupDiagnosticsTimeout
+	}
+	if instance.MaxEntries <= 0 {
+		instance.MaxEntries = BackupDiagnosticsMaxEntries
+	}
+	for index := range instance.Languages {
+		for check := range instance.Languages[index].Checks {
+			if instance.Languages[index].Checks[check].Severity == "" {
+				instance.Languages[index].Checks[check].Severity = "error"
+			}
+		}
+	}
+}
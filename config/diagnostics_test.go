@@ -0,0 +1,46 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagnosticsConfig_Default(t *testing.T) {
+	tests := []struct {
+		name     string
+		initial  DiagnosticsConfig
+		expected DiagnosticsConfig
+	}{
+		{
+			name:    "Empty config should use backup values",
+			initial: DiagnosticsConfig{},
+			expected: DiagnosticsConfig{
+				Languages:  BackupDiagnosticsLanguages(),
+				Timeout:    BackupDiagnosticsTimeout,
+				MaxEntries: BackupDiagnosticsMaxEntries,
+			},
+		},
+		{
+			name: "Check without severity should report errors",
+			initial: DiagnosticsConfig{
+				Languages:  []DiagnosticsLanguageConfig{{Name: "rust", Markers: []string{"Cargo.toml"}, Checks: []DiagnosticsCheckConfig{{Command: "cargo check"}}}},
+				Timeout:    1000,
+				MaxEntries: 5,
+			},
+			expected: DiagnosticsConfig{
+				Languages:  []DiagnosticsLanguageConfig{{Name: "rust", Markers: []string{"Cargo.toml"}, Checks: []DiagnosticsCheckConfig{{Command: "cargo check", Severity: "error"}}}},
+				Timeout:    1000,
+				MaxEntries: 5,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.initial
+			config.Default()
+			assert.Equal(t, tt.expected, config)
+		})
+	}
+}
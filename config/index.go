@@ -0,0 +1,36 @@
+package config
+
+const (
+	BackupIndexModel      = "nomic-embed-text"
+	BackupIndexTopK       = 8
+	BackupIndexChunkLines = 60
+	BackupIndexBatchSize  = 32
+	BackupIndexMaxFiles   = 5000
+)
+
+// IndexConfig configures the embedding index used by SemanticSearch.
+type IndexConfig struct {
+	Model      string
+	TopK       int
+	ChunkLines int
+	BatchSize  int
+	MaxFiles   int
+}
+
+func (instance *IndexConfig) Default() {
+	if instance.Model == "" {
+		instance.Model = BackupIndexModel
+	}
+	if instance.TopK <= 0 {
+		instance.TopK = BackupIndexTopK
+	}
+	if instance.ChunkLines <= 0 {
+		instance.ChunkLines = BackupIndexChunkLines
+	}
+	if instance.BatchSize <= 0 {
+		instance.BatchSize = BackupIndexBatchSize
+	}
+	if instance.MaxFiles <= 0 {
+		instance.MaxFiles = BackupIndexMaxFiles
+	}
+}
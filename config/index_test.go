@@ -0,0 +1,52 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexConfig_Default(t *testing.T) {
+	tests := []struct {
+		name     string
+		initial  IndexConfig
+		expected IndexConfig
+	}{
+		{
+			name:    "Empty config should use backup values",
+			initial: IndexConfig{},
+			expected: IndexConfig{
+				Model:      BackupIndexModel,
+				TopK:       BackupIndexTopK,
+				ChunkLines: BackupIndexChunkLines,
+				BatchSize:  BackupIndexBatchSize,
+				MaxFiles:   BackupIndexMaxFiles,
+			},
+		},
+		{
+			name: "Config with values should keep them",
+			initial: IndexConfig{
+				Model:      "mxbai-embed-large",
+				TopK:       3,
+				ChunkLines: 20,
+				BatchSize:  4,
+				MaxFiles:   10,
+			},
+			expected: IndexConfig{
+				Model:      "mxbai-embed-large",
+				TopK:       3,
+				ChunkLines: 20,
+				BatchSize:  4,
+				MaxFiles:   10,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.initial
+			config.Default()
+			assert.Equal(t, tt.expected, config)
+		})
+	}
+}
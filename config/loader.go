@@ -10,11 +10,58 @@ func LoadConfig() *Config {
 		SelectChar: viper.GetString("view.select"),
 	}
 
+	bashConfig := BashConfig{
+		Shell:          viper.GetString("bash.shell"),
+		DefaultTimeout: viper.GetInt("bash.default_timeout"),
+		MaxTimeout:     viper.GetInt("bash.max_timeout"),
+		MaxOutputSize:  viper.GetInt("bash.max_output_size"),
+	}
+
+	lspConfig := LspConfig{
+		RequestTimeout:     viper.GetInt("lsp.request_timeout"),
+		DiagnosticsTimeout: viper.GetInt("lsp.diagnostics_timeout"),
+		MaxRestarts:        viper.GetInt("lsp.max_restarts"),
+	}
+	viper.UnmarshalKey("lsp.servers", &lspConfig.Servers)
+
+	diagnosticsConfig := DiagnosticsConfig{
+		Timeout:    viper.GetInt("diagnostics.timeout"),
+		MaxEntries: viper.GetInt("diagnostics.max_entries"),
+	}
+	viper.UnmarshalKey("diagnostics.languages", &diagnosticsConfig.Languages)
+
+	repoMapConfig := RepoMapConfig{
+		MaxTokens:         viper.GetInt("repomap.max_tokens"),
+		Environment:       viper.GetBool("repomap.environment"),
+		EnvironmentTokens: viper.GetInt("repomap.environment_tokens"),
+		MaxFiles:          viper.GetInt("repomap.max_files"),
+	}
+
+	indexConfig := IndexConfig{
+		Model:      viper.GetString("index.model"),
+		TopK:       viper.GetInt("index.top_k"),
+		ChunkLines: viper.GetInt("index.chunk_lines"),
+		BatchSize:  viper.GetInt("index.batch_size"),
+		MaxFiles:   viper.GetInt("index.max_files"),
+	}
+
 	mcpConfig := McpServiceConfig{
 		Name:          viper.GetString("mcp.name"),
 		Version:       viper.GetString("mcp.version"),
 		ServerName:    viper.GetString("server.name"),
 		ServerVersion: viper.GetString("server.version"),
+		Bash:          bashConfig,
+		Lsp:           lspConfig,
+		Diagnostics:   diagnosticsConfig,
+		RepoMap:       repoMapConfig,
+		Index:         indexConfig,
+	}
+
+	taskConfig := TaskConfig{
+		Model:    viper.GetString("task.model"),
+		Tools:    viper.GetStringSlice("task.tools"),
+		MaxTurns: viper.GetInt("task.max_turns"),
+		system:   viper.GetString("prompt.task"),
 	}
 
 	ollamaConfig := OllamaServiceConfig{
@@ -28,6 +75,7 @@ func LoadConfig() *Config {
 		Model:                      viper.GetString("ollama.model"),
 		system:                     viper.GetString("prompt.system"),
 		DefaultActiveStreamSize:    viper.GetInt("ollama.default_active_stream_size"),
+		Task:                       taskConfig,
 	}
 
 	eventBusConfig := EventBusConfig{
@@ -39,7 +87,13 @@ func LoadConfig() *Config {
 	}
 
 	viewConfig.Default()
+	mcpConfig.Bash.Default()
+	mcpConfig.Lsp.Default()
+	mcpConfig.Diagnostics.Default()
+	mcpConfig.RepoMap.Default()
+	mcpConfig.Index.Default()
 	mcpConfig.Default()
+	ollamaConfig.Task.Default()
 	ollamaConfig.Default()
 	eventBusConfig.Default()
 
@@ -29,8 +29,27 @@ func TestLoadConfig(t *testing.T) {
 	viper.Set("ollama.model", "test-model")
 	viper.Set("prompt.system", "/nonexistent/prompt.txt")
 	viper.Set("ollama.default_active_stream_size", 5)
+	viper.Set("task.model", "task-model")
+	viper.Set("task.tools", []string{"Read", "Grep"})
 	viper.Set("bus.pool_size", 5000)
 	viper.Set("tool.allowed", []string{"Read", "Write", "List"})
+	viper.Set("bash.shell", "/bin/bash")
+	viper.Set("bash.default_timeout", 1000)
+	viper.Set("bash.max_timeout", 2000)
+	viper.Set("bash.max_output_size", 500)
+	viper.Set("lsp.request_timeout", 4000)
+	viper.Set("diagnostics.max_entries", 20)
+	viper.Set("diagnostics.languages", []map[string]any{
+		{"name": "rust", "markers": []string{"Cargo.toml"}, "checks": []map[string]any{{"command": "cargo check"}}},
+	})
+	viper.Set("repomap.environment", true)
+	viper.Set("repomap.environment_tokens", 300)
+	viper.Set("index.model", "embed-model")
+	viper.Set("index.top_k", 4)
+	viper.Set("lsp.max_restarts", 5)
+	viper.Set("lsp.servers", []map[string]any{
+		{"name": "gopls", "command": "gopls", "args": []string{"serve"}, "extensions": []string{".go"}, "language_id": "go"},
+	})
 
 	config := LoadConfig()
 	require.NotNil(t, config)
@@ -44,6 +63,19 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, "1.0.0", config.McpServiceConfig.Version)
 	assert.Equal(t, "TestServer", config.McpServiceConfig.ServerName)
 	assert.Equal(t, "2.0.0", config.McpServiceConfig.ServerVersion)
+	assert.Equal(t, "/bin/bash", config.McpServiceConfig.Bash.Shell)
+	assert.Equal(t, 1000, config.McpServiceConfig.Bash.DefaultTimeout)
+	assert.Equal(t, 2000, config.McpServiceConfig.Bash.MaxTimeout)
+	assert.Equal(t, 500, config.McpServiceConfig.Bash.MaxOutputSize)
+	assert.Equal(t, 4000, config.McpServiceConfig.Lsp.RequestTimeout)
+	assert.Equal(t, BackupLspDiagnosticsTimeout, config.McpServiceConfig.Lsp.DiagnosticsTimeout)
+	assert.Equal(t, 5, config.McpServiceConfig.Lsp.MaxRestarts)
+	assert.Equal(t, 20, config.McpServiceConfig.Diagnostics.MaxEntries)
+	assert.Equal(t, BackupDiagnosticsTimeout, config.McpServiceConfig.Diagnostics.Timeout)
+	assert.Equal(t, []DiagnosticsLanguageConfig{{Name: "rust", Markers: []string{"Cargo.toml"}, Checks: []DiagnosticsCheckConfig{{Command: "cargo check", Severity: "error"}}}}, config.McpServiceConfig.Diagnostics.Languages)
+	assert.Equal(t, []LspServerConfig{{Name: "gopls", Command: "gopls", Args: []string{"serve"}, Extensions: []string{".go"}, LanguageID: "go"}}, config.McpServiceConfig.Lsp.Servers)
+	assert.Equal(t, IndexConfig{Model: "embed-model", TopK: 4, ChunkLines: BackupIndexChunkLines, BatchSize: BackupIndexBatchSize, MaxFiles: BackupIndexMaxFiles}, config.McpServiceConfig.Index)
+	assert.Equal(t, RepoMapConfig{MaxTokens: BackupRepoMapMaxTokens, Environment: true, EnvironmentTokens: 300, MaxFiles: BackupRepoMapMaxFiles}, config.McpServiceConfig.RepoMap)
 
 	// Test OllamaServiceConfig
 	assert.Equal(t, 50, config.OllamaServiceConfig.MessageLimit)
@@ -56,6 +88,10 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, 5, config.OllamaServiceConfig.DefaultActiveStreamSize)
 	assert.NotNil(t, config.OllamaServiceConfig.Url)
 	assert.Equal(t, "http://test:8080", config.OllamaServiceConfig.Url.String())
+	assert.Equal(t, "task-model", config.OllamaServiceConfig.Task.Model)
+	assert.Equal(t, []string{"Read", "Grep"}, config.OllamaServiceConfig.Task.Tools)
+	assert.Equal(t, BackupTaskMaxTurns, config.OllamaServiceConfig.Task.MaxTurns)
+	assert.Equal(t, BackupTaskPrompt, config.OllamaServiceConfig.Task.Prompt)
 
 	// Test EventBusConfig
 	assert.Equal(t, 5000, config.EventBusConfig.PoolSize)
@@ -76,7 +112,10 @@ func TestLoadConfig_WithDefaults(t *testing.T) {
 	assert.Equal(t, BackupSelectChar, config.ViewConfig.SelectChar)
 	assert.Equal(t, BackupName, config.McpServiceConfig.Name)
 	assert.Equal(t, BackupVersion, config.McpServiceConfig.Version)
+	assert.Equal(t, BackupShell, config.McpServiceConfig.Bash.Shell)
+	assert.Equal(t, BackupDefaultTimeout, config.McpServiceConfig.Bash.DefaultTimeout)
 	assert.Equal(t, BackupMessageLimit, config.OllamaServiceConfig.MessageLimit)
+	assert.Equal(t, BackupTaskTools(), config.OllamaServiceConfig.Task.Tools)
 	assert.Equal(t, BackupPoolSize, config.EventBusConfig.PoolSize)
 }
 
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"strings"
+)
+
+const (
+	BackupLspRequestTimeout     = 10000
+	BackupLspDiagnosticsTimeout = 3000
+	BackupLspMaxRestarts        = 3
+)
+
+type LspServerConfig struct {
+	Name        string            `mapstructure:"name"`
+	Command     string            `mapstructure:"command"`
+	Args        []string          `mapstructure:"args"`
+	Extensions  []string          `mapstructure:"extensions"`
+	LanguageID  string            `mapstructure:"language_id"`
+	Env         map[string]string `mapstructure:"env"`
+	InitOptions map[string]any    `mapstructure:"initialization_options"`
+}
+
+type LspConfig struct {
+	Servers            []LspServerConfig
+	RequestTimeout     int
+	DiagnosticsTimeout int
+	MaxRestarts        int
+}
+
+func (instance *LspConfig) Default() {
+	if instance.RequestTimeout <= 0 {
+		instance.RequestTimeout = BackupLspRequestTimeout
+	}
+	if instance.DiagnosticsTimeout <= 0 {
+		instance.DiagnosticsTimeout = BackupLspDiagnosticsTimeout
+	}
+	if instance.MaxRestarts <= 0 {
+		instance.MaxRestarts = BackupLspMaxRestarts
+	}
+	for index := range instance.Servers {
+		server := &instance.Servers[index]
+		if server.Name == "" {
+			server.Name = server.Command
+		}
+		for extension, value := range server.Extensions {
+			if value != "" && !strings.HasPrefix(value, ".") {
+				server.Extensions[extension] = "." + value
+			}
+		}
+		if server.LanguageID == "" && len(server.Extensions) > 0 {
+			server.LanguageID = strings.TrimPrefix(server.Extensions[0], ".")
+		}
+	}
+}
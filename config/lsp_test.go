@@ -0,0 +1,60 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLspConfig_Default(t *testing.T) {
+	tests := []struct {
+		name     string
+		initial  LspConfig
+		expected LspConfig
+	}{
+		{
+			name:    "Empty config should use backup values",
+			initial: LspConfig{},
+			expected: LspConfig{
+				RequestTimeout:     BackupLspRequestTimeout,
+				DiagnosticsTimeout: BackupLspDiagnosticsTimeout,
+				MaxRestarts:        BackupLspMaxRestarts,
+			},
+		},
+		{
+			name: "Server without name and language should be derived from command and extension",
+			initial: LspConfig{
+				Servers:            []LspServerConfig{{Command: "pyright-langserver", Args: []string{"--stdio"}, Extensions: []string{"py"}}},
+				RequestTimeout:     500,
+				DiagnosticsTimeout: 100,
+				MaxRestarts:        1,
+			},
+			expected: LspConfig{
+				Servers:            []LspServerConfig{{Name: "pyright-langserver", Command: "pyright-langserver", Args: []string{"--stdio"}, Extensions: []string{".py"}, LanguageID: "py"}},
+				RequestTimeout:     500,
+				DiagnosticsTimeout: 100,
+				MaxRestarts:        1,
+			},
+		},
+		{
+			name: "Server with all values set should keep all",
+			initial: LspConfig{
+				Servers: []LspServerConfig{{Name: "gopls", Command: "gopls", Extensions: []string{".go"}, LanguageID: "go"}},
+			},
+			expected: LspConfig{
+				Servers:            []LspServerConfig{{Name: "gopls", Command: "gopls", Extensions: []string{".go"}, LanguageID: "go"}},
+				RequestTimeout:     BackupLspRequestTimeout,
+				DiagnosticsTimeout: BackupLspDiagnosticsTimeout,
+				MaxRestarts:        BackupLspMaxRestarts,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.initial
+			config.Default()
+			assert.Equal(t, tt.expected, config)
+		})
+	}
+}
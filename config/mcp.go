@@ -10,6 +10,11 @@ type McpServiceConfig struct {
 	Version       string
 	ServerName    string
 	ServerVersion string
+	Bash          BashConfig
+	Lsp           LspConfig
+	Diagnostics   DiagnosticsConfig
+	RepoMap       RepoMapConfig
+	Index         IndexConfig
 }
 
 func (instance *McpServiceConfig) Default() {
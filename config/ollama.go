@@ -31,6 +31,7 @@ type OllamaServiceConfig struct {
 	system                     string
 	Prompt                     string
 	DefaultActiveStreamSize    int
+	Task                       TaskConfig
 }
 
 func (instance *OllamaServiceConfig) Default() {
@@ -0,0 +1,28 @@
+package config
+
+const (
+	BackupRepoMapMaxTokens         = 2048
+	BackupRepoMapEnvironmentTokens = 512
+	BackupRepoMapMaxFiles          = 5000
+)
+
+type RepoMapConfig struct {
+	MaxTokens int
+	// Environment adds a smaller map of the working directory to the
+	// environment message sent with every request.
+	Environment       bool
+	EnvironmentTokens int
+	MaxFiles          int
+}
+
+func (instance *RepoMapConfig) Default() {
+	if instance.MaxTokens <= 0 {
+		instance.MaxTokens = BackupRepoMapMaxTokens
+	}
+	if instance.EnvironmentTokens <= 0 {
+		instance.EnvironmentTokens = BackupRepoMapEnvironmentTokens
+	}
+	if instance.MaxFiles <= 0 {
+		instance.MaxFiles = BackupRepoMapMaxFiles
+	}
+}
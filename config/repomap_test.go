@@ -0,0 +1,48 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepoMapConfig_Default(t *testing.T) {
+	tests := []struct {
+		name     string
+		initial  RepoMapConfig
+		expected RepoMapConfig
+	}{
+		{
+			name:    "Empty config should use backup values",
+			initial: RepoMapConfig{},
+			expected: RepoMapConfig{
+				MaxTokens:         BackupRepoMapMaxTokens,
+				EnvironmentTokens: BackupRepoMapEnvironmentTokens,
+				MaxFiles:          BackupRepoMapMaxFiles,
+			},
+		},
+		{
+			name: "Config with values should keep them",
+			initial: RepoMapConfig{
+				MaxTokens:         1000,
+				Environment:       true,
+				EnvironmentTokens: 200,
+				MaxFiles:          100,
+			},
+			expected: RepoMapConfig{
+				MaxTokens:         1000,
+				Environment:       true,
+				EnvironmentTokens: 200,
+				MaxFiles:          100,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.initial
+			config.Default()
+			assert.Equal(t, tt.expected, config)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package config
+
+import "os"
+
+const (
+	BackupTaskMaxTurns = 20
+	BackupTaskPrompt   = `You are a DevCode sub-agent. The main assistant has handed you a single, self-contained task.
+Use the tools available to you to investigate, then reply with one final answer that the main assistant
+can act on without repeating your work: list the relevant file paths (with line numbers when useful),
+the facts you found and any open questions. Do not ask the user anything; the user cannot see this
+conversation. Keep the final answer concise and do not include raw tool output unless it is essential.`
+)
+
+type TaskConfig struct {
+	Model    string
+	Tools    []string
+	MaxTurns int
+	system   string
+	Prompt   string
+}
+
+// BackupTaskTools are the read-only tools a sub-agent may use when env.toml configures none.
+func BackupTaskTools() []string {
+	return []string{
+		"Read", "List", "Glob", "Grep",
+		"GitStatus", "GitDiff", "GitLog", "GitBlame", "GitShow",
+		"GoSymbols", "RepoMap", "SemanticSearch", "GoToDefinition", "FindReferences", "Hover",
+	}
+}
+
+func (instance *TaskConfig) Default() {
+	if len(instance.Tools) == 0 {
+		instance.Tools = BackupTaskTools()
+	}
+	if instance.MaxTurns <= 0 {
+		instance.MaxTurns = BackupTaskMaxTurns
+	}
+	if instance.system != "" {
+		if prompt, err := os.ReadFile(instance.system); err == nil {
+			instance.Prompt = string(prompt)
+		}
+	}
+	if instance.Prompt == "" {
+		instance.Prompt = BackupTaskPrompt
+	}
+}
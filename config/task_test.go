@@ -0,0 +1,73 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskConfig_Default(t *testing.T) {
+	tests := []struct {
+		name     string
+		initial  TaskConfig
+		expected TaskConfig
+	}{
+		{
+			name:    "Empty config should use backup values",
+			initial: TaskConfig{},
+			expected: TaskConfig{
+				Tools:    BackupTaskTools(),
+				MaxTurns: BackupTaskMaxTurns,
+				Prompt:   BackupTaskPrompt,
+			},
+		},
+		{
+			name: "Config with all values set should keep all",
+			initial: TaskConfig{
+				Model:    "qwen3:4b",
+				Tools:    []string{"Read"},
+				MaxTurns: 5,
+				Prompt:   "custom",
+			},
+			expected: TaskConfig{
+				Model:    "qwen3:4b",
+				Tools:    []string{"Read"},
+				MaxTurns: 5,
+				Prompt:   "custom",
+			},
+		},
+		{
+			name: "Missing prompt file should fall back to backup prompt",
+			initial: TaskConfig{
+				system: "/nonexistent/Task.md",
+			},
+			expected: TaskConfig{
+				Tools:    BackupTaskTools(),
+				MaxTurns: BackupTaskMaxTurns,
+				system:   "/nonexistent/Task.md",
+				Prompt:   BackupTaskPrompt,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.initial
+			config.Default()
+			assert.Equal(t, tt.expected, config)
+		})
+	}
+}
+
+func TestTaskConfig_Default_WithPromptFile(t *testing.T) {
+	promptFile := filepath.Join(t.TempDir(), "Task.md")
+	require.NoError(t, os.WriteFile(promptFile, []byte("sub-agent prompt"), 0644))
+
+	config := TaskConfig{system: promptFile}
+	config.Default()
+
+	assert.Equal(t, "sub-agent prompt", config.Prompt)
+}
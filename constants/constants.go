@@ -13,6 +13,9 @@ const (
 	ToolModule
 	Model
 	ToolManager
+	TaskTool
+	SemanticSearchTool
+	AskUserTool
 )
 
 func (instance Source) String() string {
@@ -31,6 +34,14 @@ func (instance Source) String() string {
 		return "ToolModule"
 	case Model:
 		return "Model"
+	case ToolManager:
+		return "ToolManager"
+	case TaskTool:
+		return "TaskTool"
+	case SemanticSearchTool:
+		return "SemanticSearchTool"
+	case AskUserTool:
+		return "AskUserTool"
 	default:
 		return fmt.Sprintf("Source(%d)", int(instance))
 	}
@@ -51,3 +62,15 @@ const (
 	AssistantInput
 	ToolDecision
 )
+
+const (
+	TodoPending    = "pending"
+	TodoInProgress = "in_progress"
+	TodoCompleted  = "completed"
+)
+
+const (
+	// DataDirectory holds DevCode's own files inside the workspace, such as the trash.
+	DataDirectory  = ".devcode"
+	RestoreCommand = "/restore"
+)
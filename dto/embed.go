@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"DevCode/types"
+)
+
+type EmbedRequestData struct {
+	ID     types.CreateID
+	Model  string
+	Inputs []string
+}
+
+type EmbedResultData struct {
+	ID         types.CreateID
+	Embeddings [][]float32
+	Error      error
+}
@@ -11,6 +11,7 @@ type EnvironmentUpdateData struct {
 	OSVersion          string
 	IsDirectoryGitRepo bool
 	TodayDate          string
+	RepoMap            string
 }
 
 type EnvironmentRequestData struct {
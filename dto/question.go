@@ -0,0 +1,20 @@
+package dto
+
+import (
+	"DevCode/types"
+)
+
+type QuestionData struct {
+	RequestID  types.RequestID
+	ToolCallID types.ToolCallID
+	Question   string
+	Options    []string
+	Other      bool
+}
+
+type AnswerData struct {
+	ToolCallID types.ToolCallID
+	Answer     string
+	// Declined is set when the user dismissed the question without answering.
+	Declined bool
+}
@@ -0,0 +1,20 @@
+package dto
+
+import (
+	"DevCode/types"
+)
+
+type SubAgentRequestData struct {
+	ParentRequestID  types.RequestID
+	ParentToolCallID types.ToolCallID
+	Description      string
+	Prompt           string
+	Model            string
+	Tools            []string
+}
+
+type SubAgentResultData struct {
+	ParentToolCallID types.ToolCallID
+	Result           string
+	Error            error
+}
@@ -0,0 +1,9 @@
+package dto
+
+import (
+	"DevCode/types"
+)
+
+type TodoUpdateData struct {
+	Todos []types.Todo
+}
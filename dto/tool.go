@@ -16,16 +16,21 @@ type ToolListUpdateData struct {
 }
 
 type ToolCallData struct {
-	RequestID  types.RequestID
-	ToolCallID types.ToolCallID
-	ToolName   string
-	Parameters map[string]any
+	RequestID        types.RequestID
+	ToolCallID       types.ToolCallID
+	ParentToolCallID types.ToolCallID
+	ToolName         string
+	Parameters       map[string]any
+	// Meta is sent to the tool in _meta next to the call IDs, such as the
+	// plan the user approved.
+	Meta map[string]any
 }
 
 type ToolResultData struct {
 	RequestID  types.RequestID
 	ToolCallID types.ToolCallID
 	ToolResult string
+	Images     [][]byte
 }
 
 type ToolRawResultData struct {
@@ -35,8 +40,9 @@ type ToolRawResultData struct {
 }
 
 type ToolUseReportData struct {
-	RequestID  types.RequestID
-	ToolCallID types.ToolCallID
-	ToolInfo   string
-	ToolStatus constants.ToolStatus
+	RequestID        types.RequestID
+	ToolCallID       types.ToolCallID
+	ParentToolCallID types.ToolCallID
+	ToolInfo         string
+	ToolStatus       constants.ToolStatus
 }
@@ -0,0 +1,9 @@
+package dto
+
+type RestoreRequestData struct {
+	Argument string
+}
+
+type RestoreResultData struct {
+	Message string
+}
@@ -29,6 +29,20 @@ func NewEventBus(config config.EventBusConfig, logger *zap.Logger) (*EventBus, e
 		ToolResultEvent:     NewTypedBus[dto.ToolResultData](),
 		ToolUseReportEvent:  NewTypedBus[dto.ToolUseReportData](),
 
+		UpdateTodoEvent: NewTypedBus[dto.TodoUpdateData](),
+
+		SubAgentRequestEvent: NewTypedBus[dto.SubAgentRequestData](),
+		SubAgentResultEvent:  NewTypedBus[dto.SubAgentResultData](),
+
+		QuestionEvent: NewTypedBus[dto.QuestionData](),
+		AnswerEvent:   NewTypedBus[dto.AnswerData](),
+
+		EmbedRequestEvent: NewTypedBus[dto.EmbedRequestData](),
+		EmbedResultEvent:  NewTypedBus[dto.EmbedResultData](),
+
+		RestoreRequestEvent: NewTypedBus[dto.RestoreRequestData](),
+		RestoreResultEvent:  NewTypedBus[dto.RestoreResultData](),
+
 		RequestEnvironmentEvent: NewTypedBus[dto.EnvironmentRequestData](),
 		UpdateEnvironmentEvent:  NewTypedBus[dto.EnvironmentUpdateData](),
 
@@ -65,6 +79,20 @@ type EventBus struct {
 	ToolResultEvent     *TypedBus[dto.ToolResultData]
 	ToolUseReportEvent  *TypedBus[dto.ToolUseReportData]
 
+	UpdateTodoEvent *TypedBus[dto.TodoUpdateData]
+
+	SubAgentRequestEvent *TypedBus[dto.SubAgentRequestData]
+	SubAgentResultEvent  *TypedBus[dto.SubAgentResultData]
+
+	QuestionEvent *TypedBus[dto.QuestionData]
+	AnswerEvent   *TypedBus[dto.AnswerData]
+
+	EmbedRequestEvent *TypedBus[dto.EmbedRequestData]
+	EmbedResultEvent  *TypedBus[dto.EmbedResultData]
+
+	RestoreRequestEvent *TypedBus[dto.RestoreRequestData]
+	RestoreResultEvent  *TypedBus[dto.RestoreResultData]
+
 	RequestEnvironmentEvent *TypedBus[dto.EnvironmentRequestData]
 	UpdateEnvironmentEvent  *TypedBus[dto.EnvironmentUpdateData]
 
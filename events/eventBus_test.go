@@ -27,6 +27,7 @@ func TestNewEventBus(t *testing.T) {
 	assert.NotNil(t, bus.UserDecisionEvent)
 	assert.NotNil(t, bus.ToolCallEvent)
 	assert.NotNil(t, bus.StreamStartEvent)
+	assert.NotNil(t, bus.UpdateTodoEvent)
 	assert.NotNil(t, bus.RagnarokEvent)
 	assert.Equal(t, logger, bus.logger)
 	assert.NotNil(t, bus.pool)
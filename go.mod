@@ -19,6 +19,10 @@ require (
 	github.com/spf13/viper v1.20.1
 	//log
 	go.uber.org/zap v1.27.0
+	//web
+	golang.org/x/net v0.43.0
+	//concurrency
+	golang.org/x/sync v0.16.0
 )
 
 require github.com/stretchr/testify v1.10.0
@@ -54,7 +58,6 @@ require (
 	github.com/yosida95/uritemplate/v3 v3.0.2 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
 	golang.org/x/crypto v0.41.0 // indirect
-	golang.org/x/sync v0.16.0 // indirect
 	golang.org/x/sys v0.35.0 // indirect
 	golang.org/x/text v0.28.0 // indirect
 	gopkg.in/check.v1 v1.0.0-20201130134442-10cb98267c6c // indirect
@@ -35,13 +35,38 @@ type ToolManager struct {
 func (instance *ToolManager) Subscribe() {
 	events.Subscribe(instance.bus, instance.bus.ToolUseReportEvent, constants.ToolManager, instance.ProcessReportEvent)
 	events.Subscribe(instance.bus, instance.bus.RequestToolUseEvent, constants.ToolManager, instance.ProcessRequestEvent)
+	events.Subscribe(instance.bus, instance.bus.QuestionEvent, constants.ToolManager, instance.ProcessQuestionEvent)
 }
 
 func (instance *ToolManager) ProcessRequestEvent(event events.Event[dto.ToolUseReportData]) {
 	instance.mutex.Lock()
 	defer instance.mutex.Unlock()
 
-	instance.pendingToolStack = append(instance.pendingToolStack, &types.PendingTool{RequestID: event.Data.RequestID, ToolCallID: event.Data.ToolCallID})
+	instance.pushPending(&types.PendingTool{RequestID: event.Data.RequestID, ToolCallID: event.Data.ToolCallID})
+	// ProcessReportEvent를 직접 호출하지 않고 이벤트로 발행하여 데드락 방지
+	events.Publish(instance.bus, instance.bus.ToolUseReportEvent, event)
+}
+
+// ProcessQuestionEvent queues an AskUser question behind the decisions
+// already waiting for the user.
+func (instance *ToolManager) ProcessQuestionEvent(event events.Event[dto.QuestionData]) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	instance.pushPending(&types.PendingTool{
+		RequestID:  event.Data.RequestID,
+		ToolCallID: event.Data.ToolCallID,
+		Question: &types.Question{
+			Text:    event.Data.Question,
+			Options: event.Data.Options,
+			Other:   event.Data.Other,
+		},
+	})
+	instance.PublishUpdateView()
+}
+
+func (instance *ToolManager) pushPending(pending *types.PendingTool) {
+	instance.pendingToolStack = append(instance.pendingToolStack, pending)
 	if len(instance.pendingToolStack) == 1 {
 		events.Publish(instance.bus, instance.bus.UpdateUserStatusEvent, events.Event[dto.UpdateUserStatusData]{
 			Data: dto.UpdateUserStatusData{
@@ -51,8 +76,6 @@ func (instance *ToolManager) ProcessRequestEvent(event events.Event[dto.ToolUseR
 			Source:    constants.ToolManager,
 		})
 	}
-	// ProcessReportEvent를 직접 호출하지 않고 이벤트로 발행하여 데드락 방지
-	events.Publish(instance.bus, instance.bus.ToolUseReportEvent, event)
 }
 
 func (instance *ToolManager) ProcessReportEvent(event events.Event[dto.ToolUseReportData]) {
@@ -63,17 +86,20 @@ func (instance *ToolManager) ProcessReportEvent(event events.Event[dto.ToolUseRe
 		if activeTool.ToolStatus != event.Data.ToolStatus || activeTool.ToolInfo != event.Data.ToolInfo {
 			delete(instance.activeTools, event.Data.ToolCallID)
 			instance.changedActiveTool = append(instance.changedActiveTool, &types.ActiveTool{
-				ToolCallID: event.Data.ToolCallID,
-				ToolStatus: event.Data.ToolStatus,
-				ToolInfo:   event.Data.ToolInfo,
+				ToolCallID:       event.Data.ToolCallID,
+				ParentToolCallID: activeTool.ParentToolCallID,
+				ToolStatus:       event.Data.ToolStatus,
+				ToolInfo:         event.Data.ToolInfo,
 			})
 			instance.PublishUpdateView()
 		}
 		return
 	}
 	activeTool := &types.ActiveTool{
-		ToolStatus: event.Data.ToolStatus,
-		ToolInfo:   event.Data.ToolInfo,
+		ToolCallID:       event.Data.ToolCallID,
+		ParentToolCallID: event.Data.ParentToolCallID,
+		ToolStatus:       event.Data.ToolStatus,
+		ToolInfo:         event.Data.ToolInfo,
 	}
 	instance.activeTools[event.Data.ToolCallID] = activeTool
 	instance.changedActiveTool = append(instance.changedActiveTool, activeTool)
@@ -99,10 +125,28 @@ func (instance *ToolManager) ChangedActiveTool() []*types.ActiveTool {
 	return instance.changedActiveTool
 }
 
+// Pending returns the decision the user is asked for now, or nil.
+func (instance *ToolManager) Pending() *types.PendingTool {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	if len(instance.pendingToolStack) == 0 {
+		return nil
+	}
+	return instance.pendingToolStack[0]
+}
+
+// Select accepts or rejects the pending tool call, or answers the pending
+// question with the option at selectIndex.
 func (instance *ToolManager) Select(selectIndex int) {
 	instance.mutex.Lock()
 	defer instance.mutex.Unlock()
 
+	if question := instance.pendingToolStack[0].Question; question != nil {
+		if selectIndex < len(question.Options) {
+			instance.publishAnswer(dto.AnswerData{Answer: question.Options[selectIndex]})
+		}
+		return
+	}
 	accept := selectIndex == 0
 	events.Publish(instance.bus, instance.bus.UserDecisionEvent,
 		events.Event[dto.UserDecisionData]{
@@ -118,10 +162,25 @@ func (instance *ToolManager) Select(selectIndex int) {
 	instance.checkPeddingToolStack()
 }
 
+// Answer answers the pending question with text the user typed.
+func (instance *ToolManager) Answer(answer string) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	if len(instance.pendingToolStack) == 0 || instance.pendingToolStack[0].Question == nil {
+		return
+	}
+	instance.publishAnswer(dto.AnswerData{Answer: answer})
+}
+
 func (instance *ToolManager) Quit() {
 	instance.mutex.Lock()
 	defer instance.mutex.Unlock()
 
+	if instance.pendingToolStack[0].Question != nil {
+		instance.publishAnswer(dto.AnswerData{Declined: true})
+		return
+	}
 	events.Publish(instance.bus, instance.bus.UserDecisionEvent,
 		events.Event[dto.UserDecisionData]{
 			Data: dto.UserDecisionData{
@@ -136,6 +195,17 @@ func (instance *ToolManager) Quit() {
 	instance.checkPeddingToolStack()
 }
 
+func (instance *ToolManager) publishAnswer(answer dto.AnswerData) {
+	answer.ToolCallID = instance.pendingToolStack[0].ToolCallID
+	events.Publish(instance.bus, instance.bus.AnswerEvent, events.Event[dto.AnswerData]{
+		Data:      answer,
+		TimeStamp: time.Now(),
+		Source:    constants.ToolManager,
+	})
+	instance.pendingToolStack = instance.pendingToolStack[1:]
+	instance.checkPeddingToolStack()
+}
+
 func (instance *ToolManager) checkPeddingToolStack() {
 	if len(instance.pendingToolStack) == 0 {
 		events.Publish(instance.bus, instance.bus.UpdateUserStatusEvent, events.Event[dto.UpdateUserStatusData]{
@@ -171,6 +171,47 @@ func TestToolManager_ProcessReportEvent_UpdateExistingTool(t *testing.T) {
 	assert.Equal(t, "Updated info", changedTools[0].ToolInfo)
 }
 
+func TestToolManager_ProcessReportEvent_NestedTool(t *testing.T) {
+	// Given
+	logger := zap.NewNop()
+	bus, err := events.NewEventBus(config.EventBusConfig{PoolSize: 100}, logger)
+	require.NoError(t, err)
+	defer bus.Close()
+
+	manager := NewToolManager(bus, logger)
+	parentToolCallID := types.NewToolCallID()
+	toolCallID := types.NewToolCallID()
+
+	// When - a Task sub-agent calls a tool and it finishes
+	manager.ProcessReportEvent(events.Event[dto.ToolUseReportData]{
+		Data: dto.ToolUseReportData{
+			RequestID:        types.NewRequestID(),
+			ToolCallID:       toolCallID,
+			ParentToolCallID: parentToolCallID,
+			ToolInfo:         "Grep (NewSession)",
+			ToolStatus:       constants.Call,
+		},
+		TimeStamp: time.Now(),
+		Source:    constants.ToolModule,
+	})
+	manager.ProcessReportEvent(events.Event[dto.ToolUseReportData]{
+		Data: dto.ToolUseReportData{
+			ToolCallID: toolCallID,
+			ToolStatus: constants.Success,
+		},
+		TimeStamp: time.Now(),
+		Source:    constants.ToolModule,
+	})
+
+	// Then - both changes keep the tool call and its parent
+	changedTools := manager.ChangedActiveTool()
+	require.Equal(t, 2, len(changedTools))
+	for _, changed := range changedTools {
+		assert.Equal(t, toolCallID, changed.ToolCallID)
+		assert.Equal(t, parentToolCallID, changed.ParentToolCallID)
+	}
+}
+
 func TestToolManager_ProcessReportEvent_NoChangeInExistingTool(t *testing.T) {
 	// Given
 	logger := zap.NewNop()
@@ -404,6 +445,135 @@ func TestToolManager_MultiplePendingTools(t *testing.T) {
 	assert.Equal(t, 0, len(manager.pendingToolStack))
 }
 
+func subscribeAnswers(bus *events.EventBus) chan dto.AnswerData {
+	answers := make(chan dto.AnswerData, 10)
+	events.Subscribe(bus, bus.AnswerEvent, constants.AskUserTool, func(event events.Event[dto.AnswerData]) {
+		answers <- event.Data
+	})
+	return answers
+}
+
+func questionEvent(toolCallID types.ToolCallID, other bool) events.Event[dto.QuestionData] {
+	return events.Event[dto.QuestionData]{
+		Data: dto.QuestionData{
+			RequestID:  types.NewRequestID(),
+			ToolCallID: toolCallID,
+			Question:   "Which database?",
+			Options:    []string{"Redis", "Postgres"},
+			Other:      other,
+		},
+		TimeStamp: time.Now(),
+		Source:    constants.AskUserTool,
+	}
+}
+
+func receiveAnswer(t *testing.T, answers chan dto.AnswerData) dto.AnswerData {
+	t.Helper()
+	select {
+	case answer := <-answers:
+		return answer
+	case <-time.After(time.Second):
+		t.Fatal("answer was not published")
+		return dto.AnswerData{}
+	}
+}
+
+func TestToolManager_Question_Select(t *testing.T) {
+	// Given
+	logger := zap.NewNop()
+	bus, err := events.NewEventBus(config.EventBusConfig{PoolSize: 100}, logger)
+	require.NoError(t, err)
+	defer bus.Close()
+
+	manager := NewToolManager(bus, logger)
+	answers := subscribeAnswers(bus)
+	toolCallID := types.NewToolCallID()
+
+	// When
+	manager.ProcessQuestionEvent(questionEvent(toolCallID, true))
+
+	// Then - the question is pending with its options
+	pending := manager.Pending()
+	require.NotNil(t, pending)
+	assert.Equal(t, toolCallID, pending.ToolCallID)
+	assert.Equal(t, &types.Question{Text: "Which database?", Options: []string{"Redis", "Postgres"}, Other: true}, pending.Question)
+
+	// When - the index after the options is left to the view
+	manager.Select(2)
+
+	// Then
+	assert.True(t, manager.IsPending())
+
+	// When - Select the second option
+	manager.Select(1)
+
+	// Then
+	answer := receiveAnswer(t, answers)
+	assert.Equal(t, dto.AnswerData{ToolCallID: toolCallID, Answer: "Postgres"}, answer)
+	assert.False(t, manager.IsPending())
+	assert.Nil(t, manager.Pending())
+}
+
+func TestToolManager_Question_Answer(t *testing.T) {
+	// Given
+	logger := zap.NewNop()
+	bus, err := events.NewEventBus(config.EventBusConfig{PoolSize: 100}, logger)
+	require.NoError(t, err)
+	defer bus.Close()
+
+	manager := NewToolManager(bus, logger)
+	answers := subscribeAnswers(bus)
+	toolCallID := types.NewToolCallID()
+
+	// When - Answer is ignored while a tool call waits for approval
+	manager.ProcessRequestEvent(events.Event[dto.ToolUseReportData]{
+		Data: dto.ToolUseReportData{
+			RequestID:  types.NewRequestID(),
+			ToolCallID: types.NewToolCallID(),
+			ToolInfo:   "Write",
+			ToolStatus: constants.Call,
+		},
+		TimeStamp: time.Now(),
+		Source:    constants.ToolModule,
+	})
+	manager.ProcessQuestionEvent(questionEvent(toolCallID, true))
+	manager.Answer("SQLite")
+
+	// Then
+	assert.Equal(t, 2, len(manager.pendingToolStack))
+	assert.Nil(t, manager.Pending().Question)
+
+	// When - the question comes after the approval
+	manager.Select(0)
+	manager.Answer("SQLite")
+
+	// Then
+	answer := receiveAnswer(t, answers)
+	assert.Equal(t, dto.AnswerData{ToolCallID: toolCallID, Answer: "SQLite"}, answer)
+	assert.False(t, manager.IsPending())
+}
+
+func TestToolManager_Question_Quit(t *testing.T) {
+	// Given
+	logger := zap.NewNop()
+	bus, err := events.NewEventBus(config.EventBusConfig{PoolSize: 100}, logger)
+	require.NoError(t, err)
+	defer bus.Close()
+
+	manager := NewToolManager(bus, logger)
+	answers := subscribeAnswers(bus)
+	toolCallID := types.NewToolCallID()
+	manager.ProcessQuestionEvent(questionEvent(toolCallID, false))
+
+	// When
+	manager.Quit()
+
+	// Then
+	answer := receiveAnswer(t, answers)
+	assert.Equal(t, dto.AnswerData{ToolCallID: toolCallID, Declined: true}, answer)
+	assert.False(t, manager.IsPending())
+}
+
 func TestToolManager_ConcurrentAccess(t *testing.T) {
 	// Given
 	logger := zap.NewNop()
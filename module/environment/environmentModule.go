@@ -2,31 +2,43 @@ package environment
 
 import (
 	devcodeerror "DevCode/DevCodeError"
+	"DevCode/config"
 	"DevCode/constants"
 	"DevCode/dto"
 	"DevCode/events"
+	"DevCode/tools/repomap"
 	"DevCode/types"
+	"context"
 	"go.uber.org/zap"
 	"os"
 	"os/exec"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 )
 
 const (
 	Backup = "Unknown"
+	// RepoMapLifetime is how long a repository map is reused before the
+	// working directory is scanned again.
+	RepoMapLifetime = time.Minute
 )
 
-func NewEnvironmentModule(bus *events.EventBus, logger *zap.Logger) *EnvironmentModule {
-	module := &EnvironmentModule{bus: bus, logger: logger}
+func NewEnvironmentModule(bus *events.EventBus, config config.RepoMapConfig, logger *zap.Logger) *EnvironmentModule {
+	module := &EnvironmentModule{bus: bus, config: config, logger: logger}
 	module.Subscribe()
 	return module
 }
 
 type EnvironmentModule struct {
-	bus    *events.EventBus
-	logger *zap.Logger
+	bus          *events.EventBus
+	config       config.RepoMapConfig
+	logger       *zap.Logger
+	repoMap      string
+	repoMapRoot  string
+	repoMapBuilt time.Time
+	repoMapMutex sync.Mutex
 }
 
 func (instance *EnvironmentModule) Subscribe() {
@@ -74,10 +86,37 @@ func (instance *EnvironmentModule) checkVersion() string {
 	return version
 }
 
+// readRepoMap returns a map of cwd within the environment token budget, or
+// nothing when the map is disabled in the configuration.
+func (instance *EnvironmentModule) readRepoMap(cwd string) string {
+	if !instance.config.Environment || cwd == Backup {
+		return ""
+	}
+	instance.repoMapMutex.Lock()
+	defer instance.repoMapMutex.Unlock()
+
+	if instance.repoMapRoot == cwd && time.Since(instance.repoMapBuilt) < RepoMapLifetime {
+		return instance.repoMap
+	}
+	repoMap, err := repomap.Build(context.Background(), cwd, instance.config.MaxFiles)
+	if err != nil {
+		instance.logger.Warn("", zap.Error(devcodeerror.Wrap(err, devcodeerror.FailReadEnvironment, "Fail build repository map")))
+		return ""
+	}
+	instance.repoMap = ""
+	if len(repoMap.Files) > 0 {
+		instance.repoMap = repoMap.Render(instance.config.EnvironmentTokens)
+	}
+	instance.repoMapRoot = cwd
+	instance.repoMapBuilt = time.Now()
+	return instance.repoMap
+}
+
 func (instance *EnvironmentModule) UpdateEnvironmentInfo() {
 	cwd := instance.readCWD()
 	git := instance.checkGit(cwd)
 	version := instance.checkVersion()
+	repoMap := instance.readRepoMap(cwd)
 	events.Publish(instance.bus, instance.bus.UpdateEnvironmentEvent, events.Event[dto.EnvironmentUpdateData]{
 		Data: dto.EnvironmentUpdateData{
 			CreateID:           types.NewCreateID(),
@@ -86,6 +125,7 @@ func (instance *EnvironmentModule) UpdateEnvironmentInfo() {
 			OSVersion:          version,
 			IsDirectoryGitRepo: git,
 			TodayDate:          time.Now().Format("2006-01-02"),
+			RepoMap:            repoMap,
 		},
 		TimeStamp: time.Now(),
 		Source:    constants.EnvironmentModule,
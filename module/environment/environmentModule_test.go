@@ -7,6 +7,7 @@ import (
 	"DevCode/events"
 	"DevCode/types"
 	"os"
+	"path/filepath"
 	"runtime"
 	"sync"
 	"testing"
@@ -22,7 +23,7 @@ func TestNewEnvironmentModule(t *testing.T) {
 	bus := createTestEventBus(t)
 	defer bus.Close()
 
-	module := NewEnvironmentModule(bus, logger)
+	module := NewEnvironmentModule(bus, config.RepoMapConfig{}, logger)
 
 	assert.NotNil(t, module)
 	assert.Equal(t, bus, module.bus)
@@ -34,7 +35,7 @@ func TestEnvironmentModule_ReadCWD(t *testing.T) {
 	bus := createTestEventBus(t)
 	defer bus.Close()
 
-	module := NewEnvironmentModule(bus, logger)
+	module := NewEnvironmentModule(bus, config.RepoMapConfig{}, logger)
 
 	cwd := module.readCWD()
 
@@ -50,7 +51,7 @@ func TestEnvironmentModule_CheckVersion(t *testing.T) {
 	bus := createTestEventBus(t)
 	defer bus.Close()
 
-	module := NewEnvironmentModule(bus, logger)
+	module := NewEnvironmentModule(bus, config.RepoMapConfig{}, logger)
 
 	version := module.checkVersion()
 
@@ -65,7 +66,7 @@ func TestEnvironmentModule_CheckGit_WithGitRepo(t *testing.T) {
 	bus := createTestEventBus(t)
 	defer bus.Close()
 
-	module := NewEnvironmentModule(bus, logger)
+	module := NewEnvironmentModule(bus, config.RepoMapConfig{}, logger)
 	cwd, _ := os.Getwd()
 
 	isGitRepo := module.checkGit(cwd)
@@ -78,7 +79,7 @@ func TestEnvironmentModule_CheckGit_WithoutGitRepo(t *testing.T) {
 	bus := createTestEventBus(t)
 	defer bus.Close()
 
-	module := NewEnvironmentModule(bus, logger)
+	module := NewEnvironmentModule(bus, config.RepoMapConfig{}, logger)
 
 	isGitRepo := module.checkGit("/tmp")
 
@@ -99,7 +100,7 @@ func TestEnvironmentModule_UpdateEnvironmentInfo(t *testing.T) {
 		wg.Done()
 	})
 
-	module := NewEnvironmentModule(bus, logger)
+	module := NewEnvironmentModule(bus, config.RepoMapConfig{}, logger)
 	module.UpdateEnvironmentInfo()
 
 	wg.Wait()
@@ -114,6 +115,31 @@ func TestEnvironmentModule_UpdateEnvironmentInfo(t *testing.T) {
 	assert.WithinDuration(t, time.Now(), capturedEvent.TimeStamp, time.Second)
 }
 
+func TestEnvironmentModule_ReadRepoMap(t *testing.T) {
+	logger := zap.NewNop()
+	bus := createTestEventBus(t)
+	defer bus.Close()
+
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "store.go"), []byte("package store\n\nfunc NewStore() {}\n"), 0644))
+
+	// 설정에서 끄면 map을 만들지 않는다
+	module := NewEnvironmentModule(bus, config.RepoMapConfig{EnvironmentTokens: 100, MaxFiles: 10}, logger)
+	assert.Empty(t, module.readRepoMap(root))
+
+	module = NewEnvironmentModule(bus, config.RepoMapConfig{Environment: true, EnvironmentTokens: 100, MaxFiles: 10}, logger)
+	repoMap := module.readRepoMap(root)
+	assert.Contains(t, repoMap, "    func NewStore()")
+
+	// 수명 안에서는 다시 스캔하지 않는다
+	require.NoError(t, os.WriteFile(filepath.Join(root, "other.go"), []byte("package store\n\nfunc Other() {}\n"), 0644))
+	assert.Equal(t, repoMap, module.readRepoMap(root))
+	module.repoMapBuilt = time.Now().Add(-RepoMapLifetime)
+	assert.Contains(t, module.readRepoMap(root), "    func Other()")
+
+	assert.Empty(t, module.readRepoMap(t.TempDir()))
+}
+
 func TestEnvironmentModule_Subscribe_HandlesRequestEvent(t *testing.T) {
 	logger := zap.NewNop()
 	bus := createTestEventBus(t)
@@ -128,7 +154,7 @@ func TestEnvironmentModule_Subscribe_HandlesRequestEvent(t *testing.T) {
 		wg.Done()
 	})
 
-	NewEnvironmentModule(bus, logger)
+	NewEnvironmentModule(bus, config.RepoMapConfig{}, logger)
 
 	requestEvent := events.Event[dto.EnvironmentRequestData]{
 		Data: dto.EnvironmentRequestData{
@@ -163,7 +189,7 @@ func TestEnvironmentModule_Subscribe_Integration(t *testing.T) {
 		wg.Done()
 	})
 
-	NewEnvironmentModule(bus, logger)
+	NewEnvironmentModule(bus, config.RepoMapConfig{}, logger)
 
 	numEvents := 3
 	wg.Add(numEvents)
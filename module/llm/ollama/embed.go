@@ -0,0 +1,33 @@
+package ollama
+
+import (
+	"DevCode/constants"
+	"DevCode/dto"
+	"DevCode/events"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ollama/ollama/api"
+)
+
+const EmbedTimeout = 5 * time.Minute
+
+// Embed answers an embed request from the index with the client the chat
+// uses, so embeddings come from the configured Ollama server.
+func (instance *OllamaModule) Embed(data dto.EmbedRequestData) {
+	ctx, cancel := context.WithTimeout(context.Background(), EmbedTimeout)
+	defer cancel()
+	result := dto.EmbedResultData{ID: data.ID}
+	response, err := instance.client.Embed(ctx, &api.EmbedRequest{Model: data.Model, Input: data.Inputs})
+	if err != nil {
+		result.Error = fmt.Errorf("fail to embed with %s, check that the model is pulled (ollama pull %s): %v", data.Model, data.Model, err)
+	} else {
+		result.Embeddings = response.Embeddings
+	}
+	events.Publish(instance.bus, instance.bus.EmbedResultEvent, events.Event[dto.EmbedResultData]{
+		Data:      result,
+		TimeStamp: time.Now(),
+		Source:    constants.LLMModule,
+	})
+}
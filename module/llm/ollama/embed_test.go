@@ -0,0 +1,84 @@
+package ollama
+
+import (
+	"DevCode/config"
+	"DevCode/dto"
+	"DevCode/events"
+	"DevCode/types"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ollama/ollama/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newEmbedModule은 /api/embed만 답하는 가짜 Ollama 서버에 연결된 모듈을 만든다
+func newEmbedModule(t *testing.T, handler http.HandlerFunc) (*OllamaModule, chan dto.EmbedResultData) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	serverUrl, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	bus, err := events.NewEventBus(config.EventBusConfig{PoolSize: 10}, zap.NewNop())
+	require.NoError(t, err)
+	t.Cleanup(bus.Close)
+
+	module := NewOllamaModule(bus, config.OllamaServiceConfig{MessageLimit: 100, DefaultToolCallSize: 5, Url: serverUrl, Model: "chat-model"}, zap.NewNop())
+	results := make(chan dto.EmbedResultData, 1)
+	events.Subscribe(bus, bus.EmbedResultEvent, TestModule, func(event events.Event[dto.EmbedResultData]) {
+		results <- event.Data
+	})
+	return module, results
+}
+
+func TestOllamaModule_Embed(t *testing.T) {
+	var received api.EmbedRequest
+	module, results := newEmbedModule(t, func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "/api/embed", request.URL.Path)
+		require.NoError(t, json.NewDecoder(request.Body).Decode(&received))
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(api.EmbedResponse{Model: received.Model, Embeddings: [][]float32{{0.1, 0.2}, {0.3, 0.4}}})
+	})
+
+	id := types.NewCreateID()
+	events.Publish(module.bus, module.bus.EmbedRequestEvent, events.Event[dto.EmbedRequestData]{
+		Data:      dto.EmbedRequestData{ID: id, Model: "embed-model", Inputs: []string{"first", "second"}},
+		TimeStamp: time.Now(),
+		Source:    TestModule,
+	})
+
+	select {
+	case result := <-results:
+		require.NoError(t, result.Error)
+		assert.Equal(t, id, result.ID)
+		assert.Equal(t, [][]float32{{0.1, 0.2}, {0.3, 0.4}}, result.Embeddings)
+	case <-time.After(2 * time.Second):
+		t.Fatal("embed result was not published")
+	}
+	assert.Equal(t, "embed-model", received.Model)
+	assert.Equal(t, []any{"first", "second"}, received.Input)
+}
+
+func TestOllamaModule_EmbedError(t *testing.T) {
+	module, results := newEmbedModule(t, func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(writer).Encode(map[string]string{"error": "model \"embed-model\" not found"})
+	})
+
+	module.Embed(dto.EmbedRequestData{ID: types.NewCreateID(), Model: "embed-model", Inputs: []string{"first"}})
+
+	select {
+	case result := <-results:
+		require.Error(t, result.Error)
+		assert.Contains(t, result.Error.Error(), "ollama pull embed-model")
+		assert.Contains(t, result.Error.Error(), "not found")
+	case <-time.After(2 * time.Second):
+		t.Fatal("embed result was not published")
+	}
+}
@@ -85,6 +85,17 @@ func (instance *MessageManager) AddToolMessage(content string) {
 	instance.checkMessageLimit()
 }
 
+func (instance *MessageManager) AddToolImageMessage(content string, images []api.ImageData) {
+	instance.messageMutex.Lock()
+	defer instance.messageMutex.Unlock()
+	instance.messages = append(instance.messages, api.Message{
+		Role:    Tool,
+		Content: content,
+		Images:  images,
+	})
+	instance.checkMessageLimit()
+}
+
 func (instance *MessageManager) Clear() {
 	instance.messageMutex.Lock()
 	defer instance.messageMutex.Unlock()
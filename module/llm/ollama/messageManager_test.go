@@ -4,6 +4,7 @@ import (
 	"DevCode/config"
 	"testing"
 
+	"github.com/ollama/ollama/api"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -125,6 +126,22 @@ func TestMessageManager_AddToolMessage(t *testing.T) {
 	assert.Equal(t, content, manager.messages[0].Content)
 }
 
+func TestMessageManager_AddToolImageMessage(t *testing.T) {
+	ollamaConfig := config.OllamaServiceConfig{
+		DefaultSystemMessageLength: 5,
+		MessageLimit:               100,
+	}
+	manager := NewMessageManager(ollamaConfig)
+
+	images := []api.ImageData{api.ImageData("png-bytes")}
+	manager.AddToolImageMessage("Tool result", images)
+
+	assert.Equal(t, 1, len(manager.messages))
+	assert.Equal(t, Tool, manager.messages[0].Role)
+	assert.Equal(t, "Tool result", manager.messages[0].Content)
+	assert.Equal(t, images, manager.messages[0].Images)
+}
+
 func TestMessageManager_Clear(t *testing.T) {
 	ollamaConfig := config.OllamaServiceConfig{
 		DefaultSystemMessageLength: 5,
@@ -7,13 +7,35 @@ import (
 	"DevCode/events"
 	"DevCode/types"
 	"DevCode/utils"
+	"context"
+	"fmt"
 	"net/http"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/ollama/ollama/api"
+	"github.com/ollama/ollama/types/model"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 )
 
+const (
+	NoVisionNotice = "[The tool returned %d image(s), but the current model (%s) does not support images. Tell the user you cannot see the image and ask them to describe it or switch to a vision model such as llava or qwen2.5vl.]\n"
+
+	VisionTimeout = 5 * time.Second
+	// VisionRetry is how long a failed capability lookup is remembered before
+	// the model is asked again.
+	VisionRetry = 30 * time.Second
+)
+
+// visionCheck is a cached capability lookup. A failed lookup counts as no
+// vision until retryAt; a successful one never expires.
+type visionCheck struct {
+	supported bool
+	retryAt   time.Time
+}
+
 type OllamaModule struct {
 	client         *api.Client
 	config         config.OllamaServiceConfig
@@ -23,6 +45,15 @@ type OllamaModule struct {
 
 	StreamManager IStreamManager
 	logger        *zap.Logger
+
+	// vision caches whether each model accepts images, keyed by model name.
+	vision      map[string]visionCheck
+	visionMutex sync.Mutex
+	visionGroup singleflight.Group
+
+	subAgents     map[types.RequestID]*SubAgent
+	environment   string
+	subAgentMutex sync.Mutex
 }
 
 func NewOllamaModule(bus *events.EventBus, config config.OllamaServiceConfig, logger *zap.Logger) *OllamaModule {
@@ -35,6 +66,7 @@ func NewOllamaModule(bus *events.EventBus, config config.OllamaServiceConfig, lo
 		toolManager:    NewToolManager(config),
 		StreamManager:  NewStreamManager(config),
 		logger:         logger,
+		subAgents:      make(map[types.RequestID]*SubAgent),
 	}
 	module.messageManager.AddSystemMessage(config.Prompt)
 	module.Subscribe()
@@ -48,7 +80,9 @@ func (instance *OllamaModule) Subscribe() {
 		instance.CallApi(event.Data.RequestID)
 	})
 	events.Subscribe(instance.bus, instance.bus.UpdateEnvironmentEvent, constants.LLMModule, func(event events.Event[dto.EnvironmentUpdateData]) {
-		instance.messageManager.SetEnvironmentMessage(utils.EnvironmentUpdateDataToString(event.Data))
+		environment := utils.EnvironmentUpdateDataToString(event.Data)
+		instance.messageManager.SetEnvironmentMessage(environment)
+		instance.SetEnvironment(environment)
 	})
 	events.Subscribe(instance.bus, instance.bus.UpdateToolListEvent, constants.LLMModule, func(event events.Event[dto.ToolListUpdateData]) {
 		instance.toolManager.RegisterToolList(event.Data.List)
@@ -59,11 +93,20 @@ func (instance *OllamaModule) Subscribe() {
 	events.Subscribe(instance.bus, instance.bus.ToolResultEvent, constants.LLMModule, func(event events.Event[dto.ToolResultData]) {
 		instance.ProcessToolResult(event.Data)
 	})
+	events.Subscribe(instance.bus, instance.bus.SubAgentRequestEvent, constants.LLMModule, func(event events.Event[dto.SubAgentRequestData]) {
+		instance.StartSubAgent(event.Data)
+	})
+	events.Subscribe(instance.bus, instance.bus.EmbedRequestEvent, constants.LLMModule, func(event events.Event[dto.EmbedRequestData]) {
+		instance.Embed(event.Data)
+	})
 }
 
 func (instance *OllamaModule) ProcessToolResult(data dto.ToolResultData) {
+	if instance.ProcessSubAgentToolResult(data) {
+		return
+	}
 	if instance.toolManager.HasToolCall(data.RequestID, data.ToolCallID) {
-		instance.messageManager.AddToolMessage(data.ToolResult)
+		instance.AddToolResultMessage(data)
 		instance.toolManager.CompleteToolCall(data.RequestID, data.ToolCallID)
 		if !instance.toolManager.HasPendingCalls(data.RequestID) {
 			instance.toolManager.ClearRequest(data.RequestID)
@@ -77,6 +120,67 @@ func (instance *OllamaModule) ProcessToolResult(data dto.ToolResultData) {
 	}
 }
 
+func (instance *OllamaModule) AddToolResultMessage(data dto.ToolResultData) {
+	instance.addToolResult(instance.messageManager, instance.config.Model, data)
+}
+
+// addToolResult adds a tool result to a conversation on model, with its
+// images only if the model can see them.
+func (instance *OllamaModule) addToolResult(messageManager IMessageManager, model string, data dto.ToolResultData) {
+	if len(data.Images) == 0 {
+		messageManager.AddToolMessage(data.ToolResult)
+		return
+	}
+	if !instance.SupportsVision(model) {
+		messageManager.AddToolMessage(data.ToolResult + fmt.Sprintf(NoVisionNotice, len(data.Images), model))
+		return
+	}
+	images := make([]api.ImageData, 0, len(data.Images))
+	for _, image := range data.Images {
+		images = append(images, api.ImageData(image))
+	}
+	messageManager.AddToolImageMessage(data.ToolResult, images)
+}
+
+func (instance *OllamaModule) SupportsVision(name string) bool {
+	instance.visionMutex.Lock()
+	check, checked := instance.vision[name]
+	instance.visionMutex.Unlock()
+	if checked && (check.retryAt.IsZero() || time.Now().Before(check.retryAt)) {
+		return check.supported
+	}
+	if instance.client == nil {
+		return false
+	}
+	// Show runs without the lock, and concurrent lookups for one model share it.
+	supported, _, _ := instance.visionGroup.Do(name, func() (any, error) {
+		return instance.lookupVision(name), nil
+	})
+	return supported.(bool)
+}
+
+func (instance *OllamaModule) lookupVision(name string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), VisionTimeout)
+	defer cancel()
+	check := visionCheck{}
+	response, err := instance.client.Show(ctx, &api.ShowRequest{Model: name})
+	if err != nil {
+		instance.logger.Warn("Fail to read model capabilities",
+			zap.String("model", name),
+			zap.Error(err))
+		check.retryAt = time.Now().Add(VisionRetry)
+	} else {
+		check.supported = slices.Contains(response.Capabilities, model.CapabilityVision)
+	}
+	instance.visionMutex.Lock()
+	defer instance.visionMutex.Unlock()
+	if instance.vision == nil {
+		instance.vision = make(map[string]visionCheck)
+	}
+	instance.vision[name] = check
+	return check.supported
+}
+
 func (instance *OllamaModule) UpdateEnvironmentToolList() {
 	events.Publish(instance.bus, instance.bus.RequestEnvironmentEvent, events.Event[dto.EnvironmentRequestData]{
 		Data: dto.EnvironmentRequestData{
@@ -142,4 +246,5 @@ func (instance *OllamaModule) ProcessToolCalls(requestID types.RequestID, ToolCa
 func (instance *OllamaModule) CancelStream(requestID types.RequestID) {
 	instance.StreamManager.CancelStream(requestID)
 	instance.toolManager.ClearRequest(requestID)
+	instance.CancelSubAgents(requestID)
 }
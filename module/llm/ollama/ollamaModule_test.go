@@ -6,7 +6,13 @@ import (
 	"DevCode/dto"
 	"DevCode/events"
 	"DevCode/types"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -45,6 +51,10 @@ func (m *MockMessageManager) AddToolMessage(content string) {
 	m.Called(content)
 }
 
+func (m *MockMessageManager) AddToolImageMessage(content string, images []api.ImageData) {
+	m.Called(content, images)
+}
+
 func (m *MockMessageManager) Clear() {
 	m.Called()
 }
@@ -196,6 +206,140 @@ func TestOllamaModule_ProcessToolResult_ValidToolCall(t *testing.T) {
 	mockToolManager.AssertExpectations(t)
 }
 
+func TestOllamaModule_AddToolResultMessage_Vision(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "/api/show", request.URL.Path)
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(`{"capabilities":["completion","vision"]}`))
+	}))
+	defer server.Close()
+	serverUrl, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	mockMessageManager := &MockMessageManager{}
+	module := &OllamaModule{
+		client:         api.NewClient(serverUrl, http.DefaultClient),
+		config:         config.OllamaServiceConfig{Model: "llava"},
+		messageManager: mockMessageManager,
+		logger:         zap.NewNop(),
+	}
+
+	images := []api.ImageData{api.ImageData("png-bytes")}
+	mockMessageManager.On("AddToolImageMessage", "image result", images).Return()
+
+	module.AddToolResultMessage(dto.ToolResultData{
+		ToolResult: "image result",
+		Images:     [][]byte{[]byte("png-bytes")},
+	})
+
+	mockMessageManager.AssertExpectations(t)
+	assert.True(t, module.SupportsVision("llava"))
+}
+
+func TestOllamaModule_SupportsVision_PerModel(t *testing.T) {
+	shown := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var show api.ShowRequest
+		require.NoError(t, json.NewDecoder(request.Body).Decode(&show))
+		shown[show.Model]++
+		writer.Header().Set("Content-Type", "application/json")
+		if show.Model == "llava" {
+			writer.Write([]byte(`{"capabilities":["completion","vision"]}`))
+			return
+		}
+		writer.Write([]byte(`{"capabilities":["completion"]}`))
+	}))
+	defer server.Close()
+	serverUrl, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	module := &OllamaModule{
+		client: api.NewClient(serverUrl, http.DefaultClient),
+		config: config.OllamaServiceConfig{Model: "llava"},
+		logger: zap.NewNop(),
+	}
+
+	// 모델마다 따로 확인하고 결과를 캐시한다
+	assert.True(t, module.SupportsVision("llava"))
+	assert.False(t, module.SupportsVision("qwen3:4b"))
+	assert.True(t, module.SupportsVision("llava"))
+	assert.False(t, module.SupportsVision("qwen3:4b"))
+	assert.Equal(t, map[string]int{"llava": 1, "qwen3:4b": 1}, shown)
+
+	// sub-agent의 이미지 결과는 sub-agent 모델 기준으로 판단한다
+	mockMessageManager := &MockMessageManager{}
+	mockMessageManager.On("AddToolMessage", mock.MatchedBy(func(content string) bool {
+		return strings.Contains(content, "the current model (qwen3:4b) does not support images")
+	})).Return()
+	module.addToolResult(mockMessageManager, "qwen3:4b", dto.ToolResultData{
+		ToolResult: "image result",
+		Images:     [][]byte{[]byte("png-bytes")},
+	})
+	mockMessageManager.AssertExpectations(t)
+}
+
+func TestOllamaModule_SupportsVision_Failure(t *testing.T) {
+	var shown atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		shown.Add(1)
+		<-release
+		http.Error(writer, "model not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+	serverUrl, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	module := &OllamaModule{
+		client: api.NewClient(serverUrl, http.DefaultClient),
+		logger: zap.NewNop(),
+	}
+
+	// 동시에 들어온 확인은 Show 한 번을 공유한다
+	var waitGroup sync.WaitGroup
+	for range 5 {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			assert.False(t, module.SupportsVision("missing"))
+		}()
+	}
+	require.Eventually(t, func() bool { return shown.Load() == 1 }, time.Second, 10*time.Millisecond)
+	close(release)
+	waitGroup.Wait()
+	assert.Equal(t, int32(1), shown.Load())
+
+	// 실패도 VisionRetry 동안은 캐시한다
+	assert.False(t, module.SupportsVision("missing"))
+	assert.Equal(t, int32(1), shown.Load())
+
+	module.vision["missing"] = visionCheck{retryAt: time.Now().Add(-time.Second)}
+	assert.False(t, module.SupportsVision("missing"))
+	assert.Equal(t, int32(2), shown.Load())
+}
+
+func TestOllamaModule_AddToolResultMessage_NoVision(t *testing.T) {
+	mockMessageManager := &MockMessageManager{}
+	module := &OllamaModule{
+		config:         config.OllamaServiceConfig{Model: "llama3.1:8b"},
+		messageManager: mockMessageManager,
+		logger:         zap.NewNop(),
+	}
+
+	// vision을 지원하지 않는 모델은 텍스트 안내로 대체
+	mockMessageManager.On("AddToolMessage", mock.MatchedBy(func(content string) bool {
+		return assert.Contains(t, content, "image result") &&
+			assert.Contains(t, content, "does not support images")
+	})).Return()
+
+	module.AddToolResultMessage(dto.ToolResultData{
+		ToolResult: "image result",
+		Images:     [][]byte{[]byte("png-bytes")},
+	})
+
+	mockMessageManager.AssertExpectations(t)
+}
+
 func TestOllamaModule_ProcessToolResult_InvalidToolCall(t *testing.T) {
 	logger := zap.NewNop()
 	ollamaConfig := config.OllamaServiceConfig{}
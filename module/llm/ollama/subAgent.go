@@ -0,0 +1,274 @@
+package ollama
+
+import (
+	"DevCode/constants"
+	"DevCode/dto"
+	"DevCode/events"
+	"DevCode/tools/task"
+	"DevCode/types"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ollama/ollama/api"
+	"go.uber.org/zap"
+)
+
+const (
+	NoAnswerNotice   = "The sub-agent finished without an answer."
+	UnavailableTool  = "<tool_use_error>\nTool %s is not available to this sub-agent\n</tool_use_error>\n"
+	SubAgentCanceled = "task was cancelled"
+)
+
+// SubAgent is a child conversation started by the Task tool. It has its own
+// messages and toolset and reports only its final answer to the parent.
+type SubAgent struct {
+	RequestID        types.RequestID
+	ParentRequestID  types.RequestID
+	ParentToolCallID types.ToolCallID
+	Model            string
+	Tools            []api.Tool
+	messageManager   IMessageManager
+	pending          map[types.ToolCallID]string
+	turns            int
+	ctx              context.Context
+	cancel           context.CancelFunc
+	mutex            sync.Mutex
+}
+
+func (instance *SubAgent) HasTool(name string) bool {
+	return slices.ContainsFunc(instance.Tools, func(tool api.Tool) bool {
+		return tool.Function.Name == name
+	})
+}
+
+func (instance *OllamaModule) StartSubAgent(data dto.SubAgentRequestData) {
+	tools, err := instance.SubAgentTools(data.Tools)
+	if err != nil {
+		instance.publishSubAgentResult(data.ParentToolCallID, "", err)
+		return
+	}
+	model := data.Model
+	if model == "" {
+		model = instance.config.Task.Model
+	}
+	if model == "" {
+		model = instance.config.Model
+	}
+
+	messageManager := NewMessageManager(instance.config)
+	messageManager.AddSystemMessage(instance.config.Task.Prompt)
+	messageManager.SetEnvironmentMessage(instance.Environment())
+	messageManager.AddUserMessage(data.Prompt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	agent := &SubAgent{
+		RequestID:        types.NewRequestID(),
+		ParentRequestID:  data.ParentRequestID,
+		ParentToolCallID: data.ParentToolCallID,
+		Model:            model,
+		Tools:            tools,
+		messageManager:   messageManager,
+		pending:          make(map[types.ToolCallID]string, instance.config.DefaultToolCallSize),
+		ctx:              ctx,
+		cancel:           cancel,
+	}
+	instance.subAgentMutex.Lock()
+	instance.subAgents[agent.RequestID] = agent
+	instance.subAgentMutex.Unlock()
+
+	go instance.RunSubAgent(agent)
+}
+
+// SubAgentTools returns the tools a sub-agent may use: the requested names, or
+// every configured task tool when none are requested. Task itself is never included.
+func (instance *OllamaModule) SubAgentTools(requested []string) ([]api.Tool, error) {
+	names := instance.config.Task.Tools
+	if len(requested) > 0 {
+		for _, name := range requested {
+			if !slices.Contains(instance.config.Task.Tools, name) || name == task.Name {
+				return nil, fmt.Errorf("tool %s is not available to sub-agents, choose from: %s", name, strings.Join(instance.config.Task.Tools, ", "))
+			}
+		}
+		names = requested
+	}
+	tools := make([]api.Tool, 0, len(names))
+	for _, tool := range instance.toolManager.GetToolList() {
+		if tool.Function.Name != task.Name && slices.Contains(names, tool.Function.Name) {
+			tools = append(tools, tool)
+		}
+	}
+	return tools, nil
+}
+
+// RunSubAgent asks the model for the sub-agent's next turn. Tool calls are
+// published like the parent's, tagged with the Task call as their parent; a
+// reply without tool calls is the final answer.
+func (instance *OllamaModule) RunSubAgent(agent *SubAgent) {
+	for {
+		agent.mutex.Lock()
+		agent.turns++
+		turns := agent.turns
+		agent.mutex.Unlock()
+		if turns > instance.config.Task.MaxTurns {
+			instance.FinishSubAgent(agent, "", fmt.Errorf("sub-agent stopped after %d turns without a final answer", instance.config.Task.MaxTurns))
+			return
+		}
+
+		message, err := instance.chatSubAgent(agent)
+		if err != nil {
+			if agent.ctx.Err() != nil {
+				return
+			}
+			instance.FinishSubAgent(agent, "", fmt.Errorf("sub-agent request failed: %w", err))
+			return
+		}
+		agent.messageManager.AddAssistantMessage(message.Content)
+		if len(message.ToolCalls) == 0 {
+			answer := strings.TrimSpace(message.Content)
+			if answer == "" {
+				answer = NoAnswerNotice
+			}
+			instance.FinishSubAgent(agent, answer, nil)
+			return
+		}
+		if instance.processSubAgentToolCalls(agent, message.ToolCalls) {
+			return
+		}
+	}
+}
+
+func (instance *OllamaModule) chatSubAgent(agent *SubAgent) (api.Message, error) {
+	request := api.ChatRequest{
+		Model:    agent.Model,
+		Messages: agent.messageManager.GetMessages(),
+		Tools:    agent.Tools,
+		Stream:   &[]bool{false}[0],
+	}
+	var message api.Message
+	err := instance.client.Chat(agent.ctx, &request, func(response api.ChatResponse) error {
+		message.Content += response.Message.Content
+		message.ToolCalls = append(message.ToolCalls, response.Message.ToolCalls...)
+		return nil
+	})
+	return message, err
+}
+
+// processSubAgentToolCalls publishes the calls the sub-agent may make and
+// answers the others itself. It reports whether any call is now pending.
+func (instance *OllamaModule) processSubAgentToolCalls(agent *SubAgent, toolCalls []api.ToolCall) bool {
+	calls := make([]dto.ToolCallData, 0, len(toolCalls))
+	agent.mutex.Lock()
+	for _, call := range toolCalls {
+		if !agent.HasTool(call.Function.Name) {
+			agent.messageManager.AddToolMessage(fmt.Sprintf(UnavailableTool, call.Function.Name))
+			continue
+		}
+		toolCallID := types.NewToolCallID()
+		agent.pending[toolCallID] = call.Function.Name
+		calls = append(calls, dto.ToolCallData{
+			RequestID:        agent.RequestID,
+			ToolCallID:       toolCallID,
+			ParentToolCallID: agent.ParentToolCallID,
+			ToolName:         call.Function.Name,
+			Parameters:       call.Function.Arguments,
+		})
+	}
+	agent.mutex.Unlock()
+
+	for _, call := range calls {
+		events.Publish(instance.bus, instance.bus.ToolCallEvent, events.Event[dto.ToolCallData]{
+			Data:      call,
+			TimeStamp: time.Now(),
+			Source:    constants.LLMModule,
+		})
+	}
+	return len(calls) > 0
+}
+
+// ProcessSubAgentToolResult adds a tool result to the sub-agent that made the
+// call and resumes it once all of its calls are answered. It reports whether
+// the result belonged to a sub-agent.
+func (instance *OllamaModule) ProcessSubAgentToolResult(data dto.ToolResultData) bool {
+	instance.subAgentMutex.Lock()
+	agent, exists := instance.subAgents[data.RequestID]
+	instance.subAgentMutex.Unlock()
+	if !exists {
+		return false
+	}
+
+	agent.mutex.Lock()
+	if _, exists := agent.pending[data.ToolCallID]; !exists {
+		agent.mutex.Unlock()
+		instance.logger.Warn("Sub-agent tool call not found",
+			zap.String("requestUUID", data.RequestID.String()),
+			zap.String("toolCallUUID", data.ToolCallID.String()))
+		return true
+	}
+	delete(agent.pending, data.ToolCallID)
+	instance.addToolResult(agent.messageManager, agent.Model, data)
+	done := len(agent.pending) == 0
+	agent.mutex.Unlock()
+
+	if done {
+		go instance.RunSubAgent(agent)
+	}
+	return true
+}
+
+// FinishSubAgent stops the sub-agent and reports its answer or error to the
+// Task call that started it. Only the first call for an agent has an effect.
+func (instance *OllamaModule) FinishSubAgent(agent *SubAgent, result string, err error) {
+	instance.subAgentMutex.Lock()
+	_, exists := instance.subAgents[agent.RequestID]
+	delete(instance.subAgents, agent.RequestID)
+	instance.subAgentMutex.Unlock()
+	if !exists {
+		return
+	}
+	agent.cancel()
+	instance.publishSubAgentResult(agent.ParentToolCallID, result, err)
+}
+
+// CancelSubAgents stops every sub-agent started by the given parent request.
+func (instance *OllamaModule) CancelSubAgents(parentRequestID types.RequestID) {
+	instance.subAgentMutex.Lock()
+	agents := make([]*SubAgent, 0, len(instance.subAgents))
+	for _, agent := range instance.subAgents {
+		if agent.ParentRequestID == parentRequestID {
+			agents = append(agents, agent)
+		}
+	}
+	instance.subAgentMutex.Unlock()
+	for _, agent := range agents {
+		instance.FinishSubAgent(agent, "", errors.New(SubAgentCanceled))
+	}
+}
+
+func (instance *OllamaModule) publishSubAgentResult(parentToolCallID types.ToolCallID, result string, err error) {
+	events.Publish(instance.bus, instance.bus.SubAgentResultEvent, events.Event[dto.SubAgentResultData]{
+		Data: dto.SubAgentResultData{
+			ParentToolCallID: parentToolCallID,
+			Result:           result,
+			Error:            err,
+		},
+		TimeStamp: time.Now(),
+		Source:    constants.LLMModule,
+	})
+}
+
+func (instance *OllamaModule) SetEnvironment(environment string) {
+	instance.subAgentMutex.Lock()
+	defer instance.subAgentMutex.Unlock()
+	instance.environment = environment
+}
+
+func (instance *OllamaModule) Environment() string {
+	instance.subAgentMutex.Lock()
+	defer instance.subAgentMutex.Unlock()
+	return instance.environment
+}
@@ -0,0 +1,260 @@
+package ollama
+
+import (
+	"DevCode/config"
+	"DevCode/dto"
+	"DevCode/events"
+	"DevCode/types"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/ollama/ollama/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeChat는 tool 결과가 없으면 Grep을 호출하고, 있으면 최종 답변을 돌려주는 가짜 Ollama 서버
+type fakeChat struct {
+	mutex    sync.Mutex
+	requests []api.ChatRequest
+	block    chan struct{}
+}
+
+func (instance *fakeChat) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	var chat api.ChatRequest
+	if err := json.NewDecoder(request.Body).Decode(&chat); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	instance.mutex.Lock()
+	instance.requests = append(instance.requests, chat)
+	instance.mutex.Unlock()
+	if instance.block != nil {
+		select {
+		case <-instance.block:
+		case <-request.Context().Done():
+			return
+		}
+	}
+
+	response := api.ChatResponse{Model: chat.Model, Done: true, Message: api.Message{Role: Assistant}}
+	last := chat.Messages[len(chat.Messages)-1]
+	if last.Role == Tool {
+		response.Message.Content = "Sessions are created in session/store.go:42"
+	} else {
+		response.Message.ToolCalls = []api.ToolCall{
+			{Function: api.ToolCallFunction{Name: "Grep", Arguments: map[string]any{"pattern": "NewSession"}}},
+			{Function: api.ToolCallFunction{Name: "Write", Arguments: map[string]any{"path": "/tmp/x"}}},
+		}
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(writer).Encode(response)
+}
+
+func (instance *fakeChat) Requests() []api.ChatRequest {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	return append([]api.ChatRequest(nil), instance.requests...)
+}
+
+func newSubAgentModule(t *testing.T, chat *fakeChat) (*OllamaModule, *events.EventBus) {
+	server := httptest.NewServer(chat)
+	t.Cleanup(server.Close)
+	serverUrl, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	bus, err := events.NewEventBus(config.EventBusConfig{PoolSize: 10}, zap.NewNop())
+	require.NoError(t, err)
+	t.Cleanup(bus.Close)
+
+	ollamaConfig := config.OllamaServiceConfig{
+		MessageLimit:        100,
+		DefaultToolCallSize: 5,
+		Url:                 serverUrl,
+		Model:               "parent-model",
+		Prompt:              "parent prompt",
+		Task: config.TaskConfig{
+			Tools:    []string{"Grep", "Read"},
+			MaxTurns: 5,
+			Prompt:   "sub-agent prompt",
+		},
+	}
+	module := NewOllamaModule(bus, ollamaConfig, zap.NewNop())
+	module.toolManager.RegisterToolList([]*mcp.Tool{{Name: "Grep"}, {Name: "Read"}, {Name: "Write"}, {Name: "Task"}})
+	return module, bus
+}
+
+func subscribeToolCalls(bus *events.EventBus) chan dto.ToolCallData {
+	calls := make(chan dto.ToolCallData, 10)
+	events.Subscribe(bus, bus.ToolCallEvent, TestModule, func(event events.Event[dto.ToolCallData]) {
+		calls <- event.Data
+	})
+	return calls
+}
+
+func subscribeSubAgentResults(bus *events.EventBus) chan dto.SubAgentResultData {
+	results := make(chan dto.SubAgentResultData, 10)
+	events.Subscribe(bus, bus.SubAgentResultEvent, TestModule, func(event events.Event[dto.SubAgentResultData]) {
+		results <- event.Data
+	})
+	return results
+}
+
+func TestOllamaModule_SubAgent_RunsUntilFinalAnswer(t *testing.T) {
+	chat := &fakeChat{}
+	module, bus := newSubAgentModule(t, chat)
+	calls := subscribeToolCalls(bus)
+	results := subscribeSubAgentResults(bus)
+
+	parentRequestID := types.NewRequestID()
+	parentToolCallID := types.NewToolCallID()
+	module.StartSubAgent(dto.SubAgentRequestData{
+		ParentRequestID:  parentRequestID,
+		ParentToolCallID: parentToolCallID,
+		Description:      "Find sessions",
+		Prompt:           "Find where sessions are created",
+	})
+
+	var call dto.ToolCallData
+	select {
+	case call = <-calls:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected sub-agent ToolCallEvent was not received within timeout")
+	}
+	// 허용된 Grep만 발행되고 Write는 sub-agent가 직접 거절한다
+	assert.Equal(t, "Grep", call.ToolName)
+	assert.Equal(t, parentToolCallID, call.ParentToolCallID)
+	assert.NotEqual(t, parentRequestID, call.RequestID)
+
+	module.ProcessToolResult(dto.ToolResultData{
+		RequestID:  call.RequestID,
+		ToolCallID: call.ToolCallID,
+		ToolResult: "<result>\nsession/store.go:42: func NewSession()\n</result>\n",
+	})
+
+	select {
+	case result := <-results:
+		require.NoError(t, result.Error)
+		assert.Equal(t, parentToolCallID, result.ParentToolCallID)
+		assert.Equal(t, "Sessions are created in session/store.go:42", result.Result)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected SubAgentResultEvent was not received within timeout")
+	}
+	select {
+	case extra := <-calls:
+		t.Fatalf("unexpected tool call %s", extra.ToolName)
+	default:
+	}
+
+	requests := chat.Requests()
+	require.Len(t, requests, 2)
+	first := requests[0]
+	assert.Equal(t, "parent-model", first.Model)
+	assert.Equal(t, "sub-agent prompt", first.Messages[0].Content)
+	assert.Equal(t, "Find where sessions are created", first.Messages[len(first.Messages)-1].Content)
+	names := make([]string, 0, len(first.Tools))
+	for _, tool := range first.Tools {
+		names = append(names, tool.Function.Name)
+	}
+	assert.Equal(t, []string{"Grep", "Read"}, names)
+	second := requests[1].Messages
+	assert.Contains(t, second[len(second)-2].Content, "Tool Write is not available")
+	assert.Contains(t, second[len(second)-1].Content, "func NewSession()")
+
+	// 부모 대화에는 아무것도 추가되지 않는다
+	for _, message := range module.messageManager.GetMessages() {
+		assert.NotEqual(t, User, message.Role)
+	}
+	module.subAgentMutex.Lock()
+	defer module.subAgentMutex.Unlock()
+	assert.Empty(t, module.subAgents)
+}
+
+func TestOllamaModule_SubAgent_ModelAndTools(t *testing.T) {
+	chat := &fakeChat{}
+	module, bus := newSubAgentModule(t, chat)
+	calls := subscribeToolCalls(bus)
+
+	module.StartSubAgent(dto.SubAgentRequestData{
+		ParentRequestID:  types.NewRequestID(),
+		ParentToolCallID: types.NewToolCallID(),
+		Prompt:           "Read the README",
+		Model:            "small-model",
+		Tools:            []string{"Read"},
+	})
+	select {
+	case <-calls:
+		t.Fatal("Grep is not in the requested toolset and must not be published")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	requests := chat.Requests()
+	require.NotEmpty(t, requests)
+	assert.Equal(t, "small-model", requests[0].Model)
+	require.Len(t, requests[0].Tools, 1)
+	assert.Equal(t, "Read", requests[0].Tools[0].Function.Name)
+}
+
+func TestOllamaModule_SubAgent_RejectsUnavailableTools(t *testing.T) {
+	chat := &fakeChat{}
+	module, bus := newSubAgentModule(t, chat)
+	results := subscribeSubAgentResults(bus)
+
+	for _, tools := range [][]string{{"Write"}, {"Task"}} {
+		module.StartSubAgent(dto.SubAgentRequestData{
+			ParentToolCallID: types.NewToolCallID(),
+			Prompt:           "Change things",
+			Tools:            tools,
+		})
+		select {
+		case result := <-results:
+			assert.EqualError(t, result.Error, "tool "+tools[0]+" is not available to sub-agents, choose from: Grep, Read")
+		case <-time.After(2 * time.Second):
+			t.Fatal("Expected SubAgentResultEvent was not received within timeout")
+		}
+	}
+	assert.Empty(t, chat.Requests())
+}
+
+func TestOllamaModule_SubAgent_CancelledWithParent(t *testing.T) {
+	chat := &fakeChat{block: make(chan struct{})}
+	defer close(chat.block)
+	module, bus := newSubAgentModule(t, chat)
+	results := subscribeSubAgentResults(bus)
+
+	parentRequestID := types.NewRequestID()
+	parentToolCallID := types.NewToolCallID()
+	module.StartSubAgent(dto.SubAgentRequestData{
+		ParentRequestID:  parentRequestID,
+		ParentToolCallID: parentToolCallID,
+		Prompt:           "Search everything",
+	})
+	require.Eventually(t, func() bool { return len(chat.Requests()) == 1 }, 2*time.Second, 10*time.Millisecond)
+
+	// 다른 요청의 취소는 영향을 주지 않는다
+	module.CancelStream(types.NewRequestID())
+	module.CancelStream(parentRequestID)
+
+	select {
+	case result := <-results:
+		assert.Equal(t, parentToolCallID, result.ParentToolCallID)
+		assert.EqualError(t, result.Error, SubAgentCanceled)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected SubAgentResultEvent was not received within timeout")
+	}
+	select {
+	case result := <-results:
+		t.Fatalf("unexpected second result %+v", result)
+	case <-time.After(100 * time.Millisecond):
+	}
+	module.subAgentMutex.Lock()
+	defer module.subAgentMutex.Unlock()
+	assert.Empty(t, module.subAgents)
+}
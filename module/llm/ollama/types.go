@@ -13,6 +13,7 @@ type IMessageManager interface {
 	AddUserMessage(content string)
 	AddAssistantMessage(content string)
 	AddToolMessage(content string)
+	AddToolImageMessage(content string, images []api.ImageData)
 	Clear()
 	GetMessages() []api.Message
 }
@@ -6,11 +6,35 @@ import (
 	"DevCode/constants"
 	"DevCode/dto"
 	"DevCode/events"
+	"DevCode/tools"
+	"DevCode/tools/applypatch"
+	"DevCode/tools/askuser"
+	"DevCode/tools/bash"
+	"DevCode/tools/diagnostics"
+	"DevCode/tools/edit"
+	"DevCode/tools/fileops"
+	"DevCode/tools/git"
+	"DevCode/tools/glob"
+	"DevCode/tools/gosymbols"
+	"DevCode/tools/grep"
 	"DevCode/tools/list"
+	"DevCode/tools/lsp"
+	"DevCode/tools/multiedit"
+	"DevCode/tools/notebook"
 	"DevCode/tools/read"
+	"DevCode/tools/replace"
+	"DevCode/tools/repomap"
+	"DevCode/tools/runtests"
+	"DevCode/tools/semantic"
+	"DevCode/tools/task"
+	"DevCode/tools/todo"
+	"DevCode/tools/webfetch"
+	"DevCode/tools/write"
 	"DevCode/types"
 	"context"
 	"fmt"
+	"maps"
+	"os"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -20,7 +44,13 @@ import (
 type McpModule struct {
 	client        *mcp.Client
 	clientSession *mcp.ClientSession
+	childSession  *mcp.ClientSession
 	toolServer    *mcp.Server
+	config        config.McpServiceConfig
+	shells        *bash.ShellRegistry
+	languages     *lsp.Manager
+	workspace     *fileops.Workspace
+	trash         *fileops.Trash
 	bus           *events.EventBus
 	ctx           context.Context
 	logger        *zap.Logger
@@ -40,10 +70,16 @@ func NewMcpModule(bus *events.EventBus, config config.McpServiceConfig, logger *
 		client:     mcpClient,
 		bus:        bus,
 		toolServer: mcpServer,
+		config:     config,
+		shells:     bash.NewShellRegistry(),
+		languages:  lsp.NewManager(config.Lsp, workingDirectory()),
+		workspace:  fileops.NewWorkspace(workingDirectory()),
 		ctx:        context.Background(),
 		logger:     logger,
 	}
 
+	module.trash = fileops.NewTrash(module.workspace.Root)
+
 	serverTran, clientTrans := mcp.NewInMemoryTransports()
 
 	module.InitTools()
@@ -60,6 +96,18 @@ func NewMcpModule(bus *events.EventBus, config config.McpServiceConfig, logger *
 
 	module.clientSession, _ = module.client.Connect(module.ctx, clientTrans)
 
+	// The server handles one call at a time per session, and a Task call waits
+	// for its sub-agent, so sub-agent tool calls go through a session of their own.
+	childServerTrans, childClientTrans := mcp.NewInMemoryTransports()
+	if _, err := module.toolServer.Connect(module.ctx, childServerTrans); err != nil {
+		module.logger.Error("", zap.Error(devcodeerror.Wrap(
+			err,
+			devcodeerror.FailRunMcpServer,
+			"Fail Connect Sub-agent MCP Session",
+		)))
+	}
+	module.childSession, _ = module.client.Connect(module.ctx, childClientTrans)
+
 	module.Subscribe()
 	return module
 }
@@ -71,11 +119,58 @@ func (instance *McpModule) Subscribe() {
 	events.Subscribe(instance.bus, instance.bus.AcceptToolEvent, constants.McpModule, func(event events.Event[dto.ToolCallData]) {
 		instance.ToolCall(event.Data)
 	})
+	events.Subscribe(instance.bus, instance.bus.RestoreRequestEvent, constants.McpModule, func(event events.Event[dto.RestoreRequestData]) {
+		instance.Restore(event.Data)
+	})
 }
 
 func (instance *McpModule) InitTools() {
 	InsertTool(instance, &read.Tool{})
 	InsertTool(instance, &list.Tool{})
+	InsertTool(instance, &write.Tool{})
+	InsertTool(instance, &edit.Tool{})
+	InsertTool(instance, &multiedit.Tool{})
+	InsertTool(instance, &applypatch.Tool{})
+	InsertTool(instance, &replace.Tool{})
+	InsertTool(instance, fileops.NewMoveTool(instance.workspace, instance.trash))
+	InsertTool(instance, fileops.NewCopyTool(instance.workspace, instance.trash))
+	InsertTool(instance, fileops.NewDeleteTool(instance.workspace, instance.trash))
+	InsertTool(instance, bash.NewTool(instance.config.Bash, instance.shells))
+	InsertTool(instance, bash.NewShellOutputTool(instance.shells))
+	InsertTool(instance, bash.NewKillShellTool(instance.shells))
+	InsertTool(instance, &glob.Tool{})
+	InsertTool(instance, &grep.Tool{})
+	InsertTool(instance, todo.NewTool(instance.bus))
+	InsertTool(instance, &notebook.Tool{})
+	InsertTool(instance, webfetch.NewTool())
+	InsertTool(instance, &git.StatusTool{})
+	InsertTool(instance, &git.DiffTool{})
+	InsertTool(instance, &git.LogTool{})
+	InsertTool(instance, &git.BlameTool{})
+	InsertTool(instance, &git.ShowTool{})
+	InsertTool(instance, gosymbols.NewTool())
+	InsertTool(instance, repomap.NewTool(instance.config.RepoMap))
+	InsertTool(instance, semantic.NewBusTool(instance.bus, instance.config.Index, instance.workspace.Root))
+	InsertTool(instance, lsp.NewDiagnosticsTool(instance.languages))
+	InsertTool(instance, lsp.NewDefinitionTool(instance.languages))
+	InsertTool(instance, lsp.NewReferencesTool(instance.languages))
+	InsertTool(instance, lsp.NewHoverTool(instance.languages))
+	InsertTool(instance, &runtests.Tool{})
+	InsertTool(instance, diagnostics.NewTool(instance.config.Diagnostics))
+	InsertTool(instance, task.NewTool(instance.bus))
+	InsertTool(instance, askuser.NewTool(instance.bus))
+}
+
+func (instance *McpModule) Close() {
+	instance.shells.KillAll()
+	instance.languages.Shutdown()
+}
+
+func workingDirectory() string {
+	if cwd, err := os.Getwd(); err == nil {
+		return cwd
+	}
+	return "."
 }
 
 func InsertTool[T any](server *McpModule, tool types.Tool[T]) {
@@ -90,12 +185,22 @@ func InsertTool[T any](server *McpModule, tool types.Tool[T]) {
 
 func (instance *McpModule) ToolCall(data dto.ToolCallData) {
 
+	meta := mcp.Meta{
+		tools.MetaRequestID:  data.RequestID.String(),
+		tools.MetaToolCallID: data.ToolCallID.String(),
+	}
+	maps.Copy(meta, data.Meta)
 	params := &mcp.CallToolParams{
+		Meta:      meta,
 		Name:      data.ToolName,
 		Arguments: data.Parameters,
 	}
 
-	result, err := instance.clientSession.CallTool(instance.ctx, params)
+	session := instance.clientSession
+	if !data.ParentToolCallID.IsNil() {
+		session = instance.childSession
+	}
+	result, err := session.CallTool(instance.ctx, params)
 
 	if err != nil {
 		instance.logger.Error("도구 호출 실패",
@@ -132,6 +237,16 @@ func (instance *McpModule) ToolCall(data dto.ToolCallData) {
 	})
 }
 
+func (instance *McpModule) Restore(data dto.RestoreRequestData) {
+	events.Publish(instance.bus, instance.bus.RestoreResultEvent, events.Event[dto.RestoreResultData]{
+		Data: dto.RestoreResultData{
+			Message: fileops.RunRestore(instance.workspace, instance.trash, data.Argument),
+		},
+		TimeStamp: time.Now(),
+		Source:    constants.McpModule,
+	})
+}
+
 func (instance *McpModule) PublishToolList() {
 
 	mcpToolList := make([]*mcp.Tool, 0, 10)
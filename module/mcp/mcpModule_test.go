@@ -92,6 +92,64 @@ func TestMcpModuleInitTools(t *testing.T) {
 
 	assert.True(t, toolNames["Read"], "Read tool should be registered")
 	assert.True(t, toolNames["List"], "List tool should be registered")
+	assert.True(t, toolNames["Write"], "Write tool should be registered")
+	assert.True(t, toolNames["Edit"], "Edit tool should be registered")
+	assert.True(t, toolNames["MultiEdit"], "MultiEdit tool should be registered")
+	assert.True(t, toolNames["ApplyPatch"], "ApplyPatch tool should be registered")
+	assert.True(t, toolNames["Bash"], "Bash tool should be registered")
+	assert.True(t, toolNames["ShellOutput"], "ShellOutput tool should be registered")
+	assert.True(t, toolNames["KillShell"], "KillShell tool should be registered")
+	assert.True(t, toolNames["Glob"], "Glob tool should be registered")
+	assert.True(t, toolNames["Grep"], "Grep tool should be registered")
+	assert.True(t, toolNames["TodoWrite"], "TodoWrite tool should be registered")
+	assert.True(t, toolNames["NotebookEdit"], "NotebookEdit tool should be registered")
+	assert.True(t, toolNames["WebFetch"], "WebFetch tool should be registered")
+	for _, name := range []string{"GitStatus", "GitDiff", "GitLog", "GitBlame", "GitShow"} {
+		assert.True(t, toolNames[name], name+" tool should be registered")
+	}
+	assert.True(t, toolNames["GoSymbols"], "GoSymbols tool should be registered")
+	for _, name := range []string{"LspDiagnostics", "GoToDefinition", "FindReferences", "Hover"} {
+		assert.True(t, toolNames[name], name+" tool should be registered")
+	}
+	assert.True(t, toolNames["RunTests"], "RunTests tool should be registered")
+	assert.True(t, toolNames["Diagnostics"], "Diagnostics tool should be registered")
+	assert.True(t, toolNames["Task"], "Task tool should be registered")
+	for _, name := range []string{"Move", "Copy", "Delete"} {
+		assert.True(t, toolNames[name], name+" tool should be registered")
+	}
+	assert.True(t, toolNames["Replace"], "Replace tool should be registered")
+	assert.True(t, toolNames["RepoMap"], "RepoMap tool should be registered")
+	assert.True(t, toolNames["SemanticSearch"], "SemanticSearch tool should be registered")
+	assert.True(t, toolNames["AskUser"], "AskUser tool should be registered")
+}
+
+func TestMcpModuleClose(t *testing.T) {
+	busConfig := config.EventBusConfig{PoolSize: 10}
+	bus, err := events.NewEventBus(busConfig, zap.NewNop())
+	require.NoError(t, err)
+
+	mcpConfig := config.McpServiceConfig{
+		Name:          "test-client",
+		Version:       "1.0.0",
+		ServerName:    "test-server",
+		ServerVersion: "1.0.0",
+	}
+	logger := zap.NewNop()
+
+	module := NewMcpModule(bus, mcpConfig, logger)
+
+	// 백그라운드 shell 실행
+	shell, err := module.shells.Start("/bin/sh", "sleep 30", t.TempDir(), 100)
+	require.NoError(t, err)
+
+	// Close 시 실행 중인 shell이 모두 종료되어야 함
+	module.Close()
+
+	select {
+	case <-shell.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Background shell was not killed on Close")
+	}
 }
 
 func TestMcpModulePublishToolList(t *testing.T) {
@@ -174,6 +232,111 @@ func TestMcpModuleToolCall(t *testing.T) {
 	}
 }
 
+func TestMcpModuleTaskToolCall(t *testing.T) {
+	busConfig := config.EventBusConfig{PoolSize: 10}
+	bus, err := events.NewEventBus(busConfig, zap.NewNop())
+	require.NoError(t, err)
+
+	mcpConfig := config.McpServiceConfig{
+		Name:          "test-client",
+		Version:       "1.0.0",
+		ServerName:    "test-server",
+		ServerVersion: "1.0.0",
+	}
+	module := NewMcpModule(bus, mcpConfig, zap.NewNop())
+
+	received := make(chan dto.ToolRawResultData, 2)
+	events.Subscribe(bus, bus.ToolRawResultEvent, constants.McpModule, func(event events.Event[dto.ToolRawResultData]) {
+		received <- event.Data
+	})
+
+	// 가짜 sub-agent: Task 호출이 진행 중인 동안 자식 도구를 호출하고 그 결과로 답한다
+	requests := make(chan dto.SubAgentRequestData, 1)
+	events.Subscribe(bus, bus.SubAgentRequestEvent, constants.LLMModule, func(event events.Event[dto.SubAgentRequestData]) {
+		requests <- event.Data
+		module.ToolCall(dto.ToolCallData{
+			RequestID:        types.NewRequestID(),
+			ToolCallID:       types.NewToolCallID(),
+			ParentToolCallID: event.Data.ParentToolCallID,
+			ToolName:         "List",
+			Parameters:       map[string]any{"path": t.TempDir()},
+		})
+		events.Publish(bus, bus.SubAgentResultEvent, events.Event[dto.SubAgentResultData]{
+			Data: dto.SubAgentResultData{
+				ParentToolCallID: event.Data.ParentToolCallID,
+				Result:           "child finished",
+			},
+			TimeStamp: time.Now(),
+			Source:    constants.LLMModule,
+		})
+	})
+
+	requestID := types.NewRequestID()
+	toolCallID := types.NewToolCallID()
+	go module.ToolCall(dto.ToolCallData{
+		RequestID:  requestID,
+		ToolCallID: toolCallID,
+		ToolName:   "Task",
+		Parameters: map[string]any{"description": "List files", "prompt": "List the files"},
+	})
+
+	select {
+	case request := <-requests:
+		// _meta로 부모 요청과 도구 호출 ID가 전달된다
+		assert.Equal(t, requestID, request.ParentRequestID)
+		assert.Equal(t, toolCallID, request.ParentToolCallID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected SubAgentRequestEvent was not received within timeout")
+	}
+
+	results := make(map[types.ToolCallID]dto.ToolRawResultData, 2)
+	for len(results) < 2 {
+		select {
+		case data := <-received:
+			results[data.ToolCallID] = data
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected ToolRawResultEvent was not received within timeout")
+		}
+	}
+	parent := results[toolCallID]
+	require.NotNil(t, parent.Result)
+	assert.False(t, parent.Result.IsError)
+	assert.Equal(t, "child finished", parent.Result.Content[0].(*mcp.TextContent).Text)
+}
+
+func TestMcpModuleRestore(t *testing.T) {
+	busConfig := config.EventBusConfig{PoolSize: 10}
+	bus, err := events.NewEventBus(busConfig, zap.NewNop())
+	require.NoError(t, err)
+
+	mcpConfig := config.McpServiceConfig{
+		Name:          "test-client",
+		Version:       "1.0.0",
+		ServerName:    "test-server",
+		ServerVersion: "1.0.0",
+	}
+	NewMcpModule(bus, mcpConfig, zap.NewNop())
+
+	received := make(chan dto.RestoreResultData, 1)
+	events.Subscribe(bus, bus.RestoreResultEvent, constants.Model, func(event events.Event[dto.RestoreResultData]) {
+		received <- event.Data
+	})
+
+	// /restore 명령은 이벤트로 전달되고 결과 메시지가 발행된다
+	events.Publish(bus, bus.RestoreRequestEvent, events.Event[dto.RestoreRequestData]{
+		Data:      dto.RestoreRequestData{Argument: "list"},
+		TimeStamp: time.Now(),
+		Source:    constants.Model,
+	})
+
+	select {
+	case data := <-received:
+		assert.Equal(t, "Trash is empty", data.Message)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected RestoreResultEvent was not received within timeout")
+	}
+}
+
 func TestMcpModuleToolCallWithInvalidTool(t *testing.T) {
 	busConfig := config.EventBusConfig{PoolSize: 10}
 	bus, err := events.NewEventBus(busConfig, zap.NewNop())
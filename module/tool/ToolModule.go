@@ -5,10 +5,15 @@ import (
 	"DevCode/constants"
 	"DevCode/dto"
 	"DevCode/events"
+	"DevCode/tools/applypatch"
+	"DevCode/tools/replace"
 	"DevCode/types"
+	"context"
 	"fmt"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.uber.org/zap"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 )
@@ -120,9 +125,19 @@ func (instance *ToolModule) ProcessToolResult(data dto.ToolRawResultData) {
 		return
 	}
 
+	var images [][]byte
 	builder.WriteString("<result>\n")
 	for _, content := range data.Result.Content {
-		builder.WriteString(content.(*mcp.TextContent).Text + "\n")
+		switch content := content.(type) {
+		case *mcp.TextContent:
+			builder.WriteString(content.Text + "\n")
+		case *mcp.ImageContent:
+			images = append(images, content.Data)
+			builder.WriteString(fmt.Sprintf("[image: %s, %d bytes]\n", content.MIMEType, len(content.Data)))
+		default:
+			instance.logger.Warn("Unsupported tool result content",
+				zap.String("tool_call_uuid", data.ToolCallID.String()))
+		}
 	}
 	builder.WriteString("</result>\n")
 	events.Publish(instance.bus, instance.bus.ToolResultEvent,
@@ -131,6 +146,7 @@ func (instance *ToolModule) ProcessToolResult(data dto.ToolRawResultData) {
 				RequestID:  data.RequestID,
 				ToolCallID: data.ToolCallID,
 				ToolResult: builder.String(),
+				Images:     images,
 			},
 			TimeStamp: time.Now(),
 			Source:    constants.ToolModule,
@@ -149,40 +165,90 @@ func (instance *ToolModule) ProcessToolResult(data dto.ToolRawResultData) {
 
 func (instance *ToolModule) ProcessToolCall(data dto.ToolCallData) {
 
+	toolInfo := instance.ToolInfo(data.ToolName, data.Parameters)
+	if data.ToolName == replace.Name && !IsPreview(data.ToolName, data.Parameters) {
+		summary, meta := PlanReplace(data.Parameters)
+		toolInfo += summary
+		data.Meta = meta
+	}
 	events.Publish(instance.bus, instance.bus.ToolUseReportEvent, events.Event[dto.ToolUseReportData]{
 		Data: dto.ToolUseReportData{
-			RequestID:  data.RequestID,
-			ToolCallID: data.ToolCallID,
-			ToolInfo:   instance.ToolInfo(data.ToolName, data.Parameters),
-			ToolStatus: constants.Call,
+			RequestID:        data.RequestID,
+			ToolCallID:       data.ToolCallID,
+			ParentToolCallID: data.ParentToolCallID,
+			ToolInfo:         toolInfo,
+			ToolStatus:       constants.Call,
 		},
 		TimeStamp: time.Now(),
 		Source:    constants.ToolModule,
 	})
-	for _, allowed := range instance.allowed {
-		if data.ToolName == allowed {
-			events.Publish(instance.bus, instance.bus.AcceptToolEvent, events.Event[dto.ToolCallData]{
-				Data:      data,
-				TimeStamp: time.Now(),
-				Source:    constants.ToolModule,
-			})
-			return
-		}
+	if slices.Contains(instance.allowed, data.ToolName) || IsPreview(data.ToolName, data.Parameters) {
+		events.Publish(instance.bus, instance.bus.AcceptToolEvent, events.Event[dto.ToolCallData]{
+			Data:      data,
+			TimeStamp: time.Now(),
+			Source:    constants.ToolModule,
+		})
+		return
 	}
 
 	instance.toolCallBuffer[data.ToolCallID] = data
 	events.Publish(instance.bus, instance.bus.RequestToolUseEvent, events.Event[dto.ToolUseReportData]{
 		Data: dto.ToolUseReportData{
-			RequestID:  data.RequestID,
-			ToolCallID: data.ToolCallID,
-			ToolInfo:   instance.ToolInfo(data.ToolName, data.Parameters),
-			ToolStatus: constants.Call,
+			RequestID:        data.RequestID,
+			ToolCallID:       data.ToolCallID,
+			ParentToolCallID: data.ParentToolCallID,
+			ToolInfo:         toolInfo,
+			ToolStatus:       constants.Call,
 		},
 		TimeStamp: time.Now(),
 		Source:    constants.ToolModule,
 	})
 }
 
+// IsPreview reports whether the call only shows what a tool would change, so
+// it can run without asking the user.
+func IsPreview(name string, parameters map[string]any) bool {
+	preview, _ := parameters["preview"].(bool)
+	return name == replace.Name && preview
+}
+
+// PlanReplace plans a Replace call once, before the user is asked. It returns
+// the match counts and skipped files to show with the approval, and the meta
+// that makes the Handler write exactly this plan or nothing.
+func PlanReplace(parameters map[string]any) (string, map[string]any) {
+	input, ok := replaceInput(parameters)
+	if !ok {
+		return "", nil
+	}
+	root, err := replace.Root(input.Path)
+	if err != nil {
+		return "", nil
+	}
+	changes, skipped, err := replace.Plan(context.Background(), root, input)
+	if err != nil {
+		// An empty plan still goes along, so nothing unreviewed can be written.
+		return fmt.Sprintf("\nCould not plan the replacement: %v", err), map[string]any{replace.MetaPlan: map[string]string{}}
+	}
+	summary := replace.Skipped(root, skipped)
+	if len(changes) > 0 {
+		summary = "\n" + replace.Counts(root, changes) + summary
+	}
+	return summary, map[string]any{replace.MetaPlan: replace.Hashes(changes)}
+}
+
+func replaceInput(parameters map[string]any) (replace.Input, bool) {
+	pattern, ok := parameters["pattern"].(string)
+	if !ok {
+		return replace.Input{}, false
+	}
+	input := replace.Input{Pattern: pattern}
+	input.Replacement, _ = parameters["replacement"].(string)
+	input.Glob, _ = parameters["glob"].(string)
+	input.Path, _ = parameters["path"].(string)
+	input.Regex, _ = parameters["regex"].(bool)
+	return input, true
+}
+
 func (instance *ToolModule) ToolInfo(name string, parameters map[string]any) string {
 	switch name {
 	case "Read":
@@ -190,6 +256,172 @@ func (instance *ToolModule) ToolInfo(name string, parameters map[string]any) str
 			return fmt.Sprintf("%s (%s)", name, filePath)
 		}
 		return name
+	case "Write", "Edit":
+		if filePath, ok := parameters["file_path"].(string); ok {
+			return fmt.Sprintf("%s (%s)", name, filePath)
+		}
+		return name
+	case "MultiEdit":
+		if filePath, ok := parameters["file_path"].(string); ok {
+			if edits, ok := parameters["edits"].([]any); ok {
+				return fmt.Sprintf("%s (%s, %d edits)", name, filePath, len(edits))
+			}
+			return fmt.Sprintf("%s (%s)", name, filePath)
+		}
+		return name
+	case "ApplyPatch":
+		patchText, ok := parameters["patch"].(string)
+		if !ok {
+			return name
+		}
+		patches, err := applypatch.Parse(patchText)
+		if err != nil {
+			return name
+		}
+		paths := make([]string, 0, len(patches))
+		for _, patch := range patches {
+			oldPath, newPath := applypatch.ResolvePaths(".", patch)
+			switch patch.Operation() {
+			case applypatch.Delete:
+				paths = append(paths, "-"+filepath.Clean(oldPath))
+			case applypatch.Create:
+				paths = append(paths, "+"+filepath.Clean(newPath))
+			case applypatch.Rename:
+				paths = append(paths, filepath.Clean(oldPath)+" -> "+filepath.Clean(newPath))
+			default:
+				paths = append(paths, filepath.Clean(newPath))
+			}
+		}
+		return fmt.Sprintf("%s (%s)", name, strings.Join(paths, ", "))
+	case "Replace":
+		input, ok := replaceInput(parameters)
+		if !ok {
+			return name
+		}
+		info := fmt.Sprintf("%s (%s → %s in %s)", name, input.Pattern, input.Replacement, input.Glob)
+		if IsPreview(name, parameters) {
+			return info + " preview"
+		}
+		return info
+	case "Bash":
+		if command, ok := parameters["command"].(string); ok {
+			return fmt.Sprintf("%s (%s)", name, command)
+		}
+		return name
+	case "ShellOutput", "KillShell":
+		if shellID, ok := parameters["shell_id"].(string); ok {
+			return fmt.Sprintf("%s (%s)", name, shellID)
+		}
+		return name
+	case "Glob", "Grep":
+		if pattern, ok := parameters["pattern"].(string); ok {
+			return fmt.Sprintf("%s (%s)", name, pattern)
+		}
+		return name
+	case "TodoWrite":
+		if todos, ok := parameters["todos"].([]any); ok {
+			return fmt.Sprintf("%s (%d todos)", name, len(todos))
+		}
+		return name
+	case "NotebookEdit":
+		if notebookPath, ok := parameters["notebook_path"].(string); ok {
+			return fmt.Sprintf("%s (%s)", name, notebookPath)
+		}
+		return name
+	case "GitStatus", "GitDiff", "GitLog", "GitBlame", "GitShow":
+		var details []string
+		for _, key := range []string{"revision", "file"} {
+			if value, ok := parameters[key].(string); ok && value != "" {
+				details = append(details, value)
+			}
+		}
+		if len(details) > 0 {
+			return fmt.Sprintf("%s (%s)", name, strings.Join(details, " "))
+		}
+		return name
+	case "GoSymbols":
+		var details []string
+		for _, key := range []string{"action", "symbol"} {
+			if value, ok := parameters[key].(string); ok && value != "" {
+				details = append(details, value)
+			}
+		}
+		if len(details) > 0 {
+			return fmt.Sprintf("%s (%s)", name, strings.Join(details, " "))
+		}
+		return name
+	case "RepoMap":
+		if path, ok := parameters["path"].(string); ok && path != "" {
+			return fmt.Sprintf("%s (%s)", name, path)
+		}
+		return name
+	case "AskUser":
+		if question, ok := parameters["question"].(string); ok && question != "" {
+			return fmt.Sprintf("%s (%s)", name, question)
+		}
+		return name
+	case "SemanticSearch":
+		if query, ok := parameters["query"].(string); ok && query != "" {
+			return fmt.Sprintf("%s (%q)", name, query)
+		}
+		return name
+	case "LspDiagnostics", "Diagnostics":
+		if path, ok := parameters["path"].(string); ok {
+			return fmt.Sprintf("%s (%s)", name, path)
+		}
+		return name
+	case "GoToDefinition", "FindReferences", "Hover":
+		path, ok := parameters["path"].(string)
+		if !ok {
+			return name
+		}
+		if line, ok := parameters["line"].(float64); ok {
+			path = fmt.Sprintf("%s:%d", path, int(line))
+		}
+		if symbol, ok := parameters["symbol"].(string); ok && symbol != "" {
+			return fmt.Sprintf("%s (%s %s)", name, path, symbol)
+		}
+		return fmt.Sprintf("%s (%s)", name, path)
+	case "RunTests":
+		var details []string
+		if packages, ok := parameters["packages"].([]any); ok {
+			for _, pattern := range packages {
+				if text, ok := pattern.(string); ok {
+					details = append(details, text)
+				}
+			}
+		}
+		if run, ok := parameters["run"].(string); ok && run != "" {
+			details = append(details, "-run "+run)
+		}
+		if len(details) > 0 {
+			return fmt.Sprintf("%s (%s)", name, strings.Join(details, " "))
+		}
+		return name
+	case "Move", "Copy":
+		source, ok := parameters["source"].(string)
+		if !ok {
+			return name
+		}
+		if destination, ok := parameters["destination"].(string); ok {
+			return fmt.Sprintf("%s (%s → %s)", name, source, destination)
+		}
+		return fmt.Sprintf("%s (%s)", name, source)
+	case "Delete":
+		if path, ok := parameters["path"].(string); ok {
+			return fmt.Sprintf("%s (%s)", name, path)
+		}
+		return name
+	case "Task":
+		if description, ok := parameters["description"].(string); ok && description != "" {
+			return fmt.Sprintf("%s (%s)", name, description)
+		}
+		return name
+	case "WebFetch":
+		if url, ok := parameters["url"].(string); ok {
+			return fmt.Sprintf("%s (%s)", name, url)
+		}
+		return name
 	case "List":
 		if path, ok := parameters["path"].(string); ok {
 			return fmt.Sprintf("%s (%s)", name, path)
@@ -5,7 +5,10 @@ import (
 	"DevCode/constants"
 	"DevCode/dto"
 	"DevCode/events"
+	"DevCode/tools/replace"
 	"DevCode/types"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -261,6 +264,46 @@ func TestToolModuleProcessToolResultSuccess(t *testing.T) {
 	}
 }
 
+func TestToolModuleProcessToolResultImage(t *testing.T) {
+	busConfig := config.EventBusConfig{PoolSize: 10}
+	bus, err := events.NewEventBus(busConfig, zap.NewNop())
+	require.NoError(t, err)
+
+	toolConfig := config.ToolServiceConfig{
+		Allowed: []string{},
+	}
+	logger := zap.NewNop()
+
+	module := NewToolModule(bus, toolConfig, logger)
+
+	toolResultReceived := make(chan dto.ToolResultData, 1)
+	events.Subscribe(bus, bus.ToolResultEvent, constants.ToolModule, func(event events.Event[dto.ToolResultData]) {
+		toolResultReceived <- event.Data
+	})
+
+	// 이미지 결과 데이터
+	requestID := types.NewRequestID()
+	toolCallID := types.NewToolCallID()
+	imageContent := &mcp.ImageContent{Data: []byte("png-bytes"), MIMEType: "image/png"}
+	module.ProcessToolResult(dto.ToolRawResultData{
+		RequestID:  requestID,
+		ToolCallID: toolCallID,
+		Result: &mcp.CallToolResult{
+			Content: []mcp.Content{imageContent},
+		},
+	})
+
+	// 이미지는 Images로 전달되고 텍스트에는 요약만 포함되어야 함
+	select {
+	case data := <-toolResultReceived:
+		require.Len(t, data.Images, 1)
+		assert.Equal(t, []byte("png-bytes"), data.Images[0])
+		assert.Contains(t, data.ToolResult, "[image: image/png, 9 bytes]")
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected ToolResultEvent was not received within timeout")
+	}
+}
+
 func TestToolModuleProcessToolResultError(t *testing.T) {
 	busConfig := config.EventBusConfig{PoolSize: 10}
 	bus, err := events.NewEventBus(busConfig, zap.NewNop())
@@ -514,6 +557,432 @@ func TestToolModuleToolInfoList(t *testing.T) {
 	assert.Equal(t, "List", result)
 }
 
+func TestToolModuleToolInfoWrite(t *testing.T) {
+	busConfig := config.EventBusConfig{PoolSize: 10}
+	bus, err := events.NewEventBus(busConfig, zap.NewNop())
+	require.NoError(t, err)
+
+	toolConfig := config.ToolServiceConfig{
+		Allowed: []string{},
+	}
+	logger := zap.NewNop()
+
+	module := NewToolModule(bus, toolConfig, logger)
+
+	// Write 도구 정보 테스트
+	parameters := map[string]any{
+		"file_path": "/test/file.txt",
+		"content":   "hello",
+	}
+	result := module.ToolInfo("Write", parameters)
+	assert.Equal(t, "Write (/test/file.txt)", result)
+
+	// file_path가 없는 경우
+	result = module.ToolInfo("Write", map[string]any{})
+	assert.Equal(t, "Write", result)
+}
+
+func TestToolModuleToolInfoMultiEdit(t *testing.T) {
+	busConfig := config.EventBusConfig{PoolSize: 10}
+	bus, err := events.NewEventBus(busConfig, zap.NewNop())
+	require.NoError(t, err)
+
+	toolConfig := config.ToolServiceConfig{
+		Allowed: []string{},
+	}
+	logger := zap.NewNop()
+
+	module := NewToolModule(bus, toolConfig, logger)
+
+	// MultiEdit 도구 정보 테스트
+	parameters := map[string]any{
+		"file_path": "/test/file.go",
+		"edits": []any{
+			map[string]any{"old_string": "a", "new_string": "b"},
+			map[string]any{"old_string": "c", "new_string": "d"},
+		},
+	}
+	result := module.ToolInfo("MultiEdit", parameters)
+	assert.Equal(t, "MultiEdit (/test/file.go, 2 edits)", result)
+
+	// edits가 없는 경우
+	result = module.ToolInfo("MultiEdit", map[string]any{"file_path": "/test/file.go"})
+	assert.Equal(t, "MultiEdit (/test/file.go)", result)
+
+	// file_path가 없는 경우
+	result = module.ToolInfo("MultiEdit", map[string]any{})
+	assert.Equal(t, "MultiEdit", result)
+}
+
+func TestToolModuleToolInfoApplyPatch(t *testing.T) {
+	busConfig := config.EventBusConfig{PoolSize: 10}
+	bus, err := events.NewEventBus(busConfig, zap.NewNop())
+	require.NoError(t, err)
+
+	toolConfig := config.ToolServiceConfig{
+		Allowed: []string{},
+	}
+	logger := zap.NewNop()
+
+	module := NewToolModule(bus, toolConfig, logger)
+
+	// 승인 시 패치가 건드리는 파일 목록이 보여야 함
+	patch := "--- a/main.go\n+++ b/main.go\n@@ -1 +1 @@\n-a\n+b\n" +
+		"--- /dev/null\n+++ b/docs/new.md\n@@ -0,0 +1 @@\n+new\n" +
+		"--- a/old.txt\n+++ /dev/null\n@@ -1 +0,0 @@\n-old\n"
+	result := module.ToolInfo("ApplyPatch", map[string]any{"patch": patch})
+	assert.Equal(t, "ApplyPatch (main.go, +docs/new.md, -old.txt)", result)
+
+	// 파싱할 수 없는 패치
+	result = module.ToolInfo("ApplyPatch", map[string]any{"patch": "not a diff"})
+	assert.Equal(t, "ApplyPatch", result)
+}
+
+func TestToolModuleToolInfoBash(t *testing.T) {
+	busConfig := config.EventBusConfig{PoolSize: 10}
+	bus, err := events.NewEventBus(busConfig, zap.NewNop())
+	require.NoError(t, err)
+
+	toolConfig := config.ToolServiceConfig{
+		Allowed: []string{},
+	}
+	logger := zap.NewNop()
+
+	module := NewToolModule(bus, toolConfig, logger)
+
+	// 승인 시 전체 명령어가 보여야 함
+	parameters := map[string]any{
+		"command":     "go build ./... && go test ./... -run TestSomething -count=1",
+		"description": "Build and test",
+	}
+	result := module.ToolInfo("Bash", parameters)
+	assert.Equal(t, "Bash (go build ./... && go test ./... -run TestSomething -count=1)", result)
+
+	// command가 없는 경우
+	result = module.ToolInfo("Bash", map[string]any{})
+	assert.Equal(t, "Bash", result)
+}
+
+func TestToolModuleToolInfoGit(t *testing.T) {
+	busConfig := config.EventBusConfig{PoolSize: 10}
+	bus, err := events.NewEventBus(busConfig, zap.NewNop())
+	require.NoError(t, err)
+
+	toolConfig := config.ToolServiceConfig{
+		Allowed: []string{},
+	}
+	logger := zap.NewNop()
+
+	module := NewToolModule(bus, toolConfig, logger)
+
+	result := module.ToolInfo("GitDiff", map[string]any{"revision": "HEAD~1..HEAD", "file": "main.go"})
+	assert.Equal(t, "GitDiff (HEAD~1..HEAD main.go)", result)
+
+	result = module.ToolInfo("GitStatus", map[string]any{"path": "/project"})
+	assert.Equal(t, "GitStatus", result)
+}
+
+func TestToolModuleToolInfoGoSymbols(t *testing.T) {
+	busConfig := config.EventBusConfig{PoolSize: 10}
+	bus, err := events.NewEventBus(busConfig, zap.NewNop())
+	require.NoError(t, err)
+
+	toolConfig := config.ToolServiceConfig{
+		Allowed: []string{},
+	}
+	logger := zap.NewNop()
+
+	module := NewToolModule(bus, toolConfig, logger)
+
+	result := module.ToolInfo("GoSymbols", map[string]any{"action": "references", "path": "/project", "symbol": "Store.Add"})
+	assert.Equal(t, "GoSymbols (references Store.Add)", result)
+
+	result = module.ToolInfo("GoSymbols", map[string]any{})
+	assert.Equal(t, "GoSymbols", result)
+}
+
+func TestToolModuleToolInfoRepoMap(t *testing.T) {
+	busConfig := config.EventBusConfig{PoolSize: 10}
+	bus, err := events.NewEventBus(busConfig, zap.NewNop())
+	require.NoError(t, err)
+
+	toolConfig := config.ToolServiceConfig{
+		Allowed: []string{},
+	}
+	logger := zap.NewNop()
+
+	module := NewToolModule(bus, toolConfig, logger)
+
+	result := module.ToolInfo("RepoMap", map[string]any{"path": "/project/internal"})
+	assert.Equal(t, "RepoMap (/project/internal)", result)
+
+	result = module.ToolInfo("RepoMap", map[string]any{})
+	assert.Equal(t, "RepoMap", result)
+}
+
+func TestToolModuleToolInfoSemanticSearch(t *testing.T) {
+	busConfig := config.EventBusConfig{PoolSize: 10}
+	bus, err := events.NewEventBus(busConfig, zap.NewNop())
+	require.NoError(t, err)
+
+	toolConfig := config.ToolServiceConfig{
+		Allowed: []string{},
+	}
+	logger := zap.NewNop()
+
+	module := NewToolModule(bus, toolConfig, logger)
+
+	result := module.ToolInfo("SemanticSearch", map[string]any{"query": "where sessions expire"})
+	assert.Equal(t, `SemanticSearch ("where sessions expire")`, result)
+
+	result = module.ToolInfo("SemanticSearch", map[string]any{})
+	assert.Equal(t, "SemanticSearch", result)
+}
+
+func TestToolModuleToolInfoAskUser(t *testing.T) {
+	busConfig := config.EventBusConfig{PoolSize: 10}
+	bus, err := events.NewEventBus(busConfig, zap.NewNop())
+	require.NoError(t, err)
+
+	toolConfig := config.ToolServiceConfig{
+		Allowed: []string{},
+	}
+	logger := zap.NewNop()
+
+	module := NewToolModule(bus, toolConfig, logger)
+
+	result := module.ToolInfo("AskUser", map[string]any{"question": "Which database should the cache use?", "options": []any{"Redis", "Postgres"}})
+	assert.Equal(t, "AskUser (Which database should the cache use?)", result)
+
+	result = module.ToolInfo("AskUser", map[string]any{})
+	assert.Equal(t, "AskUser", result)
+}
+
+func TestToolModuleToolInfoLsp(t *testing.T) {
+	busConfig := config.EventBusConfig{PoolSize: 10}
+	bus, err := events.NewEventBus(busConfig, zap.NewNop())
+	require.NoError(t, err)
+
+	toolConfig := config.ToolServiceConfig{
+		Allowed: []string{},
+	}
+	logger := zap.NewNop()
+
+	module := NewToolModule(bus, toolConfig, logger)
+
+	result := module.ToolInfo("LspDiagnostics", map[string]any{"path": "/project/main.go"})
+	assert.Equal(t, "LspDiagnostics (/project/main.go)", result)
+
+	result = module.ToolInfo("Diagnostics", map[string]any{"path": "/project"})
+	assert.Equal(t, "Diagnostics (/project)", result)
+
+	result = module.ToolInfo("GoToDefinition", map[string]any{"path": "/project/main.go", "line": float64(12), "symbol": "Run"})
+	assert.Equal(t, "GoToDefinition (/project/main.go:12 Run)", result)
+
+	result = module.ToolInfo("Hover", map[string]any{"path": "/project/main.go", "line": float64(3), "column": float64(7)})
+	assert.Equal(t, "Hover (/project/main.go:3)", result)
+}
+
+func TestToolModuleToolInfoRunTests(t *testing.T) {
+	busConfig := config.EventBusConfig{PoolSize: 10}
+	bus, err := events.NewEventBus(busConfig, zap.NewNop())
+	require.NoError(t, err)
+
+	toolConfig := config.ToolServiceConfig{
+		Allowed: []string{},
+	}
+	logger := zap.NewNop()
+
+	module := NewToolModule(bus, toolConfig, logger)
+
+	result := module.ToolInfo("RunTests", map[string]any{"packages": []any{"./store", "./api"}, "run": "TestAdd$"})
+	assert.Equal(t, "RunTests (./store ./api -run TestAdd$)", result)
+
+	result = module.ToolInfo("RunTests", map[string]any{"path": "/project"})
+	assert.Equal(t, "RunTests", result)
+}
+
+func TestToolModuleToolInfoFileOps(t *testing.T) {
+	busConfig := config.EventBusConfig{PoolSize: 10}
+	bus, err := events.NewEventBus(busConfig, zap.NewNop())
+	require.NoError(t, err)
+
+	toolConfig := config.ToolServiceConfig{
+		Allowed: []string{},
+	}
+	logger := zap.NewNop()
+
+	module := NewToolModule(bus, toolConfig, logger)
+
+	result := module.ToolInfo("Move", map[string]any{"source": "/project/a.go", "destination": "/project/pkg/a.go"})
+	assert.Equal(t, "Move (/project/a.go → /project/pkg/a.go)", result)
+
+	result = module.ToolInfo("Copy", map[string]any{"source": "/project/a.go"})
+	assert.Equal(t, "Copy (/project/a.go)", result)
+
+	result = module.ToolInfo("Delete", map[string]any{"path": "/project/old"})
+	assert.Equal(t, "Delete (/project/old)", result)
+
+	result = module.ToolInfo("Delete", map[string]any{})
+	assert.Equal(t, "Delete", result)
+}
+
+func TestToolModuleToolInfoReplace(t *testing.T) {
+	busConfig := config.EventBusConfig{PoolSize: 10}
+	bus, err := events.NewEventBus(busConfig, zap.NewNop())
+	require.NoError(t, err)
+
+	toolConfig := config.ToolServiceConfig{
+		Allowed: []string{},
+	}
+	logger := zap.NewNop()
+
+	module := NewToolModule(bus, toolConfig, logger)
+
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "pkg"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "a.go"), []byte("OldName()\nOldName()\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "pkg", "b.go"), []byte("OldName()\n"), 0644))
+
+	// 승인 시 파일별 매치 수가 보이고, 계획한 파일의 해시가 meta로 따라간다
+	parameters := map[string]any{"pattern": "OldName", "replacement": "NewName", "glob": "*.go", "path": root}
+	result := module.ToolInfo("Replace", parameters)
+	assert.Equal(t, "Replace (OldName → NewName in *.go)", result)
+	assert.False(t, IsPreview("Replace", parameters))
+	summary, meta := PlanReplace(parameters)
+	assert.Equal(t, "\n3 matches in 2 files\n  a.go: 2\n  pkg/b.go: 1", summary)
+	hashes, ok := meta[replace.MetaPlan].(map[string]string)
+	require.True(t, ok)
+	assert.Len(t, hashes, 2)
+	assert.Contains(t, hashes, filepath.Join(root, "pkg", "b.go"))
+
+	parameters["preview"] = true
+	result = module.ToolInfo("Replace", parameters)
+	assert.Equal(t, "Replace (OldName → NewName in *.go) preview", result)
+	assert.True(t, IsPreview("Replace", parameters))
+
+	// 매치가 없으면 요약도 없다
+	summary, meta = PlanReplace(map[string]any{"pattern": "Missing", "replacement": "x", "glob": "*.go", "path": root})
+	assert.Empty(t, summary)
+	assert.Equal(t, map[string]any{replace.MetaPlan: map[string]string{}}, meta)
+
+	summary, _ = PlanReplace(map[string]any{"pattern": "OldName", "replacement": "x", "path": root})
+	assert.Equal(t, "\nCould not plan the replacement: glob must not be empty", summary)
+
+	result = module.ToolInfo("Replace", map[string]any{})
+	assert.Equal(t, "Replace", result)
+}
+
+func TestToolModuleProcessToolCallPreview(t *testing.T) {
+	busConfig := config.EventBusConfig{PoolSize: 10}
+	bus, err := events.NewEventBus(busConfig, zap.NewNop())
+	require.NoError(t, err)
+
+	toolConfig := config.ToolServiceConfig{
+		Allowed: []string{},
+	}
+	logger := zap.NewNop()
+
+	module := NewToolModule(bus, toolConfig, logger)
+
+	acceptToolReceived := make(chan dto.ToolCallData, 1)
+	events.Subscribe(bus, bus.AcceptToolEvent, constants.ToolModule, func(event events.Event[dto.ToolCallData]) {
+		acceptToolReceived <- event.Data
+	})
+
+	// preview는 아무것도 쓰지 않으므로 승인 없이 실행
+	toolCallID := types.NewToolCallID()
+	module.ProcessToolCall(dto.ToolCallData{
+		RequestID:  types.NewRequestID(),
+		ToolCallID: toolCallID,
+		ToolName:   "Replace",
+		Parameters: map[string]any{"pattern": "a", "replacement": "b", "glob": "*.go", "path": t.TempDir(), "preview": true},
+	})
+
+	select {
+	case data := <-acceptToolReceived:
+		assert.Equal(t, toolCallID, data.ToolCallID)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected AcceptToolEvent was not received within timeout")
+	}
+	_, exists := module.toolCallBuffer[toolCallID]
+	assert.False(t, exists)
+}
+
+func TestToolModuleProcessToolCallReplacePlan(t *testing.T) {
+	busConfig := config.EventBusConfig{PoolSize: 10}
+	bus, err := events.NewEventBus(busConfig, zap.NewNop())
+	require.NoError(t, err)
+
+	module := NewToolModule(bus, config.ToolServiceConfig{Allowed: []string{}}, zap.NewNop())
+
+	requestReceived := make(chan dto.ToolUseReportData, 1)
+	events.Subscribe(bus, bus.RequestToolUseEvent, constants.ToolModule, func(event events.Event[dto.ToolUseReportData]) {
+		requestReceived <- event.Data
+	})
+
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "a.go"), []byte("OldName()\n"), 0644))
+
+	// 승인을 묻기 전에 한 번 계획하고, 그 계획을 대기 중인 호출에 붙인다
+	toolCallID := types.NewToolCallID()
+	module.ProcessToolCall(dto.ToolCallData{
+		RequestID:  types.NewRequestID(),
+		ToolCallID: toolCallID,
+		ToolName:   "Replace",
+		Parameters: map[string]any{"pattern": "OldName", "replacement": "NewName", "glob": "*.go", "path": root},
+	})
+
+	select {
+	case data := <-requestReceived:
+		assert.Equal(t, "Replace (OldName → NewName in *.go)\n1 match in 1 file\n  a.go: 1", data.ToolInfo)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected RequestToolUseEvent was not received within timeout")
+	}
+	buffered, exists := module.toolCallBuffer[toolCallID]
+	require.True(t, exists)
+	assert.Contains(t, buffered.Meta[replace.MetaPlan], filepath.Join(root, "a.go"))
+}
+
+func TestToolModuleToolInfoTask(t *testing.T) {
+	busConfig := config.EventBusConfig{PoolSize: 10}
+	bus, err := events.NewEventBus(busConfig, zap.NewNop())
+	require.NoError(t, err)
+
+	toolConfig := config.ToolServiceConfig{
+		Allowed: []string{},
+	}
+	logger := zap.NewNop()
+
+	module := NewToolModule(bus, toolConfig, logger)
+
+	result := module.ToolInfo("Task", map[string]any{"description": "Find session handling", "prompt": "..."})
+	assert.Equal(t, "Task (Find session handling)", result)
+
+	result = module.ToolInfo("Task", map[string]any{"prompt": "..."})
+	assert.Equal(t, "Task", result)
+}
+
+func TestToolModuleToolInfoWebFetch(t *testing.T) {
+	busConfig := config.EventBusConfig{PoolSize: 10}
+	bus, err := events.NewEventBus(busConfig, zap.NewNop())
+	require.NoError(t, err)
+
+	toolConfig := config.ToolServiceConfig{
+		Allowed: []string{},
+	}
+	logger := zap.NewNop()
+
+	module := NewToolModule(bus, toolConfig, logger)
+
+	// 승인 시 요청할 URL이 보여야 함
+	result := module.ToolInfo("WebFetch", map[string]any{"url": "http://wiki.local/docs/deploy"})
+	assert.Equal(t, "WebFetch (http://wiki.local/docs/deploy)", result)
+
+	result = module.ToolInfo("WebFetch", map[string]any{})
+	assert.Equal(t, "WebFetch", result)
+}
+
 func TestToolModuleToolInfoUnknown(t *testing.T) {
 	busConfig := config.EventBusConfig{PoolSize: 10}
 	bus, err := events.NewEventBus(busConfig, zap.NewNop())
@@ -0,0 +1,242 @@
+package applypatch
+
+import (
+	"DevCode/tools/edit"
+	"fmt"
+	"strings"
+)
+
+// MaxFuzz is the number of leading and trailing context lines that may be
+// ignored when a hunk does not match as written, like patch --fuzz.
+const MaxFuzz = 2
+
+type comparator struct {
+	name  string
+	equal func(string, string) bool
+}
+
+var comparators = []comparator{
+	{name: "", equal: func(left string, right string) bool { return left == right }},
+	{name: "ignoring trailing whitespace", equal: func(left string, right string) bool {
+		return strings.TrimRight(left, " \t") == strings.TrimRight(right, " \t")
+	}},
+	{name: "ignoring indentation", equal: func(left string, right string) bool {
+		return strings.TrimSpace(left) == strings.TrimSpace(right)
+	}},
+}
+
+type HunkError struct {
+	Index  int
+	Total  int
+	Header string
+	Reason string
+}
+
+func (instance *HunkError) Error() string {
+	return fmt.Sprintf("hunk %d of %d (%s) failed: %s", instance.Index, instance.Total, instance.Header, instance.Reason)
+}
+
+// ApplyHunks applies hunks in order to content and returns the new content
+// together with notes about hunks that needed an offset, fuzz or relaxed
+// whitespace matching.
+func ApplyHunks(content string, hunks []Hunk) (string, []string, error) {
+	crlf := strings.Contains(content, "\r\n")
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	endsWithNewline := content == "" || strings.HasSuffix(content, "\n")
+	var lines []string
+	if content != "" {
+		lines = strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	}
+
+	var notes []string
+	offset := 0
+	position := 0
+	for index, hunk := range hunks {
+		number := index + 1
+		expected := max(hunk.OldStart-1, 0) + offset
+		if hunk.OldStart == 0 {
+			expected = 0
+		}
+		old := hunk.OldLines()
+		var start, end int
+		var replacement []string
+		if len(old) == 0 {
+			start = min(max(expected, position), len(lines))
+			if hunk.OldStart > 0 {
+				// "-N,0"은 N번째 줄 다음에 삽입
+				start = min(max(expected+1, position), len(lines))
+			}
+			end = start
+			replacement = hunk.NewLines()
+		} else {
+			match, ok := locate(lines, hunk, expected, position)
+			if !ok {
+				return "", nil, &HunkError{Index: number, Total: len(hunks), Header: hunk.Header, Reason: explain(lines, old, position)}
+			}
+			start, end, replacement = match.start, match.end, match.replacement
+			if match.fuzz > 0 {
+				notes = append(notes, fmt.Sprintf("hunk %d applied with fuzz %d", number, match.fuzz))
+			}
+			if match.comparator != "" {
+				notes = append(notes, fmt.Sprintf("hunk %d matched %s", number, match.comparator))
+			}
+			if match.hunkStart != expected && hunk.OldStart > 0 {
+				notes = append(notes, fmt.Sprintf("hunk %d applied at line %d (offset %+d lines)", number, match.hunkStart+1, match.hunkStart-expected))
+			}
+			offset = match.hunkStart - max(hunk.OldStart-1, 0)
+		}
+		updated := make([]string, 0, len(lines)-(end-start)+len(replacement))
+		updated = append(updated, lines[:start]...)
+		updated = append(updated, replacement...)
+		updated = append(updated, lines[end:]...)
+		lines = updated
+		offset += len(replacement) - (end - start)
+		position = start + len(replacement)
+		if position == len(lines) {
+			if hunk.NoNewlineAtEnd {
+				endsWithNewline = false
+			} else if hunk.OldNoNewlineAtEnd {
+				endsWithNewline = true
+			}
+		}
+	}
+
+	result := strings.Join(lines, "\n")
+	if endsWithNewline && len(lines) > 0 {
+		result += "\n"
+	}
+	if crlf {
+		result = edit.ToCRLF(result)
+	}
+	return result, notes, nil
+}
+
+type location struct {
+	start       int
+	end         int
+	hunkStart   int
+	replacement []string
+	fuzz        int
+	comparator  string
+}
+
+func locate(lines []string, hunk Hunk, expected int, position int) (location, bool) {
+	leading, trailing := 0, 0
+	for _, line := range hunk.Lines {
+		if line.Kind != Context {
+			break
+		}
+		leading++
+	}
+	for index := len(hunk.Lines) - 1; index >= 0 && hunk.Lines[index].Kind == Context; index-- {
+		trailing++
+	}
+	for fuzz := 0; fuzz <= MaxFuzz; fuzz++ {
+		skipHead := min(fuzz, leading)
+		skipTail := min(fuzz, trailing)
+		if fuzz > 0 && skipHead == 0 && skipTail == 0 {
+			break
+		}
+		body := hunk.Lines[skipHead : len(hunk.Lines)-skipTail]
+		var old []string
+		for _, line := range body {
+			if line.Kind != Added {
+				old = append(old, line.Text)
+			}
+		}
+		if len(old) == 0 {
+			continue
+		}
+		for _, compare := range comparators {
+			start, ok := search(lines, old, expected+skipHead, position, compare.equal)
+			if !ok {
+				continue
+			}
+			return location{
+				start:       start,
+				end:         start + len(old),
+				hunkStart:   start - skipHead,
+				replacement: rebuild(lines[start:start+len(old)], body),
+				fuzz:        fuzz,
+				comparator:  compare.name,
+			}, true
+		}
+	}
+	return location{}, false
+}
+
+// search finds old in lines at or after position, preferring the match closest to expected.
+func search(lines []string, old []string, expected int, position int, equal func(string, string) bool) (int, bool) {
+	last := len(lines) - len(old)
+	if last < position {
+		return 0, false
+	}
+	expected = min(max(expected, position), last)
+	for distance := 0; expected-distance >= position || expected+distance <= last; distance++ {
+		for _, candidate := range []int{expected - distance, expected + distance} {
+			if candidate < position || candidate > last || (distance == 0 && candidate != expected) {
+				continue
+			}
+			if matches(lines[candidate:candidate+len(old)], old, equal) {
+				return candidate, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func matches(lines []string, old []string, equal func(string, string) bool) bool {
+	for index := range old {
+		if !equal(lines[index], old[index]) {
+			return false
+		}
+	}
+	return true
+}
+
+// rebuild keeps the file's own text for context lines so that relaxed
+// whitespace matching does not rewrite lines the hunk did not change.
+func rebuild(original []string, body []Line) []string {
+	replacement := make([]string, 0, len(body))
+	cursor := 0
+	for _, line := range body {
+		switch line.Kind {
+		case Context:
+			replacement = append(replacement, original[cursor])
+			cursor++
+		case Removed:
+			cursor++
+		case Added:
+			replacement = append(replacement, line.Text)
+		}
+	}
+	return replacement
+}
+
+func explain(lines []string, old []string, position int) string {
+	best, bestCount := -1, 0
+	for start := position; start < len(lines); start++ {
+		count := 0
+		for index := 0; index < len(old) && start+index < len(lines); index++ {
+			if strings.TrimSpace(lines[start+index]) == strings.TrimSpace(old[index]) {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = start, count
+		}
+	}
+	if best < 0 {
+		return fmt.Sprintf("the context and removed lines were not found in the file; the first expected line is %q", old[0])
+	}
+	for index := range old {
+		if best+index >= len(lines) {
+			return fmt.Sprintf("closest match starts at line %d but the file ends before expected line %q", best+1, old[index])
+		}
+		if strings.TrimSpace(lines[best+index]) != strings.TrimSpace(old[index]) {
+			return fmt.Sprintf("closest match starts at line %d, but line %d differs: expected %q, found %q",
+				best+1, best+index+1, old[index], lines[best+index])
+		}
+	}
+	return fmt.Sprintf("context only matched partially near line %d", best+1)
+}
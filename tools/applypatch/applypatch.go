@@ -0,0 +1,296 @@
+package applypatch
+
+import (
+	"DevCode/tools"
+	"DevCode/tools/edit"
+	"DevCode/tools/write"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	ApplyPatchDescription = `Applies a unified diff to one or more files. Prefer this tool over
+  Edit and MultiEdit when you change several places or several files at once.\n\nUsage:\n-
+  The patch parameter is a unified diff as produced by "diff -u" or "git diff". Each file
+  starts with "--- a/path" and "+++ b/path" lines followed by hunks that start with
+  "@@ -old,count +new,count @@"\n- Use "--- /dev/null" to create a file and "+++ /dev/null"
+  to delete one. Git headers such as "rename from" and "rename to" are supported\n- Relative
+  paths are resolved against the path parameter, or the current working directory\n- Include
+  about three lines of unchanged context around every change. Hunks are located by their
+  context, so small line number mistakes and whitespace differences are tolerated\n- The
+  patch is all-or-nothing: if any hunk in any file fails, no file is changed and the error
+  names the hunk that failed and why\n- Use the Read tool first so that context lines match
+  the current file contents`
+	Name = "ApplyPatch"
+)
+
+type Tool struct {
+}
+
+func (*Tool) Name() string {
+	return Name
+}
+
+func (*Tool) Description() string {
+	return ApplyPatchDescription
+}
+
+func (instance *Tool) Handler() mcp.ToolHandlerFor[Input, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[Input]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		base := input.Path
+		if base == "" {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return nil, fmt.Errorf("fail to get working directory: %w", err)
+			}
+			base = cwd
+		}
+		if !filepath.IsAbs(base) {
+			return nil, fmt.Errorf("invalid path format: %s", input.Path)
+		}
+		if strings.TrimSpace(input.Patch) == "" {
+			return nil, fmt.Errorf("patch must not be empty")
+		}
+		patches, err := Parse(input.Patch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse patch: %w", err)
+		}
+		changes, err := Plan(base, patches)
+		if err != nil {
+			return nil, fmt.Errorf("patch failed, no changes were applied: %w", err)
+		}
+		if err := Commit(changes); err != nil {
+			return nil, fmt.Errorf("patch failed, no changes were applied: %w", err)
+		}
+		return tools.TextReturn(Summary(changes))
+	}
+}
+
+type Change struct {
+	Operation Operation
+	OldPath   string
+	NewPath   string
+	Content   string
+	Mode      os.FileMode
+	Hunks     int
+	Notes     []string
+}
+
+type fileState struct {
+	content string
+	mode    os.FileMode
+	exists  bool
+}
+
+// Plan applies every file patch in memory. Later patches see the result of
+// earlier ones, so a diff may touch the same file more than once.
+func Plan(base string, patches []FilePatch) ([]Change, error) {
+	states := make(map[string]fileState)
+	load := func(path string) (fileState, error) {
+		if state, ok := states[path]; ok {
+			return state, nil
+		}
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			return fileState{}, nil
+		}
+		if err == nil && info.IsDir() {
+			return fileState{}, fmt.Errorf("path is a directory: %s", path)
+		}
+		content, mode, err := edit.ReadFile(path)
+		if err != nil {
+			return fileState{}, err
+		}
+		return fileState{content: content, mode: mode, exists: true}, nil
+	}
+
+	changes := make([]Change, 0, len(patches))
+	for _, patch := range patches {
+		oldPath, newPath := ResolvePaths(base, patch)
+		change := Change{Operation: patch.Operation(), OldPath: oldPath, NewPath: newPath, Hunks: len(patch.Hunks)}
+		display := newPath
+		if display == "" {
+			display = oldPath
+		}
+
+		source := fileState{mode: write.DefaultFileMode}
+		if change.Operation != Create {
+			state, err := load(oldPath)
+			if err != nil {
+				return nil, err
+			}
+			if !state.exists {
+				return nil, fmt.Errorf("%s: file not found", oldPath)
+			}
+			source = state
+		}
+		if change.Operation == Create || change.Operation == Rename {
+			state, err := load(newPath)
+			if err != nil {
+				return nil, err
+			}
+			if state.exists {
+				return nil, fmt.Errorf("%s: file already exists", newPath)
+			}
+		}
+
+		change.Mode = source.mode
+		change.Content = source.content
+		if change.Operation != Delete && len(patch.Hunks) > 0 {
+			updated, notes, err := ApplyHunks(source.content, patch.Hunks)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", display, err)
+			}
+			change.Content = updated
+			for _, note := range notes {
+				change.Notes = append(change.Notes, fmt.Sprintf("%s: %s", display, note))
+			}
+		}
+
+		if change.Operation == Delete || change.Operation == Rename {
+			states[oldPath] = fileState{}
+		}
+		if change.Operation != Delete {
+			states[newPath] = fileState{content: change.Content, mode: change.Mode, exists: true}
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// ResolvePaths turns the paths of a file patch into absolute paths. When
+// only one side has an a/ or b/ prefix, it is removed unless such a
+// directory really exists.
+func ResolvePaths(base string, patch FilePatch) (string, string) {
+	resolve := func(path string, prefix string) string {
+		if path == "" {
+			return ""
+		}
+		if filepath.IsAbs(path) {
+			return filepath.Clean(path)
+		}
+		if !patch.Stripped && strings.HasPrefix(path, prefix) {
+			if _, err := os.Stat(filepath.Join(base, path)); err != nil {
+				path = strings.TrimPrefix(path, prefix)
+			}
+		}
+		return filepath.Join(base, filepath.FromSlash(path))
+	}
+	return resolve(patch.OldPath, "a/"), resolve(patch.NewPath, "b/")
+}
+
+// Commit writes every change. When a write fails, files that were already
+// changed are restored so that the patch is all-or-nothing on disk as well.
+func Commit(changes []Change) error {
+	originals := make(map[string]fileState)
+	var touched []string
+	remember := func(path string) error {
+		if _, ok := originals[path]; ok {
+			return nil
+		}
+		state := fileState{}
+		if _, err := os.Stat(path); err == nil {
+			content, mode, err := edit.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			state = fileState{content: content, mode: mode, exists: true}
+		}
+		originals[path] = state
+		touched = append(touched, path)
+		return nil
+	}
+	var createdDirs []string
+	rollback := func() {
+		for index := len(touched) - 1; index >= 0; index-- {
+			path := touched[index]
+			if original := originals[path]; original.exists {
+				write.WriteFile(path, []byte(original.content), original.mode)
+			} else {
+				os.Remove(path)
+			}
+		}
+		for index := len(createdDirs) - 1; index >= 0; index-- {
+			os.Remove(createdDirs[index])
+		}
+	}
+	makeParents := func(path string) error {
+		var missing []string
+		for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+			if _, err := os.Stat(dir); err == nil || dir == filepath.Dir(dir) {
+				break
+			}
+			missing = append([]string{dir}, missing...)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), write.DefaultDirMode); err != nil {
+			if os.IsPermission(err) {
+				return fmt.Errorf("permission denied: %s", filepath.Dir(path))
+			}
+			return fmt.Errorf("fail to create directory: %s", filepath.Dir(path))
+		}
+		createdDirs = append(createdDirs, missing...)
+		return nil
+	}
+
+	for _, change := range changes {
+		for _, path := range []string{change.OldPath, change.NewPath} {
+			if path == "" {
+				continue
+			}
+			if err := remember(path); err != nil {
+				rollback()
+				return err
+			}
+		}
+		if change.Operation != Delete {
+			if err := makeParents(change.NewPath); err != nil {
+				rollback()
+				return err
+			}
+			if err := write.WriteFile(change.NewPath, []byte(change.Content), change.Mode); err != nil {
+				rollback()
+				return err
+			}
+		}
+		if change.Operation == Delete || change.Operation == Rename {
+			if err := os.Remove(change.OldPath); err != nil {
+				rollback()
+				if os.IsPermission(err) {
+					return fmt.Errorf("permission denied: %s", change.OldPath)
+				}
+				return fmt.Errorf("fail to delete file: %s", change.OldPath)
+			}
+		}
+	}
+	return nil
+}
+
+func Summary(changes []Change) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Applied patch to %d files:\n", len(changes))
+	var notes []string
+	for _, change := range changes {
+		switch change.Operation {
+		case Delete:
+			fmt.Fprintf(&builder, "%s %s\n", change.Operation, change.OldPath)
+		case Rename:
+			fmt.Fprintf(&builder, "%s %s -> %s (%d hunks)\n", change.Operation, change.OldPath, change.NewPath, change.Hunks)
+		default:
+			fmt.Fprintf(&builder, "%s %s (%d hunks)\n", change.Operation, change.NewPath, change.Hunks)
+		}
+		notes = append(notes, change.Notes...)
+	}
+	if len(notes) > 0 {
+		builder.WriteString("\nNotes:\n")
+		for _, note := range notes {
+			fmt.Fprintf(&builder, "- %s\n", note)
+		}
+	}
+	return strings.TrimSuffix(builder.String(), "\n")
+}
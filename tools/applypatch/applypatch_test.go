@@ -0,0 +1,305 @@
+package applypatch
+
+import (
+	"DevCode/tools"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const original = `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("hello")
+	fmt.Println("world")
+}
+
+func helper() int {
+	return 1
+}
+`
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestParseGitDiff(t *testing.T) {
+	patch := `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -5,3 +5,3 @@ func main() {
+ func main() {
+-	fmt.Println("hello")
++	fmt.Println("hi")
+ 	fmt.Println("world")
+diff --git a/new.txt b/new.txt
+new file mode 100644
+--- /dev/null
++++ b/new.txt
+@@ -0,0 +1,2 @@
++first
++second
+diff --git a/old.txt b/old.txt
+deleted file mode 100644
+--- a/old.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-gone
+diff --git a/from.go b/to.go
+similarity index 100%
+rename from from.go
+rename to to.go
+`
+	patches, err := Parse(patch)
+	require.NoError(t, err)
+	require.Len(t, patches, 4)
+
+	assert.Equal(t, Modify, patches[0].Operation())
+	require.Len(t, patches[0].Hunks, 1)
+	assert.Equal(t, 5, patches[0].Hunks[0].OldStart)
+	assert.Equal(t, []string{"func main() {", "\tfmt.Println(\"hello\")", "\tfmt.Println(\"world\")"}, patches[0].Hunks[0].OldLines())
+	assert.Equal(t, []string{"func main() {", "\tfmt.Println(\"hi\")", "\tfmt.Println(\"world\")"}, patches[0].Hunks[0].NewLines())
+
+	assert.Equal(t, Create, patches[1].Operation())
+	assert.Equal(t, "new.txt", patches[1].NewPath)
+	assert.Equal(t, Delete, patches[2].Operation())
+	assert.Equal(t, "old.txt", patches[2].OldPath)
+	assert.Equal(t, Rename, patches[3].Operation())
+	assert.Equal(t, "from.go", patches[3].OldPath)
+	assert.Equal(t, "to.go", patches[3].NewPath)
+	assert.Empty(t, patches[3].Hunks)
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse("just some text")
+	assert.ErrorContains(t, err, "no file changes found")
+
+	_, err = Parse("@@ -1 +1 @@\n-a\n+b\n")
+	assert.ErrorContains(t, err, "has no file header")
+
+	_, err = Parse("--- a/x\n+++ b/x\n@@ -1,2 +1,2 @@\n a\n b\n")
+	assert.ErrorContains(t, err, "no added or removed lines")
+}
+
+func TestApplyHunksOffsetAndWhitespace(t *testing.T) {
+	// 줄 번호가 틀리고 들여쓰기가 공백으로 바뀐 hunk도 적용되어야 함
+	patch := `--- a/main.go
++++ b/main.go
+@@ -20,3 +20,3 @@
+ func helper() int {
+-    return 1
++	return 2
+ }
+`
+	patches, err := Parse(patch)
+	require.NoError(t, err)
+
+	updated, notes, err := ApplyHunks(original, patches[0].Hunks)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Replace(original, "return 1", "return 2", 1), updated)
+	assert.Contains(t, notes, "hunk 1 matched ignoring indentation")
+	assert.Contains(t, notes, "hunk 1 applied at line 10 (offset -10 lines)")
+}
+
+func TestApplyHunksFuzz(t *testing.T) {
+	patch := `--- a/main.go
++++ b/main.go
+@@ -5,4 +5,4 @@
+ func main() {
+-	fmt.Println("hello")
++	fmt.Println("bonjour")
+ 	fmt.Println("world")
+ this line was never in the file
+`
+	patches, err := Parse(patch)
+	require.NoError(t, err)
+
+	updated, notes, err := ApplyHunks(original, patches[0].Hunks)
+	require.NoError(t, err)
+	assert.Contains(t, updated, `fmt.Println("bonjour")`)
+	assert.Contains(t, notes, "hunk 1 applied with fuzz 1")
+}
+
+func TestApplyHunksInsertAndNoNewline(t *testing.T) {
+	patch := `--- a/list.txt
++++ b/list.txt
+@@ -2,0 +3,1 @@
++inserted
+@@ -3 +4 @@
+-c
+\ No newline at end of file
++C
+\ No newline at end of file
+`
+	patches, err := Parse(patch)
+	require.NoError(t, err)
+
+	updated, _, err := ApplyHunks("a\nb\nc", patches[0].Hunks)
+	require.NoError(t, err)
+	assert.Equal(t, "a\nb\ninserted\nC", updated)
+}
+
+func TestApplyHunksCRLF(t *testing.T) {
+	patch := "--- a/x\n+++ b/x\n@@ -1,2 +1,2 @@\n one\n-two\n+TWO\n"
+	patches, err := Parse(patch)
+	require.NoError(t, err)
+
+	updated, _, err := ApplyHunks("one\r\ntwo\r\n", patches[0].Hunks)
+	require.NoError(t, err)
+	assert.Equal(t, "one\r\nTWO\r\n", updated)
+}
+
+func TestApplyHunksReportsFailedHunk(t *testing.T) {
+	patch := `--- a/main.go
++++ b/main.go
+@@ -5,2 +5,2 @@
+ func main() {
+-	fmt.Println("hello")
++	fmt.Println("hi")
+@@ -10,3 +10,3 @@
+ func helper() int {
+-	return 42
++	return 2
+ }
+`
+	patches, err := Parse(patch)
+	require.NoError(t, err)
+
+	_, _, err = ApplyHunks(original, patches[0].Hunks)
+	var hunkErr *HunkError
+	require.ErrorAs(t, err, &hunkErr)
+	assert.Equal(t, 2, hunkErr.Index)
+	assert.Equal(t, 2, hunkErr.Total)
+	assert.Equal(t, "@@ -10,3 +10,3 @@", hunkErr.Header)
+	assert.Contains(t, hunkErr.Reason, `line 11 differs: expected "\treturn 42", found "\treturn 1"`)
+}
+
+func TestApplyPatchMultipleFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "main.go"), original)
+	writeFile(t, filepath.Join(dir, "old.txt"), "gone\n")
+	writeFile(t, filepath.Join(dir, "pkg", "from.go"), "package pkg\n\nconst Name = \"from\"\n")
+
+	patch := `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -6,2 +6,2 @@ func main() {
+-	fmt.Println("hello")
++	fmt.Println("hi")
+ 	fmt.Println("world")
+diff --git a/docs/new.md b/docs/new.md
+new file mode 100644
+--- /dev/null
++++ b/docs/new.md
+@@ -0,0 +1,2 @@
++# New
++text
+diff --git a/old.txt b/old.txt
+deleted file mode 100644
+--- a/old.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-gone
+diff --git a/pkg/from.go b/pkg/to.go
+similarity index 80%
+rename from pkg/from.go
+rename to pkg/to.go
+--- a/pkg/from.go
++++ b/pkg/to.go
+@@ -3 +3 @@
+-const Name = "from"
++const Name = "to"
+`
+	output, err := tools.CallText(context.Background(), &Tool{}, Input{Patch: patch, Path: dir})
+	require.NoError(t, err)
+	assert.Contains(t, output, "Applied patch to 4 files:")
+	assert.Contains(t, output, "M "+filepath.Join(dir, "main.go")+" (1 hunks)")
+	assert.Contains(t, output, "A "+filepath.Join(dir, "docs", "new.md"))
+	assert.Contains(t, output, "D "+filepath.Join(dir, "old.txt"))
+	assert.Contains(t, output, "R "+filepath.Join(dir, "pkg", "from.go")+" -> "+filepath.Join(dir, "pkg", "to.go"))
+
+	assert.Contains(t, readFile(t, filepath.Join(dir, "main.go")), `fmt.Println("hi")`)
+	assert.Equal(t, "# New\ntext\n", readFile(t, filepath.Join(dir, "docs", "new.md")))
+	assert.NoFileExists(t, filepath.Join(dir, "old.txt"))
+	assert.NoFileExists(t, filepath.Join(dir, "pkg", "from.go"))
+	assert.Equal(t, "package pkg\n\nconst Name = \"to\"\n", readFile(t, filepath.Join(dir, "pkg", "to.go")))
+}
+
+func TestApplyPatchAllOrNothing(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "first.txt"), "one\ntwo\n")
+	writeFile(t, filepath.Join(dir, "second.txt"), "alpha\nbeta\n")
+
+	patch := `--- a/first.txt
++++ b/first.txt
+@@ -1,2 +1,2 @@
+ one
+-two
++TWO
+--- /dev/null
++++ b/created.txt
+@@ -0,0 +1 @@
++created
+--- a/second.txt
++++ b/second.txt
+@@ -1,2 +1,2 @@
+ alpha
+-gamma
++delta
+`
+	_, err := tools.CallText(context.Background(), &Tool{}, Input{Patch: patch, Path: dir})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no changes were applied")
+	assert.Contains(t, err.Error(), filepath.Join(dir, "second.txt")+": hunk 1 of 1 (@@ -1,2 +1,2 @@) failed")
+
+	assert.Equal(t, "one\ntwo\n", readFile(t, filepath.Join(dir, "first.txt")))
+	assert.Equal(t, "alpha\nbeta\n", readFile(t, filepath.Join(dir, "second.txt")))
+	assert.NoFileExists(t, filepath.Join(dir, "created.txt"))
+}
+
+func TestApplyPatchExistenceChecks(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "exists.txt"), "x\n")
+
+	_, err := tools.CallText(context.Background(), &Tool{}, Input{Patch: "--- /dev/null\n+++ b/exists.txt\n@@ -0,0 +1 @@\n+y\n", Path: dir})
+	assert.ErrorContains(t, err, "file already exists")
+
+	_, err = tools.CallText(context.Background(), &Tool{}, Input{Patch: "--- a/missing.txt\n+++ b/missing.txt\n@@ -1 +1 @@\n-a\n+b\n", Path: dir})
+	assert.ErrorContains(t, err, "file not found")
+
+	_, err = tools.CallText(context.Background(), &Tool{}, Input{Patch: "--- a/exists.txt\n+++ b/exists.txt\n@@ -1 +1 @@\n-x\n+y\n", Path: "relative"})
+	assert.ErrorContains(t, err, "invalid path format")
+}
+
+func TestCommitRollback(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.txt")
+	writeFile(t, first, "original\n")
+	blocked := filepath.Join(dir, "blocked")
+	writeFile(t, blocked, "a file where a directory is needed\n")
+
+	// 두 번째 파일은 부모 경로가 파일이라 쓰기에 실패함
+	err := Commit([]Change{
+		{Operation: Modify, OldPath: first, NewPath: first, Content: "changed\n", Mode: 0644},
+		{Operation: Create, NewPath: filepath.Join(blocked, "child.txt"), Content: "x\n", Mode: 0644},
+	})
+	require.Error(t, err)
+	assert.Equal(t, "original\n", readFile(t, first))
+}
@@ -0,0 +1,184 @@
+package applypatch
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const devNull = "/dev/null"
+
+var hunkHeaderPattern = regexp.MustCompile(`^@@+ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@+`)
+
+// Parse reads a unified diff that may touch several files. Git extended
+// headers (new, deleted and renamed files) are understood, and hunk line
+// counts are treated as hints because hand-written diffs often miscount.
+func Parse(text string) ([]FilePatch, error) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	var patches []FilePatch
+	var current *FilePatch
+	sawHeader := false
+	finish := func() {
+		if current != nil {
+			if current.Git || (strings.HasPrefix(current.OldPath, "a/") && strings.HasPrefix(current.NewPath, "b/")) {
+				current.OldPath = strings.TrimPrefix(current.OldPath, "a/")
+				current.NewPath = strings.TrimPrefix(current.NewPath, "b/")
+				current.Stripped = true
+			}
+			patches = append(patches, *current)
+		}
+		current = nil
+		sawHeader = false
+	}
+	for index := 0; index < len(lines); index++ {
+		line := lines[index]
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			finish()
+			oldPath, newPath := parseGitHeader(strings.TrimPrefix(line, "diff --git "))
+			current = &FilePatch{OldPath: oldPath, NewPath: newPath, Git: true}
+		case current != nil && current.Git && len(current.Hunks) == 0 && strings.HasPrefix(line, "new file mode"):
+			current.OldPath = ""
+		case current != nil && current.Git && len(current.Hunks) == 0 && strings.HasPrefix(line, "deleted file mode"):
+			current.NewPath = ""
+		case current != nil && current.Git && len(current.Hunks) == 0 && strings.HasPrefix(line, "rename from "):
+			current.OldPath = "a/" + strings.TrimPrefix(line, "rename from ")
+		case current != nil && current.Git && len(current.Hunks) == 0 && strings.HasPrefix(line, "rename to "):
+			current.NewPath = "b/" + strings.TrimPrefix(line, "rename to ")
+		case isFileHeader(lines, index):
+			if current == nil || sawHeader || len(current.Hunks) > 0 {
+				finish()
+				current = &FilePatch{}
+			}
+			current.OldPath = parseHeaderPath(strings.TrimPrefix(line, "--- "))
+			current.NewPath = parseHeaderPath(strings.TrimPrefix(lines[index+1], "+++ "))
+			sawHeader = true
+			index++
+		case strings.HasPrefix(line, "@@"):
+			if current == nil {
+				return nil, fmt.Errorf("line %d: hunk %q has no file header (--- and +++ lines)", index+1, line)
+			}
+			hunk, next, err := parseHunk(lines, index)
+			if err != nil {
+				return nil, err
+			}
+			current.Hunks = append(current.Hunks, hunk)
+			index = next - 1
+		}
+	}
+	finish()
+	if len(patches) == 0 {
+		return nil, fmt.Errorf("no file changes found in patch; expected unified diff headers such as --- a/file and +++ b/file")
+	}
+	for _, patch := range patches {
+		if patch.OldPath == "" && patch.NewPath == "" {
+			return nil, fmt.Errorf("patch has a file with neither an old nor a new path")
+		}
+		if patch.Operation() == Modify && len(patch.Hunks) == 0 {
+			return nil, fmt.Errorf("%s: no hunks to apply", patch.Path())
+		}
+	}
+	return patches, nil
+}
+
+func isFileHeader(lines []string, index int) bool {
+	return strings.HasPrefix(lines[index], "--- ") && index+1 < len(lines) && strings.HasPrefix(lines[index+1], "+++ ")
+}
+
+func parseGitHeader(header string) (string, string) {
+	if separator := strings.LastIndex(header, " b/"); separator >= 0 {
+		return header[:separator], header[separator+1:]
+	}
+	fields := strings.Fields(header)
+	if len(fields) == 2 {
+		return fields[0], fields[1]
+	}
+	return header, header
+}
+
+func parseHeaderPath(header string) string {
+	if tab := strings.Index(header, "\t"); tab >= 0 {
+		header = header[:tab]
+	}
+	header = strings.TrimSpace(header)
+	if unquoted, err := strconv.Unquote(header); err == nil && strings.HasPrefix(header, `"`) {
+		header = unquoted
+	}
+	if header == devNull {
+		return ""
+	}
+	return header
+}
+
+func parseHunk(lines []string, start int) (Hunk, int, error) {
+	header := lines[start]
+	hunk := Hunk{Header: header}
+	oldCount, newCount := -1, -1
+	if match := hunkHeaderPattern.FindStringSubmatch(header); match != nil {
+		hunk.OldStart, _ = strconv.Atoi(match[1])
+		hunk.NewStart, _ = strconv.Atoi(match[3])
+		oldCount, newCount = 1, 1
+		if match[2] != "" {
+			oldCount, _ = strconv.Atoi(match[2])
+		}
+		if match[4] != "" {
+			newCount, _ = strconv.Atoi(match[4])
+		}
+	}
+	index := start + 1
+	for ; index < len(lines); index++ {
+		line := lines[index]
+		if strings.HasPrefix(line, "@@") || strings.HasPrefix(line, "diff --git ") || isFileHeader(lines, index) {
+			break
+		}
+		if line == "" {
+			hunk.Lines = append(hunk.Lines, Line{Kind: Context})
+			continue
+		}
+		switch LineKind(line[0]) {
+		case Context, Removed, Added:
+			hunk.Lines = append(hunk.Lines, Line{Kind: LineKind(line[0]), Text: line[1:]})
+			continue
+		}
+		if line[0] == '\\' {
+			if len(hunk.Lines) > 0 {
+				switch hunk.Lines[len(hunk.Lines)-1].Kind {
+				case Removed:
+					hunk.OldNoNewlineAtEnd = true
+				case Added:
+					hunk.NoNewlineAtEnd = true
+				default:
+					hunk.OldNoNewlineAtEnd = true
+					hunk.NoNewlineAtEnd = true
+				}
+			}
+			continue
+		}
+		break
+	}
+	// 빈 줄은 빈 context로 보지만, 헤더의 줄 수를 넘는 끝부분의 빈 줄은 패치 사이의 공백일 뿐
+	for len(hunk.Lines) > 0 {
+		last := hunk.Lines[len(hunk.Lines)-1]
+		if last.Kind != Context || last.Text != "" {
+			break
+		}
+		oldSeen := len(hunk.OldLines())
+		newSeen := len(hunk.NewLines())
+		if oldCount >= 0 && oldSeen <= oldCount && newSeen <= newCount {
+			break
+		}
+		hunk.Lines = hunk.Lines[:len(hunk.Lines)-1]
+	}
+	changed := false
+	for _, line := range hunk.Lines {
+		if line.Kind != Context {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return Hunk{}, 0, fmt.Errorf("line %d: hunk %q has no added or removed lines", start+1, header)
+	}
+	return hunk, index, nil
+}
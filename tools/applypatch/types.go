@@ -0,0 +1,88 @@
+package applypatch
+
+type Input struct {
+	Patch string `json:"patch" jsonschema:"description:The unified diff to apply. May contain several files, including new, deleted and renamed files"`
+	Path  string `json:"path,omitempty" jsonschema:"description:The absolute directory that relative paths in the patch are resolved against. Defaults to the current working directory"`
+}
+
+type Operation string
+
+const (
+	Modify Operation = "M"
+	Create Operation = "A"
+	Delete Operation = "D"
+	Rename Operation = "R"
+)
+
+type FilePatch struct {
+	OldPath string
+	NewPath string
+	Hunks   []Hunk
+	Git     bool
+	// Stripped is set once the a/ and b/ prefixes have been removed from both paths.
+	Stripped bool
+}
+
+func (instance FilePatch) Operation() Operation {
+	switch {
+	case instance.OldPath == "":
+		return Create
+	case instance.NewPath == "":
+		return Delete
+	case instance.OldPath != instance.NewPath:
+		return Rename
+	}
+	return Modify
+}
+
+// Path returns the path shown in messages: the new path unless the file is deleted.
+func (instance FilePatch) Path() string {
+	if instance.NewPath == "" {
+		return instance.OldPath
+	}
+	return instance.NewPath
+}
+
+type Hunk struct {
+	Header   string
+	OldStart int
+	NewStart int
+	Lines    []Line
+	// NoNewlineAtEnd and OldNoNewlineAtEnd record "\ No newline at end of file"
+	// markers on the new and the old side.
+	NoNewlineAtEnd    bool
+	OldNoNewlineAtEnd bool
+}
+
+type LineKind byte
+
+const (
+	Context LineKind = ' '
+	Removed LineKind = '-'
+	Added   LineKind = '+'
+)
+
+type Line struct {
+	Kind LineKind
+	Text string
+}
+
+func (instance Hunk) OldLines() []string {
+	lines := make([]string, 0, len(instance.Lines))
+	for _, line := range instance.Lines {
+		if line.Kind != Added {
+			lines = append(lines, line.Text)
+		}
+	}
+	return lines
+}
+
+func (instance Hunk) NewLines() []string {
+	lines := make([]string, 0, len(instance.Lines))
+	for _, line := range instance.Lines {
+		if line.Kind != Removed {
+			lines = append(lines, line.Text)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,118 @@
+package askuser
+
+import (
+	"DevCode/constants"
+	"DevCode/dto"
+	"DevCode/events"
+	"DevCode/tools"
+	"DevCode/types"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	AskUserDescription = `Asks the user a multiple-choice question and waits for the answer.\n\nWhen to
+  use:\n- The request is ambiguous and the possible readings lead to different changes\n- A
+  decision is the user's to make, such as which of two approaches to take or whether to
+  touch a file outside the task\n\nUsage:\n- Do not guess when the answer matters; ask instead\n-
+  Give 2 to 6 short, distinct options and put the one you recommend first\n- Set other to
+  let the user type an answer of their own when the options may not cover it\n- Ask one
+  question per call and do not ask about things you can find out with other tools\n- The
+  user may dismiss the question; then continue only with what is certain`
+	Name = "AskUser"
+
+	MinOptions = 2
+	MaxOptions = 6
+
+	DeclinedAnswer = "The user dismissed the question without answering. Do not guess; continue only with what is certain, or explain what you need to know."
+)
+
+func NewTool(bus *events.EventBus) *Tool {
+	tool := &Tool{
+		bus:     bus,
+		waiting: tools.NewWaiter[types.ToolCallID, dto.AnswerData](),
+	}
+	events.Subscribe(bus, bus.AnswerEvent, constants.AskUserTool, func(event events.Event[dto.AnswerData]) {
+		tool.Complete(event.Data)
+	})
+	return tool
+}
+
+type Tool struct {
+	bus     *events.EventBus
+	waiting *tools.Waiter[types.ToolCallID, dto.AnswerData]
+}
+
+func (*Tool) Name() string {
+	return Name
+}
+
+func (*Tool) Description() string {
+	return AskUserDescription
+}
+
+func (instance *Tool) Handler() mcp.ToolHandlerFor[Input, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[Input]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		question := strings.TrimSpace(input.Question)
+		if question == "" {
+			return nil, fmt.Errorf("question must not be empty")
+		}
+		options, err := Options(input.Options)
+		if err != nil {
+			return nil, err
+		}
+		requestID, toolCallID := tools.CallIDs(params.Meta)
+
+		data, err := instance.waiting.Wait(ctx, toolCallID, func() {
+			events.Publish(instance.bus, instance.bus.QuestionEvent, events.Event[dto.QuestionData]{
+				Data: dto.QuestionData{
+					RequestID:  requestID,
+					ToolCallID: toolCallID,
+					Question:   question,
+					Options:    options,
+					Other:      input.Other,
+				},
+				TimeStamp: time.Now(),
+				Source:    constants.AskUserTool,
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+		if data.Declined {
+			return tools.TextReturn(DeclinedAnswer)
+		}
+		return tools.TextReturn(fmt.Sprintf("The user answered: %s", data.Answer))
+	}
+}
+
+// Options trims the options and checks that there are between MinOptions and
+// MaxOptions distinct ones.
+func Options(options []string) ([]string, error) {
+	trimmed := make([]string, 0, len(options))
+	for _, option := range options {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			return nil, fmt.Errorf("options must not be empty")
+		}
+		if slices.Contains(trimmed, option) {
+			return nil, fmt.Errorf("duplicate option: %s", option)
+		}
+		trimmed = append(trimmed, option)
+	}
+	if len(trimmed) < MinOptions || len(trimmed) > MaxOptions {
+		return nil, fmt.Errorf("between %d and %d options are required, got %d", MinOptions, MaxOptions, len(trimmed))
+	}
+	return trimmed, nil
+}
+
+// Complete hands the user's answer to the AskUser call waiting for it.
+func (instance *Tool) Complete(data dto.AnswerData) {
+	instance.waiting.Complete(data.ToolCallID, data)
+}
@@ -0,0 +1,107 @@
+package askuser
+
+import (
+	"DevCode/config"
+	"DevCode/constants"
+	"DevCode/dto"
+	"DevCode/events"
+	"DevCode/tools"
+	"DevCode/types"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newBus(t *testing.T) *events.EventBus {
+	bus, err := events.NewEventBus(config.EventBusConfig{PoolSize: 10}, zap.NewNop())
+	require.NoError(t, err)
+	t.Cleanup(bus.Close)
+	return bus
+}
+
+// reply는 QuestionEvent를 받으면 주어진 답으로 응답하는 가짜 사용자
+func reply(bus *events.EventBus, questions chan<- dto.QuestionData, answer dto.AnswerData) {
+	events.Subscribe(bus, bus.QuestionEvent, constants.ToolManager, func(event events.Event[dto.QuestionData]) {
+		questions <- event.Data
+		answer.ToolCallID = event.Data.ToolCallID
+		events.Publish(bus, bus.AnswerEvent, events.Event[dto.AnswerData]{
+			Data:      answer,
+			TimeStamp: time.Now(),
+			Source:    constants.ToolManager,
+		})
+	})
+}
+
+func TestAskUserReturnsAnswer(t *testing.T) {
+	bus := newBus(t)
+	questions := make(chan dto.QuestionData, 1)
+	reply(bus, questions, dto.AnswerData{Answer: "Postgres"})
+
+	requestID := types.NewRequestID()
+	toolCallID := types.NewToolCallID()
+	tool := NewTool(bus)
+	result, err := tool.Handler()(context.Background(), nil, &mcp.CallToolParamsFor[Input]{
+		Meta: mcp.Meta{
+			tools.MetaRequestID:  requestID.String(),
+			tools.MetaToolCallID: toolCallID.String(),
+		},
+		Arguments: Input{Question: " Which database should the cache use? ", Options: []string{"Redis", " Postgres "}, Other: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "The user answered: Postgres", tools.Text(result))
+
+	question := <-questions
+	assert.Equal(t, requestID, question.RequestID)
+	assert.Equal(t, toolCallID, question.ToolCallID)
+	assert.Equal(t, "Which database should the cache use?", question.Question)
+	assert.Equal(t, []string{"Redis", "Postgres"}, question.Options)
+	assert.True(t, question.Other)
+}
+
+func TestAskUserDeclined(t *testing.T) {
+	bus := newBus(t)
+	questions := make(chan dto.QuestionData, 1)
+	reply(bus, questions, dto.AnswerData{Declined: true})
+
+	text, err := tools.CallText(context.Background(), NewTool(bus), Input{Question: "Rename the package?", Options: []string{"yes", "no"}})
+	require.NoError(t, err)
+	assert.Equal(t, DeclinedAnswer, text)
+}
+
+func TestAskUserCancelled(t *testing.T) {
+	bus := newBus(t)
+	tool := NewTool(bus)
+
+	// 답이 오지 않으면 context가 끝날 때 돌아온다
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := tool.Handler()(ctx, nil, &mcp.CallToolParamsFor[Input]{Arguments: Input{Question: "Rename?", Options: []string{"yes", "no"}}})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Zero(t, tool.waiting.Len())
+}
+
+func TestAskUserRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		input Input
+		err   string
+	}{
+		{name: "empty question", input: Input{Question: " ", Options: []string{"a", "b"}}, err: "question must not be empty"},
+		{name: "one option", input: Input{Question: "q", Options: []string{"a"}}, err: "between 2 and 6 options are required, got 1"},
+		{name: "too many options", input: Input{Question: "q", Options: []string{"a", "b", "c", "d", "e", "f", "g"}}, err: "got 7"},
+		{name: "empty option", input: Input{Question: "q", Options: []string{"a", ""}}, err: "options must not be empty"},
+		{name: "duplicate option", input: Input{Question: "q", Options: []string{"a", " a"}}, err: "duplicate option: a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tools.CallText(context.Background(), NewTool(newBus(t)), tt.input)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
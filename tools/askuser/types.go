@@ -0,0 +1,7 @@
+package askuser
+
+type Input struct {
+	Question string   `json:"question" jsonschema:"description:The question to ask the user. Make it specific and self-contained"`
+	Options  []string `json:"options" jsonschema:"description:Between 2 and 6 short answers for the user to choose from"`
+	Other    bool     `json:"other,omitempty" jsonschema:"description:Also let the user type an answer of their own instead of choosing an option"`
+}
@@ -0,0 +1,193 @@
+package bash
+
+import (
+	"DevCode/config"
+	"DevCode/tools"
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	BashDescription = `Executes a given command in a persistent shell session with optional
+  timeout.\n\nUsage:\n- The command argument is required.\n- You can specify an optional
+  timeout in milliseconds. If not specified, commands will timeout after the configured
+  default. Timeouts above the configured maximum are clamped.\n- The working directory
+  persists between calls, so 'cd' in one call affects the following calls.\n- Stdout and
+  stderr are captured together and returned with the exit code.\n- If the output is too
+  large it will be truncated in the middle before being returned to you.\n- VERY
+  IMPORTANT: You MUST avoid using search commands like 'find' and 'grep'. Instead use Grep,
+  Glob, or Task to search. You MUST avoid read tools like 'cat', 'head', 'tail', and 'ls',
+  and use Read and List to read files.\n- When issuing multiple commands, use the ';' or
+  '&&' operator to separate them. DO NOT use newlines (newlines are ok in quoted strings).\n-
+  Set run_in_background to true for long-running commands such as dev servers. The tool
+  returns a shell ID immediately; use ShellOutput to poll its output and KillShell to stop
+  it.`
+	Name = "Bash"
+
+	pwdMarkerWait = 100 * time.Millisecond
+	waitDelay     = 500 * time.Millisecond
+)
+
+func NewTool(config config.BashConfig, shells *ShellRegistry) *Tool {
+	config.Default()
+	cwd, err := os.Getwd()
+	if err != nil {
+		cwd = "/"
+	}
+	return &Tool{
+		config:     config,
+		shells:     shells,
+		initialCwd: cwd,
+		cwd:        cwd,
+	}
+}
+
+type Tool struct {
+	config     config.BashConfig
+	shells     *ShellRegistry
+	initialCwd string
+	cwd        string
+	cwdMutex   sync.RWMutex
+}
+
+func (*Tool) Name() string {
+	return Name
+}
+
+func (*Tool) Description() string {
+	return BashDescription
+}
+
+func (instance *Tool) Handler() mcp.ToolHandlerFor[Input, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[Input]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		if strings.TrimSpace(input.Command) == "" {
+			return nil, fmt.Errorf("command must not be empty")
+		}
+		if input.Background {
+			shell, err := instance.shells.Start(instance.config.Shell, input.Command, instance.workingDirectory(), instance.config.MaxOutputSize)
+			if err != nil {
+				return nil, err
+			}
+			return tools.TextReturn(fmt.Sprintf("Command running in background with ID: %s\nUse ShellOutput to read its output and KillShell to stop it.", shell.ID))
+		}
+		result, err := instance.Run(ctx, input.Command, instance.Timeout(input.Timeout))
+		if err != nil {
+			return nil, err
+		}
+		return tools.TextReturn(FormatResult(result, instance.Timeout(input.Timeout)))
+	}
+}
+
+func (instance *Tool) Timeout(milliseconds int) time.Duration {
+	if milliseconds <= 0 {
+		milliseconds = instance.config.DefaultTimeout
+	}
+	milliseconds = min(milliseconds, instance.config.MaxTimeout)
+	return time.Duration(milliseconds) * time.Millisecond
+}
+
+func (instance *Tool) Cwd() string {
+	instance.cwdMutex.RLock()
+	defer instance.cwdMutex.RUnlock()
+	return instance.cwd
+}
+
+func (instance *Tool) setCwd(cwd string) {
+	instance.cwdMutex.Lock()
+	defer instance.cwdMutex.Unlock()
+	instance.cwd = cwd
+}
+
+func (instance *Tool) workingDirectory() string {
+	cwd := instance.Cwd()
+	if info, err := os.Stat(cwd); err != nil || !info.IsDir() {
+		instance.setCwd(instance.initialCwd)
+		return instance.initialCwd
+	}
+	return cwd
+}
+
+func (instance *Tool) Run(ctx context.Context, command string, timeout time.Duration) (Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return Result{}, fmt.Errorf("fail to create pipe: %w", err)
+	}
+	defer reader.Close()
+
+	output := NewOutputBuffer(instance.config.MaxOutputSize)
+	cmd := exec.CommandContext(ctx, instance.config.Shell, "-c", WrapCommand(command))
+	cmd.Dir = instance.workingDirectory()
+	cmd.Stdout = output
+	cmd.Stderr = output
+	cmd.ExtraFiles = []*os.File{writer}
+	cmd.WaitDelay = waitDelay
+	configureProcess(cmd)
+
+	if err := cmd.Start(); err != nil {
+		writer.Close()
+		return Result{}, fmt.Errorf("fail to start command: %w", err)
+	}
+	writer.Close()
+
+	pwd := make(chan string, 1)
+	go func() {
+		line, _ := bufio.NewReader(reader).ReadString('\n')
+		pwd <- strings.TrimSpace(line)
+	}()
+
+	waitErr := cmd.Wait()
+	result := Result{
+		Output:   output.String(),
+		ExitCode: cmd.ProcessState.ExitCode(),
+		TimedOut: errors.Is(ctx.Err(), context.DeadlineExceeded),
+		Cwd:      cmd.Dir,
+	}
+	if waitErr != nil && !errors.Is(waitErr, exec.ErrWaitDelay) {
+		var exitErr *exec.ExitError
+		if !errors.As(waitErr, &exitErr) && !result.TimedOut {
+			return Result{}, fmt.Errorf("fail to run command: %w", waitErr)
+		}
+	}
+	select {
+	case dir := <-pwd:
+		if dir != "" {
+			result.Cwd = dir
+			instance.setCwd(dir)
+		}
+	case <-time.After(pwdMarkerWait):
+	}
+	return result, nil
+}
+
+func WrapCommand(command string) string {
+	return command + "\n__devcode_status=$?\npwd -P >&3\nexit $__devcode_status\n"
+}
+
+func FormatResult(result Result, timeout time.Duration) string {
+	var builder strings.Builder
+	output := strings.TrimRight(result.Output, "\n")
+	if output == "" {
+		builder.WriteString("(no output)\n")
+	} else {
+		builder.WriteString(output + "\n")
+	}
+	if result.TimedOut {
+		fmt.Fprintf(&builder, "Command timed out after %d ms\n", timeout.Milliseconds())
+	}
+	fmt.Fprintf(&builder, "Exit code: %d\n", result.ExitCode)
+	fmt.Fprintf(&builder, "Cwd: %s", result.Cwd)
+	return builder.String()
+}
@@ -0,0 +1,74 @@
+package bash
+
+import (
+	"DevCode/config"
+	"DevCode/tools"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBashCapturesOutputAndExitCode(t *testing.T) {
+	tool := NewTool(config.BashConfig{}, NewShellRegistry())
+
+	text, err := tools.CallText(context.Background(), tool, Input{Command: "echo out; echo err 1>&2; exit 3"})
+	require.NoError(t, err)
+	assert.Contains(t, text, "out")
+	assert.Contains(t, text, "err")
+	assert.Contains(t, text, "Exit code: 3")
+}
+
+func TestBashPersistsWorkingDirectory(t *testing.T) {
+	tool := NewTool(config.BashConfig{}, NewShellRegistry())
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0755))
+
+	_, err = tools.CallText(context.Background(), tool, Input{Command: "cd " + dir + "/sub"})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "sub"), tool.Cwd())
+
+	text, err := tools.CallText(context.Background(), tool, Input{Command: "pwd"})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(text, filepath.Join(dir, "sub")))
+}
+
+func TestBashTimeout(t *testing.T) {
+	tool := NewTool(config.BashConfig{DefaultTimeout: 200, MaxTimeout: 200}, NewShellRegistry())
+
+	start := time.Now()
+	text, err := tools.CallText(context.Background(), tool, Input{Command: "sleep 5", Timeout: 10000})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 3*time.Second)
+	assert.Contains(t, text, "timed out after 200 ms")
+}
+
+func TestBashTruncatesOutput(t *testing.T) {
+	tool := NewTool(config.BashConfig{MaxOutputSize: 100}, NewShellRegistry())
+
+	text, err := tools.CallText(context.Background(), tool, Input{Command: "i=0; while [ $i -lt 200 ]; do echo line$i; i=$((i+1)); done"})
+	require.NoError(t, err)
+	assert.Contains(t, text, "output truncated")
+	assert.Contains(t, text, "line0")
+	assert.Contains(t, text, "line199")
+	assert.NotContains(t, text, "line100\n")
+}
+
+func TestBashRejectsEmptyCommand(t *testing.T) {
+	tool := NewTool(config.BashConfig{}, NewShellRegistry())
+
+	_, err := tools.CallText(context.Background(), tool, Input{Command: "  "})
+	assert.Error(t, err)
+}
+
+func TestOutputBufferWithinLimit(t *testing.T) {
+	buffer := NewOutputBuffer(10)
+	buffer.Write([]byte("hello"))
+	assert.Equal(t, "hello", buffer.String())
+}
@@ -0,0 +1,42 @@
+package bash
+
+import (
+	"DevCode/tools"
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	KillShellDescription = `Kills a running background bash shell by its ID.\n\nUsage:\n-
+  Takes a shell_id parameter identifying the shell to kill\n- The shell and every process it
+  started are terminated\n- Use this tool when you need to terminate a long-running shell`
+	KillShellName = "KillShell"
+)
+
+func NewKillShellTool(shells *ShellRegistry) *KillShellTool {
+	return &KillShellTool{shells: shells}
+}
+
+type KillShellTool struct {
+	shells *ShellRegistry
+}
+
+func (*KillShellTool) Name() string {
+	return KillShellName
+}
+
+func (*KillShellTool) Description() string {
+	return KillShellDescription
+}
+
+func (instance *KillShellTool) Handler() mcp.ToolHandlerFor[KillShellInput, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[KillShellInput]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		if err := instance.shells.Kill(input.ShellID); err != nil {
+			return nil, err
+		}
+		return tools.TextReturn(fmt.Sprintf("Successfully killed shell: %s", input.ShellID))
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package bash
+
+import "os/exec"
+
+func configureProcess(cmd *exec.Cmd) {
+}
@@ -0,0 +1,15 @@
+//go:build unix
+
+package bash
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+func configureProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
@@ -0,0 +1,88 @@
+package bash
+
+import (
+	"DevCode/tools"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	ShellOutputDescription = `Retrieves output from a running or completed background bash
+  shell.\n\nUsage:\n- Takes a shell_id parameter identifying the shell\n- Always returns only
+  new output since the last check\n- Returns stdout and stderr output along with shell
+  status\n- Supports optional regex filtering to show only lines matching a pattern. Lines
+  that do not match are discarded and will not be returned later.\n- Use this tool when you
+  need to monitor or check the output of a long-running shell started with
+  run_in_background`
+	ShellOutputName = "ShellOutput"
+)
+
+func NewShellOutputTool(shells *ShellRegistry) *ShellOutputTool {
+	return &ShellOutputTool{shells: shells}
+}
+
+type ShellOutputTool struct {
+	shells *ShellRegistry
+}
+
+func (*ShellOutputTool) Name() string {
+	return ShellOutputName
+}
+
+func (*ShellOutputTool) Description() string {
+	return ShellOutputDescription
+}
+
+func (instance *ShellOutputTool) Handler() mcp.ToolHandlerFor[ShellOutputInput, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[ShellOutputInput]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		shell, exist := instance.shells.Get(input.ShellID)
+		if !exist {
+			return nil, fmt.Errorf("shell not found: %s", input.ShellID)
+		}
+		var filter *regexp.Regexp
+		if input.Filter != "" {
+			compiled, err := regexp.Compile(input.Filter)
+			if err != nil {
+				return nil, fmt.Errorf("invalid filter: %v", err)
+			}
+			filter = compiled
+		}
+		status, exitCode := shell.Status()
+		output, dropped := shell.ReadNew()
+		if filter != nil {
+			output = FilterLines(output, filter)
+		}
+
+		var builder strings.Builder
+		fmt.Fprintf(&builder, "Status: %s\n", status)
+		if status != Running {
+			fmt.Fprintf(&builder, "Exit code: %d\n", exitCode)
+		}
+		if dropped > 0 {
+			fmt.Fprintf(&builder, "[%d bytes of older output were dropped]\n", dropped)
+		}
+		output = strings.TrimRight(output, "\n")
+		if output == "" {
+			builder.WriteString("(no new output)")
+		} else {
+			builder.WriteString(output)
+		}
+		return tools.TextReturn(builder.String())
+	}
+}
+
+func FilterLines(output string, filter *regexp.Regexp) string {
+	lines := strings.Split(output, "\n")
+	matched := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if filter.MatchString(line) {
+			matched = append(matched, line)
+		}
+	}
+	return strings.Join(matched, "\n")
+}
@@ -0,0 +1,168 @@
+package bash
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"sync"
+)
+
+const (
+	Running   = "running"
+	Completed = "completed"
+	Killed    = "killed"
+	Failed    = "failed"
+)
+
+func NewShellRegistry() *ShellRegistry {
+	return &ShellRegistry{
+		shells: make(map[string]*BackgroundShell),
+	}
+}
+
+type ShellRegistry struct {
+	shells map[string]*BackgroundShell
+	count  int
+	mutex  sync.RWMutex
+}
+
+func (instance *ShellRegistry) Start(shell string, command string, dir string, limit int) (*BackgroundShell, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	background := &BackgroundShell{
+		Command: command,
+		limit:   limit,
+		status:  Running,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	cmd := exec.CommandContext(ctx, shell, "-c", command)
+	cmd.Dir = dir
+	cmd.Stdout = background
+	cmd.Stderr = background
+	cmd.WaitDelay = waitDelay
+	configureProcess(cmd)
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return nil, fmt.Errorf("fail to start command: %w", err)
+	}
+
+	instance.mutex.Lock()
+	instance.count++
+	background.ID = fmt.Sprintf("bash_%d", instance.count)
+	instance.shells[background.ID] = background
+	instance.mutex.Unlock()
+
+	go func() {
+		defer close(background.done)
+		cmd.Wait()
+		background.finish(cmd.ProcessState.ExitCode())
+	}()
+	return background, nil
+}
+
+func (instance *ShellRegistry) Get(id string) (*BackgroundShell, bool) {
+	instance.mutex.RLock()
+	defer instance.mutex.RUnlock()
+	shell, exist := instance.shells[id]
+	return shell, exist
+}
+
+func (instance *ShellRegistry) List() []*BackgroundShell {
+	instance.mutex.RLock()
+	defer instance.mutex.RUnlock()
+	list := make([]*BackgroundShell, 0, len(instance.shells))
+	for _, shell := range instance.shells {
+		list = append(list, shell)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	return list
+}
+
+func (instance *ShellRegistry) Kill(id string) error {
+	shell, exist := instance.Get(id)
+	if !exist {
+		return fmt.Errorf("shell not found: %s", id)
+	}
+	return shell.Kill()
+}
+
+func (instance *ShellRegistry) KillAll() {
+	for _, shell := range instance.List() {
+		shell.Kill()
+	}
+}
+
+type BackgroundShell struct {
+	ID       string
+	Command  string
+	limit    int
+	pending  []byte
+	dropped  int
+	status   string
+	exitCode int
+	cancel   context.CancelFunc
+	done     chan struct{}
+	mutex    sync.Mutex
+}
+
+func (instance *BackgroundShell) Write(data []byte) (int, error) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	instance.pending = append(instance.pending, data...)
+	if overflow := len(instance.pending) - instance.limit; overflow > 0 {
+		instance.dropped += overflow
+		instance.pending = append(instance.pending[:0], instance.pending[overflow:]...)
+	}
+	return len(data), nil
+}
+
+func (instance *BackgroundShell) ReadNew() (string, int) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	output := string(instance.pending)
+	dropped := instance.dropped
+	instance.pending = instance.pending[:0]
+	instance.dropped = 0
+	return output, dropped
+}
+
+func (instance *BackgroundShell) Status() (string, int) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	return instance.status, instance.exitCode
+}
+
+func (instance *BackgroundShell) Done() <-chan struct{} {
+	return instance.done
+}
+
+func (instance *BackgroundShell) Kill() error {
+	instance.mutex.Lock()
+	if instance.status != Running {
+		status := instance.status
+		instance.mutex.Unlock()
+		return fmt.Errorf("shell %s is not running: %s", instance.ID, status)
+	}
+	instance.status = Killed
+	instance.mutex.Unlock()
+	instance.cancel()
+	<-instance.done
+	return nil
+}
+
+func (instance *BackgroundShell) finish(exitCode int) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	instance.exitCode = exitCode
+	if instance.status != Running {
+		return
+	}
+	if exitCode == 0 {
+		instance.status = Completed
+	} else {
+		instance.status = Failed
+	}
+}
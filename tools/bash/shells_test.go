@@ -0,0 +1,92 @@
+package bash
+
+import (
+	"DevCode/config"
+	"DevCode/tools"
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackgroundShellOutputPolling(t *testing.T) {
+	shells := NewShellRegistry()
+	defer shells.KillAll()
+	tool := NewTool(config.BashConfig{}, shells)
+	outputTool := NewShellOutputTool(shells)
+
+	text, err := tools.CallText(context.Background(), tool, Input{Command: "echo first; echo skip; sleep 0.2; echo second", Background: true})
+	require.NoError(t, err)
+	assert.Contains(t, text, "bash_1")
+
+	shell, exist := shells.Get("bash_1")
+	require.True(t, exist)
+	select {
+	case <-shell.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("background shell did not finish")
+	}
+
+	text, err = tools.CallText(context.Background(), outputTool, ShellOutputInput{ShellID: "bash_1", Filter: "^(first|second)$"})
+	require.NoError(t, err)
+	assert.Contains(t, text, "Status: completed")
+	assert.Contains(t, text, "first")
+	assert.Contains(t, text, "second")
+	assert.NotContains(t, text, "skip")
+
+	// 이미 읽은 출력은 다시 반환되지 않아야 함
+	text, err = tools.CallText(context.Background(), outputTool, ShellOutputInput{ShellID: "bash_1"})
+	require.NoError(t, err)
+	assert.Contains(t, text, "(no new output)")
+}
+
+func TestKillShell(t *testing.T) {
+	shells := NewShellRegistry()
+	defer shells.KillAll()
+	tool := NewTool(config.BashConfig{}, shells)
+	killTool := NewKillShellTool(shells)
+
+	_, err := tools.CallText(context.Background(), tool, Input{Command: "sleep 30", Background: true})
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = tools.Call(context.Background(), killTool, KillShellInput{ShellID: "bash_1"})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 3*time.Second)
+
+	shell, _ := shells.Get("bash_1")
+	status, _ := shell.Status()
+	assert.Equal(t, Killed, status)
+
+	// 이미 종료된 shell은 다시 kill 할 수 없음
+	_, err = tools.Call(context.Background(), killTool, KillShellInput{ShellID: "bash_1"})
+	assert.Error(t, err)
+}
+
+func TestShellRegistryKillAll(t *testing.T) {
+	shells := NewShellRegistry()
+	first, err := shells.Start("/bin/sh", "sleep 30", t.TempDir(), 100)
+	require.NoError(t, err)
+	second, err := shells.Start("/bin/sh", "sleep 30", t.TempDir(), 100)
+	require.NoError(t, err)
+
+	shells.KillAll()
+
+	for _, shell := range []*BackgroundShell{first, second} {
+		status, _ := shell.Status()
+		assert.Equal(t, Killed, status)
+	}
+}
+
+func TestShellOutputUnknownShell(t *testing.T) {
+	_, err := tools.CallText(context.Background(), NewShellOutputTool(NewShellRegistry()), ShellOutputInput{ShellID: "bash_99"})
+	assert.Error(t, err)
+}
+
+func TestFilterLines(t *testing.T) {
+	result := FilterLines("ok 1\nFAIL 2\nok 3", regexp.MustCompile("^ok"))
+	assert.Equal(t, "ok 1\nok 3", result)
+}
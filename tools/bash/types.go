@@ -0,0 +1,75 @@
+package bash
+
+import (
+	"fmt"
+	"sync"
+)
+
+type Input struct {
+	Command     string `json:"command" jsonschema:"description:The command to execute"`
+	Timeout     int    `json:"timeout,omitempty" jsonschema:"description:Optional timeout in milliseconds"`
+	Description string `json:"description,omitempty" jsonschema:"description:Clear, concise description of what this command does in 5-10 words"`
+	Background  bool   `json:"run_in_background,omitempty" jsonschema:"description:Set to true to run this command in the background. Use ShellOutput to read the output later"`
+}
+
+type ShellOutputInput struct {
+	ShellID string `json:"shell_id" jsonschema:"description:The ID of the background shell to retrieve output from"`
+	Filter  string `json:"filter,omitempty" jsonschema:"description:Optional regular expression to filter the output lines"`
+}
+
+type KillShellInput struct {
+	ShellID string `json:"shell_id" jsonschema:"description:The ID of the background shell to kill"`
+}
+
+type Result struct {
+	Output   string
+	ExitCode int
+	TimedOut bool
+	Cwd      string
+}
+
+func NewOutputBuffer(limit int) *OutputBuffer {
+	return &OutputBuffer{
+		limit: limit,
+		head:  make([]byte, 0, limit/2),
+		tail:  make([]byte, 0, limit-limit/2),
+	}
+}
+
+type OutputBuffer struct {
+	limit   int
+	head    []byte
+	tail    []byte
+	dropped int
+	mutex   sync.Mutex
+}
+
+func (instance *OutputBuffer) Write(data []byte) (int, error) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	written := len(data)
+	if room := cap(instance.head) - len(instance.head); room > 0 {
+		size := min(room, len(data))
+		instance.head = append(instance.head, data[:size]...)
+		data = data[size:]
+	}
+	if len(data) == 0 {
+		return written, nil
+	}
+	tailLimit := instance.limit - cap(instance.head)
+	instance.tail = append(instance.tail, data...)
+	if overflow := len(instance.tail) - tailLimit; overflow > 0 {
+		instance.dropped += overflow
+		instance.tail = append(instance.tail[:0], instance.tail[overflow:]...)
+	}
+	return written, nil
+}
+
+func (instance *OutputBuffer) String() string {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	if instance.dropped == 0 {
+		return string(instance.head) + string(instance.tail)
+	}
+	return fmt.Sprintf("%s\n\n... [output truncated: %d bytes omitted] ...\n\n%s", instance.head, instance.dropped, instance.tail)
+}
@@ -0,0 +1,212 @@
+package diagnostics
+
+import (
+	"DevCode/config"
+	"DevCode/tools"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	DiagnosticsDescription = `Runs the project's build checks and reports every problem as
+  file:line:column: severity: message.\n\nUsage:\n- For Go projects the checks are go build
+  ./... and go vet ./... unless env.toml configures others\n- The project is found by
+  walking up from path to the nearest marker file such as go.mod\n- Run this after every
+  edit to confirm the code still compiles. It is much cheaper than reading build output
+  through Bash, because duplicate reports are merged and the list is capped\n- Use the
+  LspDiagnostics tool instead when you only need the problems of a single file`
+	Name = "Diagnostics"
+
+	MaxOtherLines = 20
+)
+
+func NewTool(config config.DiagnosticsConfig) *Tool {
+	return &Tool{config: config}
+}
+
+type Tool struct {
+	config config.DiagnosticsConfig
+}
+
+func (*Tool) Name() string {
+	return Name
+}
+
+func (*Tool) Description() string {
+	return DiagnosticsDescription
+}
+
+func (instance *Tool) Handler() mcp.ToolHandlerFor[Input, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[Input]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		start := input.Path
+		if start == "" {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return nil, fmt.Errorf("fail to get working directory: %w", err)
+			}
+			start = cwd
+		}
+		if !filepath.IsAbs(start) {
+			return nil, fmt.Errorf("invalid path format: %s", input.Path)
+		}
+		info, err := os.Stat(start)
+		if err != nil {
+			return nil, fmt.Errorf("path not found: %s", start)
+		}
+		if !info.IsDir() {
+			start = filepath.Dir(start)
+		}
+		language, root, err := FindProject(instance.config.Languages, start, input.Language)
+		if err != nil {
+			return nil, err
+		}
+		timeout := time.Duration(instance.config.Timeout) * time.Millisecond
+		results := make([]CheckResult, 0, len(language.Checks))
+		for _, check := range language.Checks {
+			results = append(results, RunCheck(ctx, root, check, timeout))
+		}
+		return tools.TextReturn(Summary(root, results, instance.config.MaxEntries))
+	}
+}
+
+// FindProject walks up from dir to the nearest directory holding a marker
+// file of a configured language, or of the named language when given.
+func FindProject(languages []config.DiagnosticsLanguageConfig, dir string, name string) (config.DiagnosticsLanguageConfig, string, error) {
+	candidates := languages
+	if name != "" {
+		candidates = nil
+		var known []string
+		for _, language := range languages {
+			known = append(known, language.Name)
+			if strings.EqualFold(language.Name, name) {
+				candidates = append(candidates, language)
+			}
+		}
+		if len(candidates) == 0 {
+			return config.DiagnosticsLanguageConfig{}, "", fmt.Errorf("no checks configured for language %s; configured languages: %s", name, strings.Join(known, ", "))
+		}
+	}
+	for current := dir; ; current = filepath.Dir(current) {
+		for _, language := range candidates {
+			for _, marker := range language.Markers {
+				if _, err := os.Stat(filepath.Join(current, marker)); err == nil {
+					return language, current, nil
+				}
+			}
+		}
+		if current == filepath.Dir(current) {
+			break
+		}
+	}
+	var markers []string
+	for _, language := range candidates {
+		markers = append(markers, language.Markers...)
+	}
+	return config.DiagnosticsLanguageConfig{}, "", fmt.Errorf("no project found: none of %s exist in %s or its parents", strings.Join(markers, ", "), dir)
+}
+
+// RunCheck runs one check command in root without a shell.
+func RunCheck(ctx context.Context, root string, check config.DiagnosticsCheckConfig, timeout time.Duration) CheckResult {
+	result := CheckResult{Command: check.Command}
+	fields := strings.Fields(check.Command)
+	if len(fields) == 0 {
+		result.Status = "skipped (empty command)"
+		return result
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, fields[0], fields[1:]...)
+	cmd.Dir = root
+	cmd.WaitDelay = 5 * time.Second
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		result.Status = fmt.Sprintf("timed out after %s", timeout)
+	case errors.As(err, &exitErr):
+		result.Status = fmt.Sprintf("failed, exit code %d", exitErr.ExitCode())
+	case err != nil:
+		result.Status = "could not run"
+		result.Other = []string{err.Error()}
+		return result
+	default:
+		result.Status = "ok"
+	}
+	entries, other := Parse(output.String(), root, root, check.Severity)
+	result.Entries = entries
+	result.Other = append(result.Other, other...)
+	return result
+}
+
+func Summary(root string, results []CheckResult, maxEntries int) string {
+	var all []Entry
+	commands := make([]string, 0, len(results))
+	for _, result := range results {
+		all = append(all, result.Entries...)
+		commands = append(commands, fmt.Sprintf("%s (%s)", result.Command, result.Status))
+	}
+	entries := Merge(all)
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Checked %s with %s\n", root, strings.Join(commands, ", "))
+	failed := false
+	for _, result := range results {
+		failed = failed || result.Status != "ok"
+	}
+	if len(entries) == 0 && failed {
+		builder.WriteString("No file diagnostics found, but not every check passed")
+	} else if len(entries) == 0 {
+		builder.WriteString("No problems found")
+	} else {
+		counts := make(map[string]int)
+		for _, entry := range entries {
+			counts[entry.Severity]++
+		}
+		var summary []string
+		for _, severity := range []string{"error", "warning", "info", "hint"} {
+			if counts[severity] > 0 {
+				summary = append(summary, fmt.Sprintf("%d %s", counts[severity], plural(severity, counts[severity])))
+			}
+		}
+		builder.WriteString(strings.Join(summary, ", "))
+		for index, entry := range entries {
+			if index == maxEntries {
+				fmt.Fprintf(&builder, "\n... %d more not shown", len(entries)-maxEntries)
+				break
+			}
+			builder.WriteString("\n" + entry.String())
+		}
+	}
+	// 진단으로 읽히지 않았는데 실패한 검사는 원래 출력을 보여 준다
+	for _, result := range results {
+		if result.Status == "ok" || len(result.Other) == 0 || len(result.Entries) > 0 {
+			continue
+		}
+		other := result.Other
+		if len(other) > MaxOtherLines {
+			other = append(other[:MaxOtherLines:MaxOtherLines], fmt.Sprintf("... %d more lines", len(result.Other)-MaxOtherLines))
+		}
+		fmt.Fprintf(&builder, "\n\nOutput of %s:\n%s", result.Command, strings.Join(other, "\n"))
+	}
+	return builder.String()
+}
+
+func plural(severity string, count int) string {
+	if count == 1 || severity == "info" {
+		return severity
+	}
+	return severity + "s"
+}
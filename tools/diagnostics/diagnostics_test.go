@@ -0,0 +1,136 @@
+package diagnostics
+
+import (
+	"DevCode/config"
+	"DevCode/tools"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newModule(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	files["go.mod"] = "module example.com/checks\n\ngo 1.22\n"
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return root
+}
+
+func defaultTool() *Tool {
+	diagnosticsConfig := config.DiagnosticsConfig{}
+	diagnosticsConfig.Default()
+	return NewTool(diagnosticsConfig)
+}
+
+func TestDiagnosticsGo(t *testing.T) {
+	if testing.Short() {
+		t.Skip("go build를 실행하므로 short 모드에서는 건너뛴다")
+	}
+	root := newModule(t, map[string]string{
+		"broken/broken.go": "package broken\n\nfunc Value() int {\n\treturn missing\n}\n",
+		"vetted/vetted.go": "package vetted\n\nimport \"fmt\"\n\nfunc Print() {\n\tfmt.Printf(\"%d\\n\", \"text\")\n}\n",
+	})
+
+	output, err := tools.CallText(context.Background(), defaultTool(), Input{Path: filepath.Join(root, "vetted", "vetted.go")})
+	require.NoError(t, err)
+	lines := strings.Split(output, "\n")
+	assert.Equal(t, "Checked "+root+" with go build ./... (failed, exit code 1), go vet ./... (failed, exit code 1)", lines[0])
+	// go build와 go vet가 같은 타입 오류를 보고해도 한 번만 나온다
+	assert.Equal(t, "1 error, 1 warning", lines[1])
+	assert.Equal(t, "broken/broken.go:4:9: error: undefined: missing", lines[2])
+	assert.True(t, strings.HasPrefix(lines[3], "vetted/vetted.go:6:14: warning: fmt.Printf format %d has arg \"text\" of wrong type string"), lines[3])
+	assert.Len(t, lines, 4)
+}
+
+func TestDiagnosticsClean(t *testing.T) {
+	if testing.Short() {
+		t.Skip("go build를 실행하므로 short 모드에서는 건너뛴다")
+	}
+	root := newModule(t, map[string]string{
+		"clean/clean.go": "package clean\n\nfunc Value() int {\n\treturn 1\n}\n",
+	})
+
+	output, err := tools.CallText(context.Background(), defaultTool(), Input{Path: root, Language: "Go"})
+	require.NoError(t, err)
+	assert.Equal(t, "Checked "+root+" with go build ./... (ok), go vet ./... (ok)\nNo problems found", output)
+}
+
+func TestDiagnosticsConfiguredChecks(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "package.json"), []byte("{}"), 0644))
+	script := filepath.Join(root, "lint.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\necho 'src/app.ts(3,1): not parsed'\necho 'src/app.ts:3:5: error TS2304: Cannot find name x'\necho 'src/app.ts:9:1: warning: unused'\necho 'src/b.ts:1:1: error TS2304: Cannot find name y'\nexit 2\n"), 0755))
+
+	tool := NewTool(config.DiagnosticsConfig{
+		Languages: []config.DiagnosticsLanguageConfig{{
+			Name:    "typescript",
+			Markers: []string{"package.json"},
+			Checks:  []config.DiagnosticsCheckConfig{{Command: script, Severity: "error"}},
+		}},
+		Timeout:    10000,
+		MaxEntries: 2,
+	})
+	output, err := tools.CallText(context.Background(), tool, Input{Path: root})
+	require.NoError(t, err)
+	assert.Equal(t, "Checked "+root+" with "+script+" (failed, exit code 2)\n2 errors, 1 warning\nsrc/app.ts:3:5: error: TS2304: Cannot find name x\nsrc/app.ts:9:1: warning: unused\n... 1 more not shown", output)
+}
+
+func TestDiagnosticsFailureWithoutEntries(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "Makefile"), []byte(""), 0644))
+	tool := NewTool(config.DiagnosticsConfig{
+		Languages: []config.DiagnosticsLanguageConfig{{
+			Name:    "make",
+			Markers: []string{"Makefile"},
+			Checks:  []config.DiagnosticsCheckConfig{{Command: "sh -c exit", Severity: "error"}, {Command: "/nonexistent/checker", Severity: "error"}},
+		}},
+		Timeout:    10000,
+		MaxEntries: 10,
+	})
+	output, err := tools.CallText(context.Background(), tool, Input{Path: root})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(output, "Checked "+root+" with sh -c exit (ok), /nonexistent/checker (could not run)\nNo file diagnostics found, but not every check passed\n\nOutput of /nonexistent/checker:\n"), output)
+}
+
+func TestDiagnosticsErrors(t *testing.T) {
+	tool := defaultTool()
+
+	_, err := tools.CallText(context.Background(), tool, Input{Path: "relative"})
+	assert.ErrorContains(t, err, "invalid path format")
+
+	_, err = tools.CallText(context.Background(), tool, Input{Path: t.TempDir()})
+	assert.ErrorContains(t, err, "no project found: none of go.mod exist in")
+
+	_, err = tools.CallText(context.Background(), tool, Input{Path: t.TempDir(), Language: "rust"})
+	assert.ErrorContains(t, err, "no checks configured for language rust; configured languages: go")
+}
+
+func TestParse(t *testing.T) {
+	output := "# example.com/checks/broken\n" +
+		"broken/broken.go:4:9: cannot use x (variable of type int) as string value in return statement\n" +
+		"\thave (int)\n\twant (string)\n" +
+		"vet: /abs/other.go:2: warning: odd\n" +
+		"go: downloading nothing\n"
+	entries, other := Parse(output, "/project", "/project", "error")
+	require.Len(t, entries, 2)
+	assert.Equal(t, Entry{File: "broken/broken.go", Line: 4, Column: 9, Severity: "error", Message: "cannot use x (variable of type int) as string value in return statement; have (int); want (string)"}, entries[0])
+	assert.Equal(t, Entry{File: "/abs/other.go", Line: 2, Severity: "warning", Message: "odd"}, entries[1])
+	assert.Equal(t, []string{"go: downloading nothing"}, other)
+	assert.Equal(t, "/abs/other.go:2: warning: odd", entries[1].String())
+
+	merged := Merge([]Entry{
+		{File: "b.go", Line: 1, Message: "x", Severity: "warning"},
+		{File: "a.go", Line: 3, Message: "y", Severity: "warning"},
+		{File: "b.go", Line: 1, Message: "x", Severity: "error"},
+	})
+	assert.Equal(t, []Entry{{File: "a.go", Line: 3, Message: "y", Severity: "warning"}, {File: "b.go", Line: 1, Message: "x", Severity: "error"}}, merged)
+}
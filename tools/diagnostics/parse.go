@@ -0,0 +1,111 @@
+package diagnostics
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	entryPattern    = regexp.MustCompile(`^(?:vet: )?([^\s:][^:]*\.[A-Za-z0-9]+):(\d+)(?::(\d+))?:\s*(.*)$`)
+	severityPattern = regexp.MustCompile(`(?i)^(fatal error|error|warning|note|info|hint)(?:\[([^\]]*)\]|\s+([A-Za-z]+\d+))?:\s*(.*)$`)
+)
+
+var severityRank = map[string]int{"error": 0, "warning": 1, "info": 2, "hint": 3}
+
+// Parse reads compiler style output made of "file:line[:column]: message"
+// lines. Tab indented lines continue the previous message, as in Go's
+// "have/want" notes. Relative files are resolved against dir and reported
+// relative to root. Lines that are not diagnostics are returned as other.
+func Parse(output string, dir string, root string, severity string) ([]Entry, []string) {
+	var entries []Entry
+	var other []string
+	for _, line := range strings.Split(strings.ReplaceAll(output, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "# ") {
+			continue
+		}
+		if strings.HasPrefix(line, "\t") && len(entries) > 0 {
+			last := &entries[len(entries)-1]
+			last.Message += "; " + strings.TrimSpace(line)
+			continue
+		}
+		match := entryPattern.FindStringSubmatch(line)
+		if match == nil {
+			other = append(other, line)
+			continue
+		}
+		entry := Entry{File: match[1], Severity: severity, Message: strings.TrimSpace(match[4])}
+		entry.Line, _ = strconv.Atoi(match[2])
+		entry.Column, _ = strconv.Atoi(match[3])
+		if found := severityPattern.FindStringSubmatch(entry.Message); found != nil {
+			entry.Severity = normalize(found[1])
+			entry.Message = found[4]
+			if code := found[2] + found[3]; code != "" {
+				entry.Message = code + ": " + entry.Message
+			}
+		}
+		path := entry.File
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		if relative, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(relative, "..") {
+			entry.File = relative
+		} else {
+			entry.File = path
+		}
+		entries = append(entries, entry)
+	}
+	return entries, other
+}
+
+func normalize(severity string) string {
+	switch strings.ToLower(severity) {
+	case "fatal error", "error":
+		return "error"
+	case "warning":
+		return "warning"
+	case "note", "info":
+		return "info"
+	}
+	return "hint"
+}
+
+// Merge removes entries reported more than once, for example a type error
+// found by both go build and go vet, keeping the most severe one, and sorts
+// the rest by file and position.
+func Merge(entries []Entry) []Entry {
+	seen := make(map[string]int)
+	var merged []Entry
+	for _, entry := range entries {
+		key := fmt.Sprintf("%s:%d:%d:%s", entry.File, entry.Line, entry.Column, entry.Message)
+		if index, ok := seen[key]; ok {
+			if severityRank[entry.Severity] < severityRank[merged[index].Severity] {
+				merged[index].Severity = entry.Severity
+			}
+			continue
+		}
+		seen[key] = len(merged)
+		merged = append(merged, entry)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		if merged[i].File != merged[j].File {
+			return merged[i].File < merged[j].File
+		}
+		if merged[i].Line != merged[j].Line {
+			return merged[i].Line < merged[j].Line
+		}
+		return merged[i].Column < merged[j].Column
+	})
+	return merged
+}
+
+func (instance Entry) String() string {
+	position := fmt.Sprintf("%s:%d", instance.File, instance.Line)
+	if instance.Column > 0 {
+		position += fmt.Sprintf(":%d", instance.Column)
+	}
+	return fmt.Sprintf("%s: %s: %s", position, instance.Severity, instance.Message)
+}
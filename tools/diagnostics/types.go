@@ -0,0 +1,21 @@
+package diagnostics
+
+type Input struct {
+	Path     string `json:"path,omitempty" jsonschema:"description:The absolute path of a file or directory inside the project. Defaults to the current working directory"`
+	Language string `json:"language,omitempty" jsonschema:"description:The language whose checks to run, such as go. Defaults to the language of the nearest project marker file"`
+}
+
+type Entry struct {
+	File     string
+	Line     int
+	Column   int
+	Severity string
+	Message  string
+}
+
+type CheckResult struct {
+	Command string
+	Status  string
+	Entries []Entry
+	Other   []string
+}
@@ -0,0 +1,115 @@
+package edit
+
+import (
+	"DevCode/tools"
+	"DevCode/tools/write"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	EditDescription = `Performs exact string replacements in files.\n\nUsage:\n- You must use
+  your Read tool at least once in the conversation before editing, so that old_string
+  matches the file as it is now.\n- When editing text from Read tool output,
+  ensure you preserve the exact indentation (tabs/spaces) as it appears AFTER the line
+  number prefix. Never include any part of the line number prefix in the old_string or
+  new_string.\n- ALWAYS prefer editing existing files in the codebase. NEVER write new files
+  unless explicitly required.\n- The edit will FAIL if old_string is not unique in the file.
+  Either provide a larger string with more surrounding context to make it unique or use
+  replace_all to change every instance of old_string.\n- Use replace_all for replacing and
+  renaming strings across the file. This parameter is useful if you want to rename a
+  variable for instance.`
+	Name = "Edit"
+)
+
+type Tool struct {
+}
+
+func (*Tool) Name() string {
+	return Name
+}
+
+func (*Tool) Description() string {
+	return EditDescription
+}
+
+func (instance *Tool) Handler() mcp.ToolHandlerFor[Input, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[Input]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		content, mode, err := ReadFile(input.FilePath)
+		if err != nil {
+			return nil, err
+		}
+		updated, count, err := Replace(content, input.OldString, input.NewString, input.ReplaceAll)
+		if err != nil {
+			return nil, err
+		}
+		if err := write.WriteFile(input.FilePath, []byte(updated), mode); err != nil {
+			return nil, err
+		}
+		if count > 1 {
+			return tools.TextReturn(fmt.Sprintf("The file %s has been updated. Replaced %d occurrences.", input.FilePath, count))
+		}
+		return tools.TextReturn(fmt.Sprintf("The file %s has been updated.", input.FilePath))
+	}
+}
+
+func ReadFile(path string) (string, os.FileMode, error) {
+	if path == "" || !filepath.IsAbs(path) {
+		return "", 0, fmt.Errorf("invalid path format: %s", path)
+	}
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return "", 0, fmt.Errorf("file not found: %s", path)
+	}
+	if err != nil {
+		if os.IsPermission(err) {
+			return "", 0, fmt.Errorf("permission denied: %s", path)
+		}
+		return "", 0, fmt.Errorf("invalid path format: %s", path)
+	}
+	if info.IsDir() {
+		return "", 0, fmt.Errorf("path is a directory: %s", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsPermission(err) {
+			return "", 0, fmt.Errorf("permission denied: %s", path)
+		}
+		return "", 0, fmt.Errorf("fail to read file: %s", path)
+	}
+	return string(data), info.Mode().Perm(), nil
+}
+
+func Replace(content string, oldString string, newString string, replaceAll bool) (string, int, error) {
+	if oldString == "" {
+		return "", 0, fmt.Errorf("old_string must not be empty")
+	}
+	if oldString == newString {
+		return "", 0, fmt.Errorf("no changes to make: old_string and new_string are exactly the same")
+	}
+	if strings.Contains(content, "\r\n") && !strings.Contains(oldString, "\r\n") {
+		oldString = ToCRLF(oldString)
+		newString = ToCRLF(newString)
+	}
+	count := strings.Count(content, oldString)
+	if count == 0 {
+		return "", 0, fmt.Errorf("string to replace not found in file.\nString: %s", oldString)
+	}
+	if count > 1 && !replaceAll {
+		return "", 0, fmt.Errorf("found %d matches of the string to replace, but replace_all is false. To replace all occurrences, set replace_all to true. To replace only one occurrence, please provide more context to uniquely identify the instance.\nString: %s", count, oldString)
+	}
+	if replaceAll {
+		return strings.ReplaceAll(content, oldString, newString), count, nil
+	}
+	return strings.Replace(content, oldString, newString, 1), 1, nil
+}
+
+func ToCRLF(text string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\n", "\r\n")
+}
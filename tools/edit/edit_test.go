@@ -0,0 +1,88 @@
+package edit
+
+import (
+	"DevCode/tools"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTemp(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "file.go")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestEditReplacesUniqueString(t *testing.T) {
+	path := writeTemp(t, "package main\n\nfunc Old() {}\n")
+
+	_, err := tools.Call(context.Background(), &Tool{}, Input{FilePath: path, OldString: "Old", NewString: "New"})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "package main\n\nfunc New() {}\n", string(data))
+}
+
+func TestEditFailsOnMultipleMatches(t *testing.T) {
+	path := writeTemp(t, "a := 1\na := 2\n")
+
+	_, err := tools.Call(context.Background(), &Tool{}, Input{FilePath: path, OldString: "a :=", NewString: "b :="})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "found 2 matches")
+
+	// 파일은 변경되지 않아야 함
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "a := 1\na := 2\n", string(data))
+}
+
+func TestEditReplaceAll(t *testing.T) {
+	path := writeTemp(t, "a := 1\na := 2\n")
+
+	result, err := tools.Call(context.Background(), &Tool{}, Input{FilePath: path, OldString: "a :=", NewString: "b :=", ReplaceAll: true})
+	require.NoError(t, err)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "2 occurrences")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "b := 1\nb := 2\n", string(data))
+}
+
+func TestEditFailsWhenMissing(t *testing.T) {
+	path := writeTemp(t, "hello\n")
+
+	_, err := tools.Call(context.Background(), &Tool{}, Input{FilePath: path, OldString: "world", NewString: "there"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestEditPreservesCRLF(t *testing.T) {
+	path := writeTemp(t, "line1\r\nline2\r\nline3\r\n")
+
+	_, err := tools.Call(context.Background(), &Tool{}, Input{FilePath: path, OldString: "line1\nline2", NewString: "first\nsecond"})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "first\r\nsecond\r\nline3\r\n", string(data))
+}
+
+func TestEditRejectsInvalidInput(t *testing.T) {
+	path := writeTemp(t, "hello\n")
+
+	_, err := tools.Call(context.Background(), &Tool{}, Input{FilePath: "relative.go", OldString: "a", NewString: "b"})
+	assert.Error(t, err)
+
+	_, err = tools.Call(context.Background(), &Tool{}, Input{FilePath: path, OldString: "", NewString: "b"})
+	assert.Error(t, err)
+
+	_, err = tools.Call(context.Background(), &Tool{}, Input{FilePath: path, OldString: "hello", NewString: "hello"})
+	assert.Error(t, err)
+}
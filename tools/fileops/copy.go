@@ -0,0 +1,66 @@
+package fileops
+
+import (
+	"DevCode/tools"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	CopyDescription = `Copies a file or directory inside the workspace.\n\nUsage:\n- Both source and
+  destination must be absolute paths inside the workspace\n- destination is the full path of
+  the copy, not the directory to copy into; missing parent directories are created\n-
+  Directories are copied recursively with their file modes; symlinks are copied as links\n-
+  An existing destination is only replaced when overwrite is true, and the replaced file is
+  kept in the trash`
+	CopyName = "Copy"
+)
+
+func NewCopyTool(workspace *Workspace, trash *Trash) *CopyTool {
+	return &CopyTool{workspace: workspace, trash: trash}
+}
+
+type CopyTool struct {
+	workspace *Workspace
+	trash     *Trash
+}
+
+func (*CopyTool) Name() string {
+	return CopyName
+}
+
+func (*CopyTool) Description() string {
+	return CopyDescription
+}
+
+func (instance *CopyTool) Handler() mcp.ToolHandlerFor[CopyInput, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[CopyInput]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		source, destination, err := paths(instance.workspace, input.Source, input.Destination)
+		if err != nil {
+			return nil, err
+		}
+		replaced, trashed, err := clearDestination(instance.workspace, instance.trash, destination, input.Overwrite)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Dir(destination), DefaultDirMode); err != nil {
+			return nil, errors.Join(fmt.Errorf("fail to create directory: %s", filepath.Dir(destination)), instance.trash.putBack(trashed))
+		}
+		count, err := CopyPath(source, destination)
+		if err != nil {
+			os.RemoveAll(destination)
+			return nil, errors.Join(err, instance.trash.putBack(trashed))
+		}
+		files := "1 file"
+		if count != 1 {
+			files = fmt.Sprintf("%d files", count)
+		}
+		return tools.TextReturn(fmt.Sprintf("Copied %s to %s (%s)%s", instance.workspace.Relative(source), instance.workspace.Relative(destination), files, replaced))
+	}
+}
@@ -0,0 +1,54 @@
+package fileops
+
+import (
+	"DevCode/constants"
+	"DevCode/tools"
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	DeleteDescription = `Deletes a file or directory inside the workspace by moving it to the session
+  trash.\n\nUsage:\n- The path must be an absolute path inside the workspace\n- Directories
+  are deleted with everything in them\n- Nothing is removed for good: the user can bring it
+  back with the /restore command\n- Use this instead of rm through Bash`
+	DeleteName = "Delete"
+)
+
+func NewDeleteTool(workspace *Workspace, trash *Trash) *DeleteTool {
+	return &DeleteTool{workspace: workspace, trash: trash}
+}
+
+type DeleteTool struct {
+	workspace *Workspace
+	trash     *Trash
+}
+
+func (*DeleteTool) Name() string {
+	return DeleteName
+}
+
+func (*DeleteTool) Description() string {
+	return DeleteDescription
+}
+
+func (instance *DeleteTool) Handler() mcp.ToolHandlerFor[DeleteInput, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[DeleteInput]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		path, err := instance.workspace.Resolve(input.Path)
+		if err != nil {
+			return nil, err
+		}
+		if path == instance.workspace.Root {
+			return nil, fmt.Errorf("cannot change the workspace root: %s", input.Path)
+		}
+		entry, err := instance.trash.Put(path)
+		if err != nil {
+			return nil, err
+		}
+		relative := instance.workspace.Relative(entry.Original)
+		return tools.TextReturn(fmt.Sprintf("Moved %s to the trash. The user can bring it back with %s %s", relative, constants.RestoreCommand, relative))
+	}
+}
@@ -0,0 +1,269 @@
+package fileops
+
+import (
+	"DevCode/tools"
+	"DevCode/tools/glob"
+	"DevCode/tools/grep"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWorkspace(t *testing.T) (*Workspace, *Trash) {
+	workspace := NewWorkspace(t.TempDir())
+	return workspace, NewTrash(workspace.Root)
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), DefaultDirMode))
+	require.NoError(t, os.WriteFile(path, []byte(content), DefaultFileMode))
+}
+
+func readFile(t *testing.T, path string) string {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func move(workspace *Workspace, trash *Trash, input MoveInput) (*mcp.CallToolResultFor[any], error) {
+	return tools.Call(context.Background(), NewMoveTool(workspace, trash), input)
+}
+
+func copyPath(workspace *Workspace, trash *Trash, input CopyInput) (*mcp.CallToolResultFor[any], error) {
+	return tools.Call(context.Background(), NewCopyTool(workspace, trash), input)
+}
+
+func remove(workspace *Workspace, trash *Trash, path string) (*mcp.CallToolResultFor[any], error) {
+	return tools.Call(context.Background(), NewDeleteTool(workspace, trash), DeleteInput{Path: path})
+}
+
+func TestWorkspaceResolve(t *testing.T) {
+	workspace, _ := newWorkspace(t)
+	root := workspace.Root
+	outside := t.TempDir()
+	require.NoError(t, os.Symlink(outside, filepath.Join(root, "escape")))
+
+	resolved, err := workspace.Resolve(filepath.Join(root, "pkg", "..", "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "main.go"), resolved)
+
+	// 아직 없는 경로도 workspace 안이면 허용
+	_, err = workspace.Resolve(filepath.Join(root, "new", "dir", "file.go"))
+	assert.NoError(t, err)
+
+	// 링크 자체는 workspace 안에 있으므로 링크를 다루는 것은 허용
+	resolved, err = workspace.Resolve(filepath.Join(root, "escape"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "escape"), resolved)
+
+	tests := []struct {
+		name string
+		path string
+		err  string
+	}{
+		{name: "relative path", path: "main.go", err: "invalid path format: main.go"},
+		{name: "outside", path: filepath.Join(outside, "a.go"), err: "path is outside the workspace"},
+		{name: "parent traversal", path: filepath.Join(root, "..", "other"), err: "path is outside the workspace"},
+		{name: "through symlink", path: filepath.Join(root, "escape", "a.go"), err: "path is outside the workspace"},
+		{name: "data directory", path: filepath.Join(root, ".devcode", "trash"), err: "path is inside .devcode"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := workspace.Resolve(tt.path)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestMove(t *testing.T) {
+	workspace, trash := newWorkspace(t)
+	root := workspace.Root
+	writeFile(t, filepath.Join(root, "store", "store.go"), "package store\n")
+
+	result, err := move(workspace, trash, MoveInput{
+		Source:      filepath.Join(root, "store"),
+		Destination: filepath.Join(root, "internal", "store"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Moved store to internal/store", tools.Text(result))
+	assert.Equal(t, "package store\n", readFile(t, filepath.Join(root, "internal", "store", "store.go")))
+	assert.NoDirExists(t, filepath.Join(root, "store"))
+}
+
+func TestMoveOverwrite(t *testing.T) {
+	workspace, trash := newWorkspace(t)
+	root := workspace.Root
+	writeFile(t, filepath.Join(root, "a.go"), "new")
+	writeFile(t, filepath.Join(root, "b.go"), "old")
+
+	_, err := move(workspace, trash, MoveInput{Source: filepath.Join(root, "a.go"), Destination: filepath.Join(root, "b.go")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "destination already exists")
+
+	result, err := move(workspace, trash, MoveInput{Source: filepath.Join(root, "a.go"), Destination: filepath.Join(root, "b.go"), Overwrite: true})
+	require.NoError(t, err)
+	assert.Equal(t, "Moved a.go to b.go\nThe previous b.go was moved to the trash.", tools.Text(result))
+	assert.Equal(t, "new", readFile(t, filepath.Join(root, "b.go")))
+
+	// 덮어쓴 파일은 trash에 남아 있다
+	entries := trash.Entries()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "old", readFile(t, filepath.Join(trash.Dir, entries[0].Trashed)))
+}
+
+func TestMoveRejects(t *testing.T) {
+	workspace, trash := newWorkspace(t)
+	root := workspace.Root
+	writeFile(t, filepath.Join(root, "pkg", "a.go"), "package pkg\n")
+
+	tests := []struct {
+		name  string
+		input MoveInput
+		err   string
+	}{
+		{name: "missing source", input: MoveInput{Source: filepath.Join(root, "none.go"), Destination: filepath.Join(root, "b.go")}, err: "file not found"},
+		{name: "into itself", input: MoveInput{Source: filepath.Join(root, "pkg"), Destination: filepath.Join(root, "pkg", "sub")}, err: "inside itself"},
+		{name: "same path", input: MoveInput{Source: filepath.Join(root, "pkg"), Destination: filepath.Join(root, "pkg")}, err: "source and destination are the same"},
+		{name: "workspace root", input: MoveInput{Source: root, Destination: filepath.Join(root, "moved")}, err: "cannot change the workspace root"},
+		{name: "outside destination", input: MoveInput{Source: filepath.Join(root, "pkg"), Destination: filepath.Join(t.TempDir(), "pkg")}, err: "outside the workspace"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := move(workspace, trash, tt.input)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+	assert.FileExists(t, filepath.Join(root, "pkg", "a.go"))
+}
+
+func TestCopy(t *testing.T) {
+	workspace, trash := newWorkspace(t)
+	root := workspace.Root
+	writeFile(t, filepath.Join(root, "pkg", "a.go"), "a")
+	writeFile(t, filepath.Join(root, "pkg", "sub", "b.sh"), "b")
+	require.NoError(t, os.Chmod(filepath.Join(root, "pkg", "sub", "b.sh"), 0755))
+	require.NoError(t, os.Symlink("a.go", filepath.Join(root, "pkg", "link.go")))
+
+	result, err := copyPath(workspace, trash, CopyInput{Source: filepath.Join(root, "pkg"), Destination: filepath.Join(root, "pkg2")})
+	require.NoError(t, err)
+	assert.Equal(t, "Copied pkg to pkg2 (3 files)", tools.Text(result))
+	assert.Equal(t, "a", readFile(t, filepath.Join(root, "pkg2", "a.go")))
+	info, err := os.Stat(filepath.Join(root, "pkg2", "sub", "b.sh"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+	link, err := os.Readlink(filepath.Join(root, "pkg2", "link.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "a.go", link)
+	assert.FileExists(t, filepath.Join(root, "pkg", "a.go"))
+
+	result, err = copyPath(workspace, trash, CopyInput{Source: filepath.Join(root, "pkg", "a.go"), Destination: filepath.Join(root, "c.go")})
+	require.NoError(t, err)
+	assert.Equal(t, "Copied pkg/a.go to c.go (1 file)", tools.Text(result))
+}
+
+func TestDeleteAndRestore(t *testing.T) {
+	workspace, trash := newWorkspace(t)
+	root := workspace.Root
+	writeFile(t, filepath.Join(root, "a.go"), "a")
+	writeFile(t, filepath.Join(root, "pkg", "b.go"), "b")
+
+	result, err := remove(workspace, trash, filepath.Join(root, "a.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "Moved a.go to the trash. The user can bring it back with /restore a.go", tools.Text(result))
+	assert.NoFileExists(t, filepath.Join(root, "a.go"))
+	_, err = remove(workspace, trash, filepath.Join(root, "pkg"))
+	require.NoError(t, err)
+	assert.NoDirExists(t, filepath.Join(root, "pkg"))
+
+	// manifest에 원래 경로가 기록된다
+	var manifest Manifest
+	data, err := os.ReadFile(filepath.Join(trash.Dir, ManifestName))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &manifest))
+	require.Len(t, manifest.Entries, 2)
+	assert.Equal(t, filepath.Join(root, "a.go"), manifest.Entries[0].Original)
+	assert.True(t, manifest.Entries[1].Dir)
+	assert.Equal(t, filepath.Join(root, ".devcode", "trash"), filepath.Dir(trash.Dir))
+
+	list := RunRestore(workspace, trash, "list")
+	assert.Contains(t, list, "  pkg/  deleted ")
+	assert.Contains(t, list, "  a.go  deleted ")
+
+	// 인자가 없으면 가장 최근에 지운 것을 복원
+	assert.Equal(t, "Restored pkg", RunRestore(workspace, trash, ""))
+	assert.Equal(t, "b", readFile(t, filepath.Join(root, "pkg", "b.go")))
+
+	// 같은 경로에 새 파일이 있으면 덮어쓰지 않는다
+	writeFile(t, filepath.Join(root, "a.go"), "new a")
+	assert.Contains(t, RunRestore(workspace, trash, "a.go"), "/restore: cannot restore "+filepath.Join(root, "a.go"))
+	assert.Equal(t, "new a", readFile(t, filepath.Join(root, "a.go")))
+
+	require.NoError(t, os.Remove(filepath.Join(root, "a.go")))
+	assert.Equal(t, "Restored a.go", RunRestore(workspace, trash, filepath.Join(root, "a.go")))
+	assert.Equal(t, "a", readFile(t, filepath.Join(root, "a.go")))
+
+	assert.Equal(t, "/restore: trash is empty", RunRestore(workspace, trash, "all"))
+	assert.Equal(t, "Trash is empty", RunRestore(workspace, trash, "list"))
+}
+
+func TestDeletedFilesAreNotSearched(t *testing.T) {
+	workspace, trash := newWorkspace(t)
+	root := workspace.Root
+	writeFile(t, filepath.Join(root, "kept.go"), "package main // marker")
+	writeFile(t, filepath.Join(root, "pkg", "gone.go"), "package pkg // marker")
+	_, err := remove(workspace, trash, filepath.Join(root, "pkg", "gone.go"))
+	require.NoError(t, err)
+
+	// 휴지통에 있는 파일은 Grep과 Glob 결과에 나오지 않는다
+	result, err := (&grep.Tool{}).Handler()(context.Background(), nil, &mcp.CallToolParamsFor[grep.Input]{Arguments: grep.Input{Pattern: "marker", Path: root}})
+	require.NoError(t, err)
+	assert.Contains(t, tools.Text(result), "kept.go")
+	assert.NotContains(t, tools.Text(result), "gone.go")
+
+	result, err = (&glob.Tool{}).Handler()(context.Background(), nil, &mcp.CallToolParamsFor[glob.Input]{Arguments: glob.Input{Pattern: "**/*.go", Path: root}})
+	require.NoError(t, err)
+	assert.Contains(t, tools.Text(result), "kept.go")
+	assert.NotContains(t, tools.Text(result), "gone.go")
+}
+
+func TestRestoreAll(t *testing.T) {
+	workspace, trash := newWorkspace(t)
+	root := workspace.Root
+	writeFile(t, filepath.Join(root, "a.go"), "first")
+	_, err := remove(workspace, trash, filepath.Join(root, "a.go"))
+	require.NoError(t, err)
+	writeFile(t, filepath.Join(root, "b.go"), "b")
+	_, err = remove(workspace, trash, filepath.Join(root, "b.go"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "/restore: nothing in the trash matches c.go", RunRestore(workspace, trash, "c.go"))
+
+	assert.Equal(t, "Restored b.go\nRestored a.go", RunRestore(workspace, trash, "all"))
+	assert.Equal(t, "first", readFile(t, filepath.Join(root, "a.go")))
+	assert.Empty(t, trash.Entries())
+}
+
+func TestDeleteRejects(t *testing.T) {
+	workspace, trash := newWorkspace(t)
+	root := workspace.Root
+
+	_, err := remove(workspace, trash, root)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot change the workspace root")
+
+	_, err = remove(workspace, trash, filepath.Join(root, "missing.go"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "file not found")
+
+	_, err = remove(workspace, trash, filepath.Join(t.TempDir(), "a.go"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "outside the workspace")
+}
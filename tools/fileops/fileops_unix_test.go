@@ -0,0 +1,28 @@
+//go:build unix
+
+package fileops
+
+import (
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyFailureKeepsDestination(t *testing.T) {
+	workspace, trash := newWorkspace(t)
+	root := workspace.Root
+	writeFile(t, filepath.Join(root, "pkg", "a.go"), "a")
+	require.NoError(t, syscall.Mkfifo(filepath.Join(root, "pkg", "pipe"), 0644))
+	writeFile(t, filepath.Join(root, "out", "old.go"), "old")
+
+	// 복사가 실패하면 trash로 옮겼던 기존 destination을 되돌린다
+	_, err := copyPath(workspace, trash, CopyInput{Source: filepath.Join(root, "pkg"), Destination: filepath.Join(root, "out"), Overwrite: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported file type")
+	assert.Equal(t, "old", readFile(t, filepath.Join(root, "out", "old.go")))
+	assert.NoFileExists(t, filepath.Join(root, "out", "a.go"))
+	assert.Empty(t, trash.Entries())
+}
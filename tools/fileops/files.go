@@ -0,0 +1,97 @@
+package fileops
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+const (
+	DefaultFileMode = os.FileMode(0644)
+	DefaultDirMode  = os.FileMode(0755)
+)
+
+// Rename moves source to destination, copying and removing the source when
+// they are on different file systems.
+func Rename(source string, destination string) error {
+	err := os.Rename(source, destination)
+	if err == nil {
+		return nil
+	}
+	var linkErr *os.LinkError
+	if !errors.As(err, &linkErr) || !errors.Is(linkErr.Err, syscall.EXDEV) {
+		return err
+	}
+	if _, err := CopyPath(source, destination); err != nil {
+		os.RemoveAll(destination)
+		return err
+	}
+	return os.RemoveAll(source)
+}
+
+// CopyPath copies a file, symlink or directory tree from source to destination,
+// keeping file modes, and returns the number of files copied.
+func CopyPath(source string, destination string) (int, error) {
+	info, err := os.Lstat(source)
+	if err != nil {
+		return 0, fmt.Errorf("file not found: %s", source)
+	}
+	if !info.IsDir() {
+		return 1, copyEntry(source, destination, info)
+	}
+	count := 0
+	err = filepath.WalkDir(source, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relative, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(destination, relative)
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		}
+		count++
+		return copyEntry(path, target, info)
+	})
+	if err != nil {
+		return count, fmt.Errorf("fail to copy %s: %w", source, err)
+	}
+	return count, nil
+}
+
+func copyEntry(source string, destination string, info fs.FileInfo) error {
+	if info.Mode()&os.ModeSymlink != 0 {
+		link, err := os.Readlink(source)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(link, destination)
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("unsupported file type: %s", source)
+	}
+	reader, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	writer, err := os.OpenFile(destination, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(writer, reader); err != nil {
+		writer.Close()
+		return err
+	}
+	return writer.Close()
+}
@@ -0,0 +1,105 @@
+package fileops
+
+import (
+	"DevCode/tools"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	MoveDescription = `Moves or renames a file or directory inside the workspace.\n\nUsage:\n- Both
+  source and destination must be absolute paths inside the workspace\n- destination is the
+  full new path, not the directory to move into; missing parent directories are created\n-
+  An existing destination is only replaced when overwrite is true, and the replaced file is
+  kept in the trash\n- Use this instead of mv through Bash, for example when moving files
+  between packages`
+	MoveName = "Move"
+)
+
+func NewMoveTool(workspace *Workspace, trash *Trash) *MoveTool {
+	return &MoveTool{workspace: workspace, trash: trash}
+}
+
+type MoveTool struct {
+	workspace *Workspace
+	trash     *Trash
+}
+
+func (*MoveTool) Name() string {
+	return MoveName
+}
+
+func (*MoveTool) Description() string {
+	return MoveDescription
+}
+
+func (instance *MoveTool) Handler() mcp.ToolHandlerFor[MoveInput, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[MoveInput]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		source, destination, err := paths(instance.workspace, input.Source, input.Destination)
+		if err != nil {
+			return nil, err
+		}
+		replaced, trashed, err := clearDestination(instance.workspace, instance.trash, destination, input.Overwrite)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Dir(destination), DefaultDirMode); err != nil {
+			return nil, errors.Join(fmt.Errorf("fail to create directory: %s", filepath.Dir(destination)), instance.trash.putBack(trashed))
+		}
+		if err := Rename(source, destination); err != nil {
+			return nil, errors.Join(fmt.Errorf("fail to move %s to %s: %w", input.Source, input.Destination, err), instance.trash.putBack(trashed))
+		}
+		return tools.TextReturn(fmt.Sprintf("Moved %s to %s%s", instance.workspace.Relative(source), instance.workspace.Relative(destination), replaced))
+	}
+}
+
+// paths resolves a source and destination pair for Move and Copy.
+func paths(workspace *Workspace, sourcePath string, destinationPath string) (string, string, error) {
+	source, err := workspace.Resolve(sourcePath)
+	if err != nil {
+		return "", "", err
+	}
+	destination, err := workspace.Resolve(destinationPath)
+	if err != nil {
+		return "", "", err
+	}
+	if source == workspace.Root {
+		return "", "", fmt.Errorf("cannot change the workspace root: %s", sourcePath)
+	}
+	if _, err := os.Lstat(source); err != nil {
+		return "", "", fmt.Errorf("file not found: %s", sourcePath)
+	}
+	if source == destination {
+		return "", "", fmt.Errorf("source and destination are the same: %s", sourcePath)
+	}
+	if Within(source, destination) {
+		return "", "", fmt.Errorf("cannot put %s inside itself: %s", sourcePath, destinationPath)
+	}
+	return source, destination, nil
+}
+
+// clearDestination makes room for a Move or Copy. An existing destination is
+// moved to the trash when overwrite is set; the returned note says so, and the
+// returned entry lets a failed Move or Copy put it back.
+func clearDestination(workspace *Workspace, trash *Trash, destination string, overwrite bool) (string, *TrashEntry, error) {
+	if _, err := os.Lstat(destination); err != nil {
+		return "", nil, nil
+	}
+	if !overwrite {
+		return "", nil, fmt.Errorf("destination already exists: %s (set overwrite to true to replace it)", destination)
+	}
+	if destination == workspace.Root {
+		return "", nil, fmt.Errorf("cannot change the workspace root: %s", destination)
+	}
+	entry, err := trash.Put(destination)
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("\nThe previous %s was moved to the trash.", workspace.Relative(destination)), &entry, nil
+}
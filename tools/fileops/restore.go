@@ -0,0 +1,46 @@
+package fileops
+
+import (
+	"DevCode/constants"
+	"fmt"
+	"strings"
+)
+
+// RunRestore handles the /restore command typed by the user and returns the
+// text to show them. "list" shows the trash, "all" restores everything, a path
+// restores that path and no argument restores the latest deletion.
+func RunRestore(workspace *Workspace, trash *Trash, argument string) string {
+	argument = strings.TrimSpace(argument)
+	if argument == RestoreList {
+		return TrashList(workspace, trash)
+	}
+	restored, err := trash.Restore(argument)
+	var builder strings.Builder
+	for _, entry := range restored {
+		builder.WriteString(fmt.Sprintf("Restored %s\n", workspace.Relative(entry.Original)))
+	}
+	if err != nil {
+		builder.WriteString(fmt.Sprintf("%s: %v\n", constants.RestoreCommand, err))
+	}
+	return strings.TrimRight(builder.String(), "\n")
+}
+
+// TrashList describes the entries of the session trash, newest first.
+func TrashList(workspace *Workspace, trash *Trash) string {
+	entries := trash.Entries()
+	if len(entries) == 0 {
+		return "Trash is empty"
+	}
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Trash (%s):\n", workspace.Relative(trash.Dir)))
+	for index := len(entries) - 1; index >= 0; index-- {
+		entry := entries[index]
+		kind := ""
+		if entry.Dir {
+			kind = "/"
+		}
+		builder.WriteString(fmt.Sprintf("  %s%s  deleted %s\n", workspace.Relative(entry.Original), kind, entry.DeletedAt.Format("15:04:05")))
+	}
+	builder.WriteString(fmt.Sprintf("Use %s <path>, %s or %s %s", constants.RestoreCommand, constants.RestoreCommand, constants.RestoreCommand, RestoreAll))
+	return builder.String()
+}
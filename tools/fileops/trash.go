@@ -0,0 +1,189 @@
+package fileops
+
+import (
+	"DevCode/constants"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	TrashDirectory = "trash"
+	ManifestName   = "manifest.json"
+	RestoreAll     = "all"
+	RestoreList    = "list"
+)
+
+// TrashEntry is one deleted or overwritten path in the session trash.
+type TrashEntry struct {
+	ID        int       `json:"id"`
+	Original  string    `json:"original"`
+	Trashed   string    `json:"trashed"`
+	Dir       bool      `json:"dir"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type Manifest struct {
+	Session string       `json:"session"`
+	Root    string       `json:"root"`
+	Entries []TrashEntry `json:"entries"`
+}
+
+// Trash holds what this session deleted under .devcode/trash/<session>, with a
+// manifest.json recording where each entry came from.
+type Trash struct {
+	Dir      string
+	manifest Manifest
+	nextID   int
+	mutex    sync.Mutex
+}
+
+func NewTrash(root string) *Trash {
+	session := fmt.Sprintf("%s-%d", time.Now().Format("20060102-150405"), os.Getpid())
+	return &Trash{
+		Dir: filepath.Join(root, constants.DataDirectory, TrashDirectory, session),
+		manifest: Manifest{
+			Session: session,
+			Root:    root,
+			Entries: make([]TrashEntry, 0),
+		},
+		nextID: 1,
+	}
+}
+
+// Put moves path into the trash and records it in the manifest.
+func (instance *Trash) Put(path string) (TrashEntry, error) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	info, err := os.Lstat(path)
+	if err != nil {
+		return TrashEntry{}, fmt.Errorf("file not found: %s", path)
+	}
+	if err := os.MkdirAll(instance.Dir, DefaultDirMode); err != nil {
+		return TrashEntry{}, fmt.Errorf("fail to create trash directory: %s", instance.Dir)
+	}
+	entry := TrashEntry{
+		ID:        instance.nextID,
+		Original:  path,
+		Trashed:   fmt.Sprintf("%d-%s", instance.nextID, filepath.Base(path)),
+		Dir:       info.IsDir(),
+		DeletedAt: time.Now(),
+	}
+	if err := Rename(path, filepath.Join(instance.Dir, entry.Trashed)); err != nil {
+		return TrashEntry{}, fmt.Errorf("fail to move %s to trash: %w", path, err)
+	}
+	instance.nextID++
+	instance.manifest.Entries = append(instance.manifest.Entries, entry)
+	if err := instance.save(); err != nil {
+		return entry, err
+	}
+	return entry, nil
+}
+
+func (instance *Trash) Entries() []TrashEntry {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	return slices.Clone(instance.manifest.Entries)
+}
+
+// Restore moves entries back to where they were deleted from. An empty target
+// restores the latest entry, "all" every entry from newest to oldest, and any
+// other target the latest entry whose original path is target. Entries whose
+// original path is taken again are left in the trash and reported in the error.
+func (instance *Trash) Restore(target string) ([]TrashEntry, error) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	entries := instance.manifest.Entries
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("trash is empty")
+	}
+	var selected []TrashEntry
+	switch target {
+	case "":
+		selected = entries[len(entries)-1:]
+	case RestoreAll:
+		selected = slices.Clone(entries)
+		slices.Reverse(selected)
+	default:
+		path := target
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(instance.manifest.Root, path)
+		}
+		path = filepath.Clean(path)
+		for index := len(entries) - 1; index >= 0; index-- {
+			if entries[index].Original == path {
+				selected = []TrashEntry{entries[index]}
+				break
+			}
+		}
+		if len(selected) == 0 {
+			return nil, fmt.Errorf("nothing in the trash matches %s", target)
+		}
+	}
+
+	restored := make([]TrashEntry, 0, len(selected))
+	var failed error
+	for _, entry := range selected {
+		if err := instance.restore(entry); err != nil {
+			failed = err
+			continue
+		}
+		restored = append(restored, entry)
+	}
+	if len(restored) > 0 {
+		if err := instance.save(); err != nil && failed == nil {
+			failed = err
+		}
+	}
+	return restored, failed
+}
+
+// putBack restores an entry that a failed Move or Copy put in the trash. A nil
+// entry means nothing was trashed.
+func (instance *Trash) putBack(entry *TrashEntry) error {
+	if entry == nil {
+		return nil
+	}
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+
+	if err := instance.restore(*entry); err != nil {
+		return err
+	}
+	return instance.save()
+}
+
+// restore moves one entry back and drops it from the manifest. The caller holds
+// the mutex and saves the manifest.
+func (instance *Trash) restore(entry TrashEntry) error {
+	if _, err := os.Lstat(entry.Original); err == nil {
+		return fmt.Errorf("cannot restore %s: something already exists there", entry.Original)
+	}
+	if err := os.MkdirAll(filepath.Dir(entry.Original), DefaultDirMode); err != nil {
+		return fmt.Errorf("cannot restore %s: fail to create directory %s", entry.Original, filepath.Dir(entry.Original))
+	}
+	if err := Rename(filepath.Join(instance.Dir, entry.Trashed), entry.Original); err != nil {
+		return fmt.Errorf("cannot restore %s: %w", entry.Original, err)
+	}
+	instance.manifest.Entries = slices.DeleteFunc(instance.manifest.Entries, func(other TrashEntry) bool {
+		return other.ID == entry.ID
+	})
+	return nil
+}
+
+func (instance *Trash) save() error {
+	data, err := json.MarshalIndent(instance.manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("fail to encode trash manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(instance.Dir, ManifestName), append(data, '\n'), DefaultFileMode); err != nil {
+		return fmt.Errorf("fail to write trash manifest: %s", filepath.Join(instance.Dir, ManifestName))
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package fileops
+
+type MoveInput struct {
+	Source      string `json:"source" jsonschema:"description:The absolute path of the file or directory to move"`
+	Destination string `json:"destination" jsonschema:"description:The absolute path to move it to, including the new name"`
+	Overwrite   bool   `json:"overwrite,omitempty" jsonschema:"description:Replace an existing destination. The replaced file is moved to the trash first (default false)"`
+}
+
+type CopyInput struct {
+	Source      string `json:"source" jsonschema:"description:The absolute path of the file or directory to copy"`
+	Destination string `json:"destination" jsonschema:"description:The absolute path of the copy, including its name"`
+	Overwrite   bool   `json:"overwrite,omitempty" jsonschema:"description:Replace an existing destination. The replaced file is moved to the trash first (default false)"`
+}
+
+type DeleteInput struct {
+	Path string `json:"path" jsonschema:"description:The absolute path of the file or directory to delete"`
+}
@@ -0,0 +1,83 @@
+package fileops
+
+import (
+	"DevCode/constants"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Workspace keeps Move, Copy and Delete inside the directory DevCode was started in.
+type Workspace struct {
+	Root string
+}
+
+func NewWorkspace(root string) *Workspace {
+	if abs, err := filepath.Abs(root); err == nil {
+		root = abs
+	}
+	if real, err := filepath.EvalSymlinks(root); err == nil {
+		root = real
+	}
+	return &Workspace{Root: filepath.Clean(root)}
+}
+
+// Resolve checks that path is absolute and lies inside the workspace, following
+// symlinks in its parent directories but not in the last element, so a link is
+// handled as the link itself. It returns the resolved path.
+func (instance *Workspace) Resolve(path string) (string, error) {
+	if path == "" || !filepath.IsAbs(path) {
+		return "", fmt.Errorf("invalid path format: %s", path)
+	}
+	clean := filepath.Clean(path)
+	resolved := filepath.Join(resolveExisting(filepath.Dir(clean)), filepath.Base(clean))
+	if !Within(instance.Root, resolved) {
+		return "", fmt.Errorf("path is outside the workspace %s: %s", instance.Root, path)
+	}
+	if Within(filepath.Join(instance.Root, constants.DataDirectory), resolved) {
+		return "", fmt.Errorf("path is inside %s and cannot be changed: %s", constants.DataDirectory, path)
+	}
+	return resolved, nil
+}
+
+// Relative returns path relative to the workspace root for messages.
+func (instance *Workspace) Relative(path string) string {
+	if relative, err := filepath.Rel(instance.Root, path); err == nil && !strings.HasPrefix(relative, "..") {
+		return relative
+	}
+	return path
+}
+
+// Within reports whether path is root or inside it.
+func Within(root string, path string) bool {
+	relative, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return relative == "." || (relative != ".." && !strings.HasPrefix(relative, ".."+string(filepath.Separator)))
+}
+
+// resolveExisting evaluates symlinks in the longest existing prefix of path
+// and appends the part that does not exist yet.
+func resolveExisting(path string) string {
+	missing := make([]string, 0, 4)
+	current := path
+	for {
+		if real, err := filepath.EvalSymlinks(current); err == nil {
+			for index := len(missing) - 1; index >= 0; index-- {
+				real = filepath.Join(real, missing[index])
+			}
+			return real
+		}
+		if _, err := os.Lstat(current); err == nil {
+			return path
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return path
+		}
+		missing = append(missing, filepath.Base(current))
+		current = parent
+	}
+}
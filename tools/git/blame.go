@@ -0,0 +1,151 @@
+package git
+
+import (
+	"DevCode/tools"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	BlameDescription = `Shows which commit and author last changed each line of a file.\n\nUsage:\n-
+  file is required and may be absolute or relative to path, which defaults to the working
+  directory\n- Use start_line and end_line to blame a range. Without them the first 200 lines are shown\n- Each line
+  shows the short commit hash, the author date, the author and the line content. Lines
+  that are not committed yet show 00000000\n- Set revision to blame the file as it was in an
+  older commit. Use GitShow with the hash to see why a line changed`
+	BlameName = "GitBlame"
+
+	DefaultBlameLines = 200
+	shortHashLength   = 8
+)
+
+var shortFilePattern = regexp.MustCompile(`has only (\d+) lines?`)
+
+type BlameTool struct {
+}
+
+func (*BlameTool) Name() string {
+	return BlameName
+}
+
+func (*BlameTool) Description() string {
+	return BlameDescription
+}
+
+func (instance *BlameTool) Handler() mcp.ToolHandlerFor[BlameInput, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[BlameInput]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		if input.File == "" {
+			return nil, fmt.Errorf("file must not be empty")
+		}
+		dir, err := Repository(input.Path)
+		if err != nil {
+			return nil, err
+		}
+		if err := ValidateRevision(input.Revision); err != nil {
+			return nil, err
+		}
+		start := max(input.StartLine, 1)
+		end := input.EndLine
+		if end <= 0 {
+			end = start + DefaultBlameLines - 1
+		}
+		if end < start {
+			return nil, fmt.Errorf("end_line (%d) must not be before start_line (%d)", end, start)
+		}
+		blame := func(end int) (string, error) {
+			args := []string{"blame", "--porcelain", "-L", fmt.Sprintf("%d,%d", start, end)}
+			if input.Revision != "" {
+				args = append(args, input.Revision)
+			}
+			return Run(ctx, dir, append(args, "--", input.File)...)
+		}
+		output, err := blame(end)
+		if err != nil {
+			// 범위가 파일 끝을 넘으면 마지막 줄까지로 줄여서 다시 시도
+			match := shortFilePattern.FindStringSubmatch(err.Error())
+			if match == nil {
+				return nil, err
+			}
+			total, _ := strconv.Atoi(match[1])
+			if start > total {
+				return nil, fmt.Errorf("start_line %d is past the end of %s (%d lines)", start, input.File, total)
+			}
+			if output, err = blame(total); err != nil {
+				return nil, err
+			}
+		}
+		return tools.TextReturn(Limit(FormatBlame(ParseBlame(output))))
+	}
+}
+
+// ParseBlame reads git blame --porcelain output. Commit headers are only
+// printed the first time a commit appears, so they are remembered by hash.
+func ParseBlame(output string) []BlameLine {
+	type commitInfo struct {
+		author string
+		date   string
+	}
+	commits := make(map[string]*commitInfo)
+	var lines []BlameLine
+	var current *BlameLine
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "\t") {
+			if current != nil {
+				current.Content = line[1:]
+				if info := commits[current.Hash]; info != nil {
+					current.Author = info.author
+					current.Date = info.date
+				}
+				lines = append(lines, *current)
+				current = nil
+			}
+			continue
+		}
+		fields := strings.Fields(line)
+		if current == nil && len(fields) >= 3 && len(fields[0]) >= 40 {
+			number, _ := strconv.Atoi(fields[2])
+			current = &BlameLine{Hash: fields[0], Line: number}
+			if commits[fields[0]] == nil {
+				commits[fields[0]] = &commitInfo{}
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		info := commits[current.Hash]
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "author":
+			info.author = value
+		case "author-time":
+			if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+				info.date = time.Unix(seconds, 0).UTC().Format("2006-01-02")
+			}
+		}
+	}
+	return lines
+}
+
+func FormatBlame(lines []BlameLine) string {
+	if len(lines) == 0 {
+		return "No lines to blame"
+	}
+	width := 0
+	for _, line := range lines {
+		width = max(width, len([]rune(line.Author)))
+	}
+	var builder strings.Builder
+	for _, line := range lines {
+		hash := line.Hash[:min(len(line.Hash), shortHashLength)]
+		fmt.Fprintf(&builder, "%s %s %-*s %6d→\t%s\n", hash, line.Date, width, line.Author, line.Line, line.Content)
+	}
+	return strings.TrimSuffix(builder.String(), "\n")
+}
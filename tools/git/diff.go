@@ -0,0 +1,103 @@
+package git
+
+import (
+	"DevCode/tools"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	DiffDescription = `Shows changes in a git repository as a unified diff.\n\nUsage:\n- Without
+  parameters it shows unstaged changes in the working tree\n- Set staged to true to see what
+  is staged for the next commit\n- Set revision to compare with a commit (HEAD~1) or to show
+  a range (main..feature)\n- Set file to limit the diff to one file or directory\n- The
+  result starts with a per-file summary of added and deleted lines. Large diffs are
+  truncated, so narrow them down with file when needed`
+	DiffName = "GitDiff"
+
+	DefaultContext = 3
+	MaxContext     = 50
+)
+
+type DiffTool struct {
+}
+
+func (*DiffTool) Name() string {
+	return DiffName
+}
+
+func (*DiffTool) Description() string {
+	return DiffDescription
+}
+
+func (instance *DiffTool) Handler() mcp.ToolHandlerFor[DiffInput, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[DiffInput]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		dir, err := Repository(input.Path)
+		if err != nil {
+			return nil, err
+		}
+		if err := ValidateRevision(input.Revision); err != nil {
+			return nil, err
+		}
+		lines := input.Context
+		if lines <= 0 {
+			lines = DefaultContext
+		}
+		args := []string{"diff", "--no-ext-diff", "--find-renames"}
+		if input.Staged {
+			args = append(args, "--cached")
+		}
+		if input.Revision != "" {
+			args = append(args, input.Revision)
+		}
+		pathspec := []string{"--"}
+		if input.File != "" {
+			pathspec = append(pathspec, input.File)
+		}
+		stat, err := Run(ctx, dir, append(append(append([]string{}, args...), "--numstat"), pathspec...)...)
+		if err != nil {
+			return nil, err
+		}
+		patch, err := Run(ctx, dir, append(append(args, "--unified="+strconv.Itoa(min(lines, MaxContext))), pathspec...)...)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(patch) == "" {
+			return tools.TextReturn("No differences")
+		}
+		return tools.TextReturn(Limit(FormatNumstat(stat) + "\n" + patch))
+	}
+}
+
+// FormatNumstat turns git --numstat output into a short per-file summary.
+func FormatNumstat(output string) string {
+	var builder strings.Builder
+	files, added, deleted := 0, 0, 0
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		files++
+		if fields[0] == "-" {
+			lines = append(lines, fmt.Sprintf("  binary %s", fields[2]))
+			continue
+		}
+		plus, _ := strconv.Atoi(fields[0])
+		minus, _ := strconv.Atoi(fields[1])
+		added += plus
+		deleted += minus
+		lines = append(lines, fmt.Sprintf("  +%d -%d %s", plus, minus, fields[2]))
+	}
+	fmt.Fprintf(&builder, "%d files changed, +%d -%d:\n", files, added, deleted)
+	for _, line := range lines {
+		builder.WriteString(line + "\n")
+	}
+	return builder.String()
+}
@@ -0,0 +1,91 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	CommandTimeout = 30 * time.Second
+	MaxOutputSize  = 50 * 1000
+)
+
+// Run executes a read-only git command in dir and returns its standard output.
+func Run(ctx context.Context, dir string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, CommandTimeout)
+	defer cancel()
+	full := append([]string{"-C", dir, "--no-pager", "-c", "core.quotepath=false", "-c", "color.ui=false"}, args...)
+	cmd := exec.CommandContext(ctx, "git", full...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_OPTIONAL_LOCKS=0", "LC_ALL=C")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("git %s timed out after %s", args[0], CommandTimeout)
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			message := strings.TrimSpace(stderr.String())
+			if message == "" {
+				message = exitErr.Error()
+			}
+			return "", fmt.Errorf("git %s failed: %s", args[0], message)
+		}
+		return "", fmt.Errorf("fail to run git: %w", err)
+	}
+	return stdout.String(), nil
+}
+
+// Repository resolves the directory the git tools run in.
+func Repository(path string) (string, error) {
+	if path == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return "", fmt.Errorf("fail to get working directory: %w", err)
+		}
+		return cwd, nil
+	}
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("invalid path format: %s", path)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("directory not found: %s", path)
+	}
+	if !info.IsDir() {
+		return filepath.Dir(path), nil
+	}
+	return path, nil
+}
+
+// ValidateRevision rejects revisions that git would read as options.
+func ValidateRevision(revision string) error {
+	if strings.HasPrefix(revision, "-") || strings.ContainsAny(revision, " \t\n") {
+		return fmt.Errorf("invalid revision: %q", revision)
+	}
+	return nil
+}
+
+// Limit cuts output at MaxOutputSize bytes and says how much was left out.
+func Limit(output string) string {
+	if len(output) <= MaxOutputSize {
+		return output
+	}
+	cut := MaxOutputSize
+	for cut > 0 && !utf8.RuneStart(output[cut]) {
+		cut--
+	}
+	if newline := strings.LastIndexByte(output[:cut], '\n'); newline > 0 {
+		cut = newline + 1
+	}
+	return output[:cut] + fmt.Sprintf("\n[output truncated: %d of %d bytes shown; narrow the request with file or revision]", cut, len(output))
+}
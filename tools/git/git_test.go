@@ -0,0 +1,177 @@
+package git
+
+import (
+	"DevCode/tools"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gitCommand(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Alice", "GIT_AUTHOR_EMAIL=alice@example.com", "GIT_AUTHOR_DATE=2024-03-01T10:00:00Z",
+		"GIT_COMMITTER_NAME=Alice", "GIT_COMMITTER_EMAIL=alice@example.com", "GIT_COMMITTER_DATE=2024-03-01T10:00:00Z",
+		"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null")
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+	return strings.TrimSpace(string(output))
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+// newRepository creates a repository with two commits on main.
+func newRepository(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	gitCommand(t, dir, "init", "-q", "-b", "main")
+	writeFile(t, filepath.Join(dir, "main.go"), "package main\n\nfunc main() {\n}\n")
+	writeFile(t, filepath.Join(dir, "README.md"), "# Project\n")
+	gitCommand(t, dir, "add", ".")
+	gitCommand(t, dir, "commit", "-q", "-m", "Initial commit")
+	writeFile(t, filepath.Join(dir, "main.go"), "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n")
+	gitCommand(t, dir, "commit", "-q", "-am", "Print a greeting", "-m", "The greeting is printed on start.")
+	return dir
+}
+
+func TestStatusTool(t *testing.T) {
+	dir := newRepository(t)
+	tool := &StatusTool{}
+
+	output, err := tools.CallText(context.Background(), tool, StatusInput{Path: dir})
+	require.NoError(t, err)
+	assert.Equal(t, "Branch: main\n\nWorking tree clean", output)
+
+	writeFile(t, filepath.Join(dir, "README.md"), "# Project\n\nMore.\n")
+	writeFile(t, filepath.Join(dir, "notes", "todo.txt"), "todo\n")
+	gitCommand(t, dir, "mv", "main.go", "app.go")
+
+	output, err = tools.CallText(context.Background(), tool, StatusInput{Path: dir})
+	require.NoError(t, err)
+	assert.Contains(t, output, "3 changed files (XY: X = staged, Y = unstaged):")
+	assert.Contains(t, output, "R  main.go -> app.go\n")
+	assert.Contains(t, output, " M README.md\n")
+	assert.Contains(t, output, "?? notes/todo.txt")
+}
+
+func TestParseStatusBranch(t *testing.T) {
+	branch, entries := ParseStatus("## main...origin/main [ahead 2, behind 1]\x00M  a.go\x00")
+	require.Len(t, entries, 1)
+	output := FormatStatus(branch, entries)
+	assert.True(t, strings.HasPrefix(output, "Branch: main\nUpstream: origin/main (ahead 2, behind 1)\n"))
+}
+
+func TestDiffTool(t *testing.T) {
+	dir := newRepository(t)
+	tool := &DiffTool{}
+
+	output, err := tools.CallText(context.Background(), tool, DiffInput{Path: dir})
+	require.NoError(t, err)
+	assert.Equal(t, "No differences", output)
+
+	writeFile(t, filepath.Join(dir, "README.md"), "# Project\n\nMore.\n")
+	output, err = tools.CallText(context.Background(), tool, DiffInput{Path: dir})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(output, "1 files changed, +2 -0:\n  +2 -0 README.md\n"))
+	assert.Contains(t, output, "+More.")
+
+	// staged는 아직 비어 있음
+	output, err = tools.CallText(context.Background(), tool, DiffInput{Path: dir, Staged: true})
+	require.NoError(t, err)
+	assert.Equal(t, "No differences", output)
+
+	output, err = tools.CallText(context.Background(), tool, DiffInput{Path: dir, Revision: "HEAD~1..HEAD", File: "main.go", Context: 1})
+	require.NoError(t, err)
+	assert.Contains(t, output, "+\tfmt.Println(\"hi\")")
+	assert.NotContains(t, output, "README.md")
+
+	_, err = tools.CallText(context.Background(), tool, DiffInput{Path: dir, Revision: "--output=/tmp/x"})
+	assert.ErrorContains(t, err, "invalid revision")
+}
+
+func TestLogTool(t *testing.T) {
+	dir := newRepository(t)
+	tool := &LogTool{}
+	head := gitCommand(t, dir, "rev-parse", "HEAD")
+
+	output, err := tools.CallText(context.Background(), tool, LogInput{Path: dir})
+	require.NoError(t, err)
+	lines := strings.Split(output, "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, head+" 2024-03-01T10:00:00+00:00 Alice <alice@example.com>: Print a greeting", lines[0])
+	assert.True(t, strings.HasSuffix(lines[1], ": Initial commit"))
+
+	output, err = tools.CallText(context.Background(), tool, LogInput{Path: dir, Limit: 1})
+	require.NoError(t, err)
+	assert.Contains(t, output, "Print a greeting")
+	assert.Contains(t, output, "[more commits available; raise limit to see them]")
+
+	output, err = tools.CallText(context.Background(), tool, LogInput{Path: dir, File: "README.md"})
+	require.NoError(t, err)
+	assert.NotContains(t, output, "Print a greeting")
+
+	output, err = tools.CallText(context.Background(), tool, LogInput{Path: dir, Author: "nobody"})
+	require.NoError(t, err)
+	assert.Equal(t, "No commits found", output)
+}
+
+func TestBlameTool(t *testing.T) {
+	dir := newRepository(t)
+	tool := &BlameTool{}
+	first := gitCommand(t, dir, "rev-parse", "--short=8", "HEAD~1")
+	second := gitCommand(t, dir, "rev-parse", "--short=8", "HEAD")
+
+	output, err := tools.CallText(context.Background(), tool, BlameInput{Path: dir, File: "main.go", StartLine: 5, EndLine: 6})
+	require.NoError(t, err)
+	assert.Equal(t, first+" 2024-03-01 Alice      5→\tfunc main() {\n"+
+		second+" 2024-03-01 Alice      6→\t\tfmt.Println(\"hi\")", output)
+
+	// 범위가 파일보다 길면 마지막 줄까지만
+	output, err = tools.CallText(context.Background(), tool, BlameInput{Path: dir, File: filepath.Join(dir, "main.go"), StartLine: 6})
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(output, "\n"), 2)
+
+	_, err = tools.CallText(context.Background(), tool, BlameInput{Path: dir, File: "main.go", StartLine: 50})
+	assert.ErrorContains(t, err, "past the end")
+
+	_, err = tools.CallText(context.Background(), tool, BlameInput{Path: dir})
+	assert.ErrorContains(t, err, "file must not be empty")
+}
+
+func TestShowTool(t *testing.T) {
+	dir := newRepository(t)
+	tool := &ShowTool{}
+
+	output, err := tools.CallText(context.Background(), tool, ShowInput{Path: dir})
+	require.NoError(t, err)
+	assert.Contains(t, output, "Author: Alice <alice@example.com>\nDate:   2024-03-01T10:00:00+00:00")
+	assert.Contains(t, output, "Print a greeting\n\nThe greeting is printed on start.")
+	assert.Contains(t, output, "1 files changed, +3 -0:\n  +3 -0 main.go")
+	assert.Contains(t, output, "+import \"fmt\"")
+
+	_, err = tools.CallText(context.Background(), tool, ShowInput{Path: dir, Revision: "doesnotexist"})
+	assert.ErrorContains(t, err, "git show failed")
+}
+
+func TestRepositoryAndLimit(t *testing.T) {
+	_, err := Repository("relative/path")
+	assert.ErrorContains(t, err, "invalid path format")
+
+	output := Limit(strings.Repeat("line\n", MaxOutputSize))
+	assert.Contains(t, output, "[output truncated:")
+	assert.Less(t, len(output), MaxOutputSize+200)
+}
@@ -0,0 +1,102 @@
+package git
+
+import (
+	"DevCode/tools"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	LogDescription = `Lists commits of a git repository, newest first.\n\nUsage:\n- Each entry shows
+  the full commit hash, the author date, the author and the subject line\n- Set file to
+  list only commits that touch a file or directory (renames are followed for single
+  files)\n- Set revision to list another branch or a range such as v1.0..HEAD\n- Set author
+  to filter by author name or email\n- limit defaults to 20 commits. Use GitShow to see the
+  full message and patch of a commit`
+	LogName = "GitLog"
+
+	DefaultLogLimit = 20
+	MaxLogLimit     = 500
+
+	fieldSeparator  = "\x1f"
+	recordSeparator = "\x1e"
+)
+
+type LogTool struct {
+}
+
+func (*LogTool) Name() string {
+	return LogName
+}
+
+func (*LogTool) Description() string {
+	return LogDescription
+}
+
+func (instance *LogTool) Handler() mcp.ToolHandlerFor[LogInput, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[LogInput]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		dir, err := Repository(input.Path)
+		if err != nil {
+			return nil, err
+		}
+		if err := ValidateRevision(input.Revision); err != nil {
+			return nil, err
+		}
+		limit := input.Limit
+		if limit <= 0 {
+			limit = DefaultLogLimit
+		}
+		limit = min(limit, MaxLogLimit)
+		args := []string{"log", "--max-count=" + strconv.Itoa(limit+1),
+			"--format=%H%x1f%an%x1f%ae%x1f%aI%x1f%s%x1e"}
+		if input.Author != "" {
+			args = append(args, "--author="+input.Author)
+		}
+		if input.Revision != "" {
+			args = append(args, input.Revision)
+		}
+		if input.File != "" {
+			args = append(args, "--follow", "--", input.File)
+		}
+		output, err := Run(ctx, dir, args...)
+		if err != nil {
+			return nil, err
+		}
+		commits := ParseLog(output)
+		if len(commits) == 0 {
+			return tools.TextReturn("No commits found")
+		}
+		var builder strings.Builder
+		for index, commit := range commits {
+			if index == limit {
+				fmt.Fprintf(&builder, "[more commits available; raise limit to see them]\n")
+				break
+			}
+			fmt.Fprintf(&builder, "%s %s %s <%s>: %s\n", commit.Hash, commit.Date, commit.Author, commit.Email, commit.Subject)
+		}
+		return tools.TextReturn(Limit(strings.TrimSuffix(builder.String(), "\n")))
+	}
+}
+
+func ParseLog(output string) []Commit {
+	var commits []Commit
+	for _, record := range strings.Split(output, recordSeparator) {
+		fields := strings.Split(strings.TrimLeft(record, "\n"), fieldSeparator)
+		if len(fields) != 5 {
+			continue
+		}
+		commits = append(commits, Commit{
+			Hash:    fields[0],
+			Author:  fields[1],
+			Email:   fields[2],
+			Date:    fields[3],
+			Subject: fields[4],
+		})
+	}
+	return commits
+}
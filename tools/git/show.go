@@ -0,0 +1,72 @@
+package git
+
+import (
+	"DevCode/tools"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	ShowDescription = `Shows a single commit: hash, author, dates, the full commit message, a
+  per-file summary and the patch.\n\nUsage:\n- revision defaults to HEAD and may be a hash,
+  branch, tag or an expression such as HEAD~2\n- Set file to limit the patch to one file or
+  directory\n- Large patches are truncated, so narrow them down with file when needed`
+	ShowName = "GitShow"
+
+	showFormat = "--format=commit %H%nAuthor: %an <%ae>%nDate:   %aI%nCommit: %cn <%ce> %cI%n%n%B"
+)
+
+type ShowTool struct {
+}
+
+func (*ShowTool) Name() string {
+	return ShowName
+}
+
+func (*ShowTool) Description() string {
+	return ShowDescription
+}
+
+func (instance *ShowTool) Handler() mcp.ToolHandlerFor[ShowInput, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[ShowInput]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		dir, err := Repository(input.Path)
+		if err != nil {
+			return nil, err
+		}
+		revision := input.Revision
+		if revision == "" {
+			revision = "HEAD"
+		}
+		if err := ValidateRevision(revision); err != nil {
+			return nil, err
+		}
+		pathspec := []string{"--"}
+		if input.File != "" {
+			pathspec = append(pathspec, input.File)
+		}
+		header, err := Run(ctx, dir, "show", "--no-patch", showFormat, revision)
+		if err != nil {
+			return nil, err
+		}
+		stat, err := Run(ctx, dir, append([]string{"show", "--format=", "--numstat", "--find-renames", revision}, pathspec...)...)
+		if err != nil {
+			return nil, err
+		}
+		patch, err := Run(ctx, dir, append([]string{"show", "--format=", "--no-ext-diff", "--find-renames", revision}, pathspec...)...)
+		if err != nil {
+			return nil, err
+		}
+		var builder strings.Builder
+		builder.WriteString(strings.TrimRight(header, "\n"))
+		builder.WriteString("\n\n")
+		builder.WriteString(FormatNumstat(stat))
+		if patch = strings.TrimLeft(patch, "\n"); patch != "" {
+			fmt.Fprintf(&builder, "\n%s", patch)
+		}
+		return tools.TextReturn(Limit(strings.TrimSuffix(builder.String(), "\n")))
+	}
+}
@@ -0,0 +1,111 @@
+package git
+
+import (
+	"DevCode/tools"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	StatusDescription = `Shows the working tree status of a git repository.\n\nUsage:\n- Returns the
+  current branch, its upstream with ahead/behind counts, and every changed file with its
+  two-letter status code\n- The first letter is the staged (index) status and the second the
+  unstaged (worktree) status: M modified, A added, D deleted, R renamed, C copied, U
+  unmerged, ?? untracked\n- Use this instead of running git status through Bash`
+	StatusName = "GitStatus"
+
+	MaxStatusEntries = 500
+)
+
+type StatusTool struct {
+}
+
+func (*StatusTool) Name() string {
+	return StatusName
+}
+
+func (*StatusTool) Description() string {
+	return StatusDescription
+}
+
+func (instance *StatusTool) Handler() mcp.ToolHandlerFor[StatusInput, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[StatusInput]) (*mcp.CallToolResultFor[any], error) {
+		dir, err := Repository(params.Arguments.Path)
+		if err != nil {
+			return nil, err
+		}
+		output, err := Run(ctx, dir, "status", "--porcelain=v1", "-z", "--branch", "--untracked-files=all")
+		if err != nil {
+			return nil, err
+		}
+		branch, entries := ParseStatus(output)
+		return tools.TextReturn(FormatStatus(branch, entries))
+	}
+}
+
+// ParseStatus reads the output of git status --porcelain=v1 -z --branch.
+func ParseStatus(output string) (string, []StatusEntry) {
+	fields := strings.Split(output, "\x00")
+	branch := ""
+	var entries []StatusEntry
+	for index := 0; index < len(fields); index++ {
+		field := fields[index]
+		if field == "" {
+			continue
+		}
+		if strings.HasPrefix(field, "## ") {
+			branch = strings.TrimPrefix(field, "## ")
+			continue
+		}
+		if len(field) < 4 {
+			continue
+		}
+		entry := StatusEntry{Code: field[:2], Path: field[3:]}
+		if (entry.Code[0] == 'R' || entry.Code[0] == 'C') && index+1 < len(fields) {
+			entry.OldPath = fields[index+1]
+			index++
+		}
+		entries = append(entries, entry)
+	}
+	return branch, entries
+}
+
+func FormatStatus(branch string, entries []StatusEntry) string {
+	var builder strings.Builder
+	name, upstream, tracking := branch, "", ""
+	if open := strings.Index(name, " ["); open >= 0 && strings.HasSuffix(name, "]") {
+		tracking = name[open+2 : len(name)-1]
+		name = name[:open]
+	}
+	if separator := strings.Index(name, "..."); separator >= 0 {
+		upstream = name[separator+3:]
+		name = name[:separator]
+	}
+	fmt.Fprintf(&builder, "Branch: %s\n", name)
+	if upstream != "" {
+		if tracking == "" {
+			tracking = "up to date"
+		}
+		fmt.Fprintf(&builder, "Upstream: %s (%s)\n", upstream, tracking)
+	}
+	if len(entries) == 0 {
+		builder.WriteString("\nWorking tree clean")
+		return builder.String()
+	}
+	fmt.Fprintf(&builder, "\n%d changed files (XY: X = staged, Y = unstaged):\n", len(entries))
+	for index, entry := range entries {
+		if index == MaxStatusEntries {
+			fmt.Fprintf(&builder, "[%d more files not shown]\n", len(entries)-MaxStatusEntries)
+			break
+		}
+		if entry.OldPath != "" {
+			fmt.Fprintf(&builder, "%s %s -> %s\n", entry.Code, entry.OldPath, entry.Path)
+		} else {
+			fmt.Fprintf(&builder, "%s %s\n", entry.Code, entry.Path)
+		}
+	}
+	return strings.TrimSuffix(builder.String(), "\n")
+}
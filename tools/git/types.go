@@ -0,0 +1,57 @@
+package git
+
+type StatusInput struct {
+	Path string `json:"path,omitempty" jsonschema:"description:The absolute path of a directory inside the repository. Defaults to the current working directory"`
+}
+
+type DiffInput struct {
+	Path     string `json:"path,omitempty" jsonschema:"description:The absolute path of a directory inside the repository. Defaults to the current working directory"`
+	File     string `json:"file,omitempty" jsonschema:"description:Limit the diff to this file or directory, absolute or relative to path"`
+	Revision string `json:"revision,omitempty" jsonschema:"description:A revision or range such as HEAD~3, main..feature or abc123. Without it the working tree is compared with the index"`
+	Staged   bool   `json:"staged,omitempty" jsonschema:"description:Show staged changes (the index compared with HEAD) instead of unstaged changes"`
+	Context  int    `json:"context,omitempty" jsonschema:"description:Number of context lines around each change. Defaults to 3"`
+}
+
+type LogInput struct {
+	Path     string `json:"path,omitempty" jsonschema:"description:The absolute path of a directory inside the repository. Defaults to the current working directory"`
+	File     string `json:"file,omitempty" jsonschema:"description:Only show commits that touch this file or directory, absolute or relative to path"`
+	Revision string `json:"revision,omitempty" jsonschema:"description:A revision or range to list, such as main or v1.0..HEAD. Defaults to HEAD"`
+	Author   string `json:"author,omitempty" jsonschema:"description:Only show commits whose author matches this pattern"`
+	Limit    int    `json:"limit,omitempty" jsonschema:"description:Maximum number of commits to return. Defaults to 20"`
+}
+
+type BlameInput struct {
+	Path      string `json:"path,omitempty" jsonschema:"description:The absolute path of a directory inside the repository. Defaults to the current working directory"`
+	File      string `json:"file" jsonschema:"description:The file to blame, absolute or relative to path"`
+	StartLine int    `json:"start_line,omitempty" jsonschema:"description:First line to blame (1-based). Defaults to 1"`
+	EndLine   int    `json:"end_line,omitempty" jsonschema:"description:Last line to blame. Defaults to start_line plus 199"`
+	Revision  string `json:"revision,omitempty" jsonschema:"description:Blame the file as of this revision instead of the working tree"`
+}
+
+type ShowInput struct {
+	Path     string `json:"path,omitempty" jsonschema:"description:The absolute path of a directory inside the repository. Defaults to the current working directory"`
+	Revision string `json:"revision,omitempty" jsonschema:"description:The commit to show. Defaults to HEAD"`
+	File     string `json:"file,omitempty" jsonschema:"description:Limit the patch to this file or directory, absolute or relative to path"`
+}
+
+type StatusEntry struct {
+	Code    string
+	Path    string
+	OldPath string
+}
+
+type Commit struct {
+	Hash    string
+	Author  string
+	Email   string
+	Date    string
+	Subject string
+}
+
+type BlameLine struct {
+	Hash    string
+	Author  string
+	Date    string
+	Line    int
+	Content string
+}
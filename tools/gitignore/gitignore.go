@@ -0,0 +1,143 @@
+package gitignore
+
+import (
+	"DevCode/constants"
+	"DevCode/tools/pattern"
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	FileName = ".gitignore"
+	GitDir   = ".git"
+)
+
+type Rule struct {
+	Base    string
+	Negate  bool
+	DirOnly bool
+	Regexp  *regexp.Regexp
+}
+
+func NewMatcher(root string) *Matcher {
+	matcher := &Matcher{
+		root:   root,
+		loaded: make(map[string]bool),
+	}
+	matcher.loadFile(root, filepath.Join(root, GitDir, "info", "exclude"))
+	matcher.Load(root)
+	return matcher
+}
+
+type Matcher struct {
+	root   string
+	rules  []Rule
+	loaded map[string]bool
+	mutex  sync.RWMutex
+}
+
+func (instance *Matcher) Load(dir string) {
+	instance.mutex.Lock()
+	if instance.loaded[dir] {
+		instance.mutex.Unlock()
+		return
+	}
+	instance.loaded[dir] = true
+	instance.mutex.Unlock()
+	instance.loadFile(dir, filepath.Join(dir, FileName))
+}
+
+func (instance *Matcher) loadFile(base string, path string) {
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	rules := make([]Rule, 0, 10)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if rule, ok := ParseRule(base, scanner.Text()); ok {
+			rules = append(rules, rule)
+		}
+	}
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	instance.rules = append(instance.rules, rules...)
+}
+
+func (instance *Matcher) AddPatterns(base string, lines ...string) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	for _, line := range lines {
+		if rule, ok := ParseRule(base, line); ok {
+			instance.rules = append(instance.rules, rule)
+		}
+	}
+}
+
+// Match reports whether path is ignored. The .git directory and DevCode's
+// own data directory, which holds the trash, are always ignored.
+func (instance *Matcher) Match(path string, isDir bool) bool {
+	if name := filepath.Base(path); name == GitDir || (isDir && name == constants.DataDirectory) {
+		return true
+	}
+	instance.mutex.RLock()
+	defer instance.mutex.RUnlock()
+	ignored := false
+	for _, rule := range instance.rules {
+		if rule.DirOnly && !isDir {
+			continue
+		}
+		relative, err := filepath.Rel(rule.Base, path)
+		if err != nil || relative == "." || strings.HasPrefix(relative, "..") {
+			continue
+		}
+		if rule.Regexp.MatchString(filepath.ToSlash(relative)) {
+			ignored = !rule.Negate
+		}
+	}
+	return ignored
+}
+
+func ParseRule(base string, line string) (Rule, bool) {
+	line = strings.TrimRight(line, "\r")
+	if !strings.HasSuffix(line, `\ `) {
+		line = strings.TrimRight(line, " ")
+	}
+	if line == "" || strings.HasPrefix(line, "#") {
+		return Rule{}, false
+	}
+	rule := Rule{Base: base}
+	if strings.HasPrefix(line, "!") {
+		rule.Negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		rule.DirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return Rule{}, false
+	}
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+	expression, err := pattern.Translate(line)
+	if err != nil {
+		return Rule{}, false
+	}
+	if !anchored {
+		expression = "(?:.*/)?" + expression
+	}
+	compiled, err := regexp.Compile("^" + expression + "$")
+	if err != nil {
+		return Rule{}, false
+	}
+	rule.Regexp = compiled
+	return rule, true
+}
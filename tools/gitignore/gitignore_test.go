@@ -0,0 +1,57 @@
+package gitignore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatcher(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, FileName), []byte("# comment\n*.log\n!keep.log\nbuild/\n/vendor\ndocs/**/*.tmp\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "sub"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "sub", FileName), []byte("local.txt\n"), 0644))
+
+	matcher := NewMatcher(root)
+	matcher.Load(filepath.Join(root, "sub"))
+
+	tests := []struct {
+		path     string
+		isDir    bool
+		expected bool
+	}{
+		{"app.log", false, true},
+		{"deep/nested/app.log", false, true},
+		{"keep.log", false, false},
+		{"build", true, true},
+		{"build", false, false},
+		{"src/build", true, true},
+		{"vendor", true, true},
+		{"src/vendor", true, false},
+		{"docs/a/b/x.tmp", false, true},
+		{"x.tmp", false, false},
+		{"sub/local.txt", false, true},
+		{"local.txt", false, false},
+		{".git", true, true},
+		{"main.go", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, matcher.Match(filepath.Join(root, tt.path), tt.isDir))
+		})
+	}
+}
+
+func TestParseRuleSkipsBlankAndComments(t *testing.T) {
+	_, ok := ParseRule("/", "")
+	assert.False(t, ok)
+	_, ok = ParseRule("/", "# comment")
+	assert.False(t, ok)
+	rule, ok := ParseRule("/", "!important")
+	assert.True(t, ok)
+	assert.True(t, rule.Negate)
+}
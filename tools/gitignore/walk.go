@@ -0,0 +1,65 @@
+package gitignore
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func FindRoot(path string) string {
+	current := path
+	for {
+		if _, err := os.Stat(filepath.Join(current, GitDir)); err == nil {
+			return current
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return ""
+		}
+		current = parent
+	}
+}
+
+func ForPath(path string) *Matcher {
+	root := FindRoot(path)
+	if root == "" {
+		return NewMatcher(path)
+	}
+	matcher := NewMatcher(root)
+	relative, err := filepath.Rel(root, path)
+	if err != nil || relative == "." {
+		return matcher
+	}
+	current := root
+	for _, segment := range strings.Split(relative, string(filepath.Separator)) {
+		current = filepath.Join(current, segment)
+		matcher.Load(current)
+	}
+	return matcher
+}
+
+func Walk(ctx context.Context, root string, matcher *Matcher, walkFunc func(path string, entry fs.DirEntry) error) error {
+	return filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			if entry != nil && entry.IsDir() && path != root {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if path != root && matcher.Match(path, entry.IsDir()) {
+			if entry.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			matcher.Load(path)
+		}
+		return walkFunc(path, entry)
+	})
+}
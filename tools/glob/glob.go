@@ -0,0 +1,123 @@
+package glob
+
+import (
+	"DevCode/tools"
+	"DevCode/tools/gitignore"
+	"DevCode/tools/pattern"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	GlobDescription = `- Fast file pattern matching tool that works with any codebase size\n-
+  Supports glob patterns like "**/*.js", "src/**/*.ts" or "**/*.{go,mod}"\n- Returns matching
+  file paths sorted by modification time, newest first\n- Files ignored by .gitignore and the
+  .git directory are skipped\n- Use this tool when you need to find files by name patterns\n-
+  You have the capability to call multiple tools in a single response. It is always better
+  to speculatively perform multiple searches as a batch that are potentially useful.`
+	Name = "Glob"
+
+	MaxResults = 100
+)
+
+type Tool struct {
+}
+
+func (*Tool) Name() string {
+	return Name
+}
+
+func (*Tool) Description() string {
+	return GlobDescription
+}
+
+func (instance *Tool) Handler() mcp.ToolHandlerFor[Input, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[Input]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		if input.Pattern == "" {
+			return nil, fmt.Errorf("pattern must not be empty")
+		}
+		root := input.Path
+		if root == "" {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return nil, fmt.Errorf("fail to read working directory: %v", err)
+			}
+			root = cwd
+		}
+		if !filepath.IsAbs(root) {
+			return nil, fmt.Errorf("invalid path format: %s", root)
+		}
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("directory not found: %s", root)
+		}
+		matches, err := Search(ctx, root, input.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return tools.TextReturn("No files found")
+		}
+		var builder strings.Builder
+		for index, match := range matches {
+			if index == MaxResults {
+				builder.WriteString("(Results are truncated. Consider using a more specific path or pattern.)\n")
+				break
+			}
+			builder.WriteString(match.Path + "\n")
+		}
+		return tools.TextReturn(strings.TrimRight(builder.String(), "\n"))
+	}
+}
+
+func Search(ctx context.Context, root string, glob string) ([]Match, error) {
+	glob = strings.TrimPrefix(filepath.ToSlash(glob), "./")
+	if filepath.IsAbs(glob) {
+		relative, err := filepath.Rel(root, glob)
+		if err != nil || strings.HasPrefix(relative, "..") {
+			return nil, fmt.Errorf("pattern %s is outside of %s", glob, root)
+		}
+		glob = filepath.ToSlash(relative)
+	}
+	compiled, err := pattern.Compile(glob)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %v", err)
+	}
+	start := filepath.Join(root, filepath.FromSlash(pattern.StaticPrefix(glob)))
+	if _, err := os.Stat(start); err != nil {
+		return []Match{}, nil
+	}
+	matches := make([]Match, 0, MaxResults)
+	err = gitignore.Walk(ctx, start, gitignore.ForPath(start), func(path string, entry fs.DirEntry) error {
+		if entry.IsDir() {
+			return nil
+		}
+		relative, err := filepath.Rel(root, path)
+		if err != nil || !compiled.MatchString(filepath.ToSlash(relative)) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		matches = append(matches, Match{Path: path, ModTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].ModTime.Equal(matches[j].ModTime) {
+			return matches[i].Path < matches[j].Path
+		}
+		return matches[i].ModTime.After(matches[j].ModTime)
+	})
+	return matches, nil
+}
@@ -0,0 +1,87 @@
+package glob
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createFile(t *testing.T, root string, name string, modTime time.Time) string {
+	t.Helper()
+	path := filepath.Join(root, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(name), 0644))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	return path
+}
+
+func TestSearchSortsByModTime(t *testing.T) {
+	root := t.TempDir()
+	now := time.Now()
+	older := createFile(t, root, "a/old.go", now.Add(-time.Hour))
+	newer := createFile(t, root, "b/c/new.go", now)
+	createFile(t, root, "b/readme.md", now)
+
+	matches, err := Search(context.Background(), root, "**/*.go")
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+	assert.Equal(t, newer, matches[0].Path)
+	assert.Equal(t, older, matches[1].Path)
+}
+
+func TestSearchRespectsGitignore(t *testing.T) {
+	root := t.TempDir()
+	now := time.Now()
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".git"), 0755))
+	createFile(t, root, ".git/config.go", now)
+	createFile(t, root, ".gitignore", now)
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".gitignore"), []byte("gen/\n"), 0644))
+	createFile(t, root, "gen/out.go", now)
+	kept := createFile(t, root, "main.go", now)
+
+	matches, err := Search(context.Background(), root, "**/*.go")
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, kept, matches[0].Path)
+}
+
+func TestSearchBraceAndPrefix(t *testing.T) {
+	root := t.TempDir()
+	now := time.Now()
+	createFile(t, root, "src/x/a.ts", now)
+	createFile(t, root, "src/b.tsx", now)
+	createFile(t, root, "lib/c.ts", now)
+
+	matches, err := Search(context.Background(), root, "src/**/*.{ts,tsx}")
+	require.NoError(t, err)
+	assert.Len(t, matches, 2)
+}
+
+func TestGlobHandler(t *testing.T) {
+	root := t.TempDir()
+	createFile(t, root, "main.go", time.Now())
+
+	tool := &Tool{}
+	result, err := tool.Handler()(context.Background(), nil, &mcp.CallToolParamsFor[Input]{Arguments: Input{Pattern: "*.md", Path: root}})
+	require.NoError(t, err)
+	assert.Equal(t, "No files found", result.Content[0].(*mcp.TextContent).Text)
+
+	for i := 0; i < MaxResults+5; i++ {
+		createFile(t, root, filepath.Join("many", strings.Repeat("x", i+1)+".txt"), time.Now())
+	}
+	result, err = tool.Handler()(context.Background(), nil, &mcp.CallToolParamsFor[Input]{Arguments: Input{Pattern: "many/*.txt", Path: root}})
+	require.NoError(t, err)
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "Results are truncated")
+	assert.Equal(t, MaxResults+1, len(strings.Split(text, "\n")))
+
+	_, err = tool.Handler()(context.Background(), nil, &mcp.CallToolParamsFor[Input]{Arguments: Input{Pattern: "*.go", Path: "relative"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,13 @@
+package glob
+
+import "time"
+
+type Input struct {
+	Pattern string `json:"pattern" jsonschema:"description:The glob pattern to match files against"`
+	Path    string `json:"path,omitempty" jsonschema:"description:The absolute path of the directory to search in. If not specified, the current working directory will be used"`
+}
+
+type Match struct {
+	Path    string
+	ModTime time.Time
+}
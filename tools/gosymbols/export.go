@@ -0,0 +1,92 @@
+package gosymbols
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const ListTimeout = 2 * time.Minute
+
+// exportImporter imports packages outside the module from the export data the
+// go command leaves in the build cache, so the standard library and
+// dependencies are not type-checked from source on every load. A package
+// without export data, for example when the go command is missing, falls back
+// to the source importer. GOPROXY is off, so nothing is downloaded.
+type exportImporter struct {
+	root    string
+	exports map[string]string
+	export  types.Importer
+	source  types.ImporterFrom
+}
+
+func newExportImporter(fset *token.FileSet, root string) *exportImporter {
+	instance := &exportImporter{
+		root:    root,
+		exports: make(map[string]string),
+		source:  importer.ForCompiler(fset, "source", nil).(types.ImporterFrom),
+	}
+	instance.export = importer.ForCompiler(fset, "gc", instance.lookup)
+	return instance
+}
+
+func (instance *exportImporter) Import(path string) (*types.Package, error) {
+	return instance.ImportFrom(path, instance.root, 0)
+}
+
+func (instance *exportImporter) ImportFrom(path string, dir string, mode types.ImportMode) (*types.Package, error) {
+	instance.Prefetch([]string{path})
+	if instance.exports[path] != "" {
+		if pkg, err := instance.export.Import(path); err == nil {
+			return pkg, nil
+		}
+	}
+	return instance.source.ImportFrom(path, dir, mode)
+}
+
+// Prefetch asks the go command once for the export data of every path not
+// seen yet and of everything they import.
+func (instance *exportImporter) Prefetch(paths []string) {
+	missing := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if _, seen := instance.exports[path]; !seen && path != "C" && path != "unsafe" {
+			missing = append(missing, path)
+			instance.exports[path] = ""
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), ListTimeout)
+	defer cancel()
+	command := exec.CommandContext(ctx, "go", append([]string{"list", "-e", "-export", "-deps", "-f", "{{.ImportPath}}\t{{.Export}}", "--"}, missing...)...)
+	command.Dir = instance.root
+	command.Env = append(os.Environ(), "GOPROXY=off", "CGO_ENABLED=0")
+	output, err := command.Output()
+	if err != nil && len(output) == 0 {
+		return
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		path, export, found := strings.Cut(scanner.Text(), "\t")
+		if found && export != "" {
+			instance.exports[path] = export
+		}
+	}
+}
+
+func (instance *exportImporter) lookup(path string) (io.ReadCloser, error) {
+	instance.Prefetch([]string{path})
+	if export := instance.exports[path]; export != "" {
+		return os.Open(export)
+	}
+	return nil, os.ErrNotExist
+}
@@ -0,0 +1,481 @@
+package gosymbols
+
+import (
+	"DevCode/tools"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	GoSymbolsDescription = `Go code intelligence built on the Go type checker. Works offline and is
+  more accurate than searching with Grep.\n\nActions:\n- symbols: list the top-level
+  declarations of a package directory or a single file with their types and positions\n-
+  definition: find where symbol is declared and show its signature, doc comment and
+  source\n- references: find every use of symbol in the module, including test files\n-
+  methods: show the method sets of a type T and of *T, including promoted methods\n\nUsage:\n-
+  path is the absolute path of a Go file or package directory and decides which package
+  symbol is resolved in\n- symbol may be Name, Type.Method, Type.Field or pkg.Name for an
+  imported package\n- For local variables or ambiguous names, also pass the file as path
+  and the line the identifier appears on`
+	Name = "GoSymbols"
+
+	MaxReferences   = 200
+	MaxSnippetLines = 40
+	maxTypeSummary  = 80
+)
+
+func NewTool() *Tool {
+	return &Tool{loaders: make(map[string]*Loader)}
+}
+
+// Tool keeps one Loader per module root, so packages checked by one call are
+// reused by the next until their files change.
+type Tool struct {
+	loaders map[string]*Loader
+	mutex   sync.Mutex
+}
+
+func (*Tool) Name() string {
+	return Name
+}
+
+func (*Tool) Description() string {
+	return GoSymbolsDescription
+}
+
+func (instance *Tool) Handler() mcp.ToolHandlerFor[Input, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[Input]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		if input.Path == "" || !filepath.IsAbs(input.Path) {
+			return nil, fmt.Errorf("invalid path format: %s", input.Path)
+		}
+		info, err := os.Stat(input.Path)
+		if err != nil {
+			return nil, fmt.Errorf("file not found: %s", input.Path)
+		}
+		dir, file := input.Path, ""
+		if !info.IsDir() {
+			dir, file = filepath.Dir(input.Path), input.Path
+		}
+		if input.Action != ActionSymbols && strings.TrimSpace(input.Symbol) == "" {
+			return nil, fmt.Errorf("symbol is required for the %s action", input.Action)
+		}
+		loader, err := instance.loader(dir)
+		if err != nil {
+			return nil, err
+		}
+		loader.Mutex.Lock()
+		defer loader.Mutex.Unlock()
+		if err := loader.Refresh(); err != nil {
+			return nil, err
+		}
+		var output string
+		switch input.Action {
+		case ActionSymbols:
+			output, err = Symbols(loader, dir, file)
+		case ActionDefinition:
+			output, err = Definition(loader, dir, file, input.Symbol, input.Line)
+		case ActionReferences:
+			output, err = References(ctx, loader, dir, file, input.Symbol, input.Line)
+		case ActionMethods:
+			output, err = Methods(loader, dir, file, input.Symbol, input.Line)
+		default:
+			return nil, fmt.Errorf("unknown action %q: use symbols, definition, references or methods", input.Action)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return tools.TextReturn(output)
+	}
+}
+
+func (instance *Tool) loader(dir string) (*Loader, error) {
+	root, _, err := FindModule(dir)
+	if err != nil {
+		return nil, err
+	}
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	if loader, ok := instance.loaders[root]; ok {
+		return loader, nil
+	}
+	loader, err := NewLoader(dir)
+	if err != nil {
+		return nil, err
+	}
+	instance.loaders[root] = loader
+	return loader, nil
+}
+
+func Symbols(loader *Loader, dir string, file string) (string, error) {
+	packages, err := loader.LoadWithTests(dir)
+	if err != nil {
+		return "", err
+	}
+	pkg := choosePackage(loader, packages, file)
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "package %s (%s)\n", pkg.Types.Name(), pkg.Path)
+	count := 0
+	for _, astFile := range pkg.Files {
+		filename := loader.Fset.Position(astFile.Pos()).Filename
+		if (file != "" && filename != file) || (file == "" && strings.HasSuffix(filename, "_test.go")) {
+			continue
+		}
+		for _, decl := range astFile.Decls {
+			for _, ident := range declaredNames(decl) {
+				object := pkg.Info.Defs[ident]
+				if object == nil {
+					continue
+				}
+				count++
+				fmt.Fprintf(&builder, "%s  %s\n", Describe(object, pkg.Types), loader.Relative(loader.Fset.Position(ident.Pos())))
+			}
+		}
+	}
+	if count == 0 {
+		builder.WriteString("No top-level declarations found\n")
+	}
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func declaredNames(decl ast.Decl) []*ast.Ident {
+	switch node := decl.(type) {
+	case *ast.FuncDecl:
+		return []*ast.Ident{node.Name}
+	case *ast.GenDecl:
+		var names []*ast.Ident
+		for _, spec := range node.Specs {
+			switch spec := spec.(type) {
+			case *ast.TypeSpec:
+				names = append(names, spec.Name)
+			case *ast.ValueSpec:
+				for _, name := range spec.Names {
+					if name.Name != "_" {
+						names = append(names, name)
+					}
+				}
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+// Describe prints an object the way it is declared, keeping type
+// declarations short by naming only the kind of their underlying type.
+func Describe(object types.Object, from *types.Package) string {
+	qualifier := types.RelativeTo(from)
+	if typeName, ok := object.(*types.TypeName); ok {
+		prefix := "type " + typeName.Name()
+		if typeName.IsAlias() {
+			return prefix + " = " + types.TypeString(typeName.Type(), qualifier)
+		}
+		switch underlying := typeName.Type().Underlying().(type) {
+		case *types.Struct:
+			return fmt.Sprintf("%s struct (%d fields)", prefix, underlying.NumFields())
+		case *types.Interface:
+			return fmt.Sprintf("%s interface (%d methods)", prefix, underlying.NumMethods())
+		default:
+			summary := types.TypeString(underlying, qualifier)
+			if len(summary) > maxTypeSummary {
+				summary = summary[:maxTypeSummary] + "…"
+			}
+			return prefix + " " + summary
+		}
+	}
+	return types.ObjectString(object, qualifier)
+}
+
+func choosePackage(loader *Loader, packages []*Package, file string) *Package {
+	if file != "" {
+		for _, pkg := range packages {
+			for _, astFile := range pkg.Files {
+				if loader.Fset.Position(astFile.Pos()).Filename == file {
+					return pkg
+				}
+			}
+		}
+	}
+	return packages[0]
+}
+
+// Resolve finds the object symbol refers to, either at a line of file or
+// in the scope of the package in dir.
+func Resolve(loader *Loader, dir string, file string, symbol string, line int) (types.Object, *Package, error) {
+	packages, err := loader.LoadWithTests(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	pkg := choosePackage(loader, packages, file)
+	parts := strings.Split(strings.TrimSpace(symbol), ".")
+	name := parts[len(parts)-1]
+
+	if line > 0 {
+		if file == "" {
+			return nil, nil, fmt.Errorf("line requires path to be a file")
+		}
+		var found *ast.Ident
+		var object types.Object
+		for _, objects := range []map[*ast.Ident]types.Object{pkg.Info.Defs, pkg.Info.Uses} {
+			for ident, candidate := range objects {
+				position := loader.Fset.Position(ident.Pos())
+				if candidate == nil || ident.Name != name || position.Filename != file || position.Line != line {
+					continue
+				}
+				if found == nil || ident.Pos() < found.Pos() {
+					found, object = ident, candidate
+				}
+			}
+		}
+		if object == nil {
+			return nil, nil, fmt.Errorf("symbol %s not found on line %d of %s", name, line, file)
+		}
+		return object, pkg, nil
+	}
+
+	scope := pkg.Types.Scope()
+	switch len(parts) {
+	case 1:
+		if object := scope.Lookup(name); object != nil {
+			return object, pkg, nil
+		}
+	case 2:
+		if object := scope.Lookup(parts[0]); object != nil {
+			if _, ok := object.(*types.TypeName); ok {
+				member, _, _ := types.LookupFieldOrMethod(object.Type(), true, pkg.Types, name)
+				if member != nil {
+					return member, pkg, nil
+				}
+				return nil, nil, fmt.Errorf("type %s has no field or method %s", parts[0], name)
+			}
+		}
+		for _, imported := range pkg.Types.Imports() {
+			if imported.Name() == parts[0] || imported.Path() == parts[0] {
+				if object := imported.Scope().Lookup(name); object != nil {
+					return object, pkg, nil
+				}
+			}
+		}
+	}
+	return nil, nil, fmt.Errorf("symbol %s not found in package %s; pass the file as path and the line for local identifiers", symbol, pkg.Path)
+}
+
+func Definition(loader *Loader, dir string, file string, symbol string, line int) (string, error) {
+	object, pkg, err := Resolve(loader, dir, file, symbol, line)
+	if err != nil {
+		return "", err
+	}
+	var builder strings.Builder
+	builder.WriteString(Describe(object, pkg.Types))
+	builder.WriteString("\n")
+	if object.Pkg() != nil && object.Pkg() != pkg.Types {
+		fmt.Fprintf(&builder, "Package: %s\n", object.Pkg().Path())
+	}
+	if !object.Pos().IsValid() {
+		builder.WriteString("Declared in the universe scope (builtin)")
+		return builder.String(), nil
+	}
+	position := loader.Fset.Position(object.Pos())
+	fmt.Fprintf(&builder, "Defined at %s\n", loader.Relative(position))
+	start, end := position.Line, position.Line
+	if node, doc := findDeclaration(loader, object); node != nil {
+		start = loader.Fset.Position(node.Pos()).Line
+		end = loader.Fset.Position(node.End()).Line
+		if doc != nil {
+			start = loader.Fset.Position(doc.Pos()).Line
+		}
+	}
+	if snippet := readLines(position.Filename, start, min(end, start+MaxSnippetLines-1)); snippet != "" {
+		builder.WriteString("\n")
+		builder.WriteString(snippet)
+		if end > start+MaxSnippetLines-1 {
+			fmt.Fprintf(&builder, "\n[%d more lines]", end-start-MaxSnippetLines+1)
+		}
+	}
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// findDeclaration returns the declaration node of an object that belongs
+// to a module package, along with its doc comment.
+func findDeclaration(loader *Loader, object types.Object) (ast.Node, *ast.CommentGroup) {
+	target := loader.Fset.File(object.Pos())
+	position := object.Pos()
+	for _, pkg := range loader.Cached() {
+		for _, file := range pkg.Files {
+			if loader.Fset.File(file.Pos()) != target {
+				continue
+			}
+			defines := func(names ...*ast.Ident) bool {
+				for _, name := range names {
+					if name.Pos() == position && pkg.Info.Defs[name] != nil {
+						return true
+					}
+				}
+				return false
+			}
+			var found ast.Node
+			var doc *ast.CommentGroup
+			ast.Inspect(file, func(node ast.Node) bool {
+				if found != nil || node == nil || node.Pos() > position || node.End() <= position {
+					return false
+				}
+				switch node := node.(type) {
+				case *ast.FuncDecl:
+					if defines(node.Name) {
+						found, doc = node, node.Doc
+					}
+				case *ast.GenDecl:
+					if len(node.Specs) == 1 && defines(declaredNames(node)...) {
+						found, doc = node, node.Doc
+					}
+				case *ast.TypeSpec:
+					if defines(node.Name) {
+						found, doc = node, node.Doc
+					}
+				case *ast.ValueSpec:
+					if defines(node.Names...) {
+						found, doc = node, node.Doc
+					}
+				case *ast.Field:
+					if defines(node.Names...) {
+						found, doc = node, node.Doc
+					}
+				}
+				return true
+			})
+			return found, doc
+		}
+	}
+	return nil, nil
+}
+
+func readLines(filename string, start int, end int) string {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(string(data), "\n")
+	var builder strings.Builder
+	for number := start; number <= end && number <= len(lines); number++ {
+		fmt.Fprintf(&builder, "%6d→\t%s\n", number, lines[number-1])
+	}
+	return builder.String()
+}
+
+type reference struct {
+	position token.Position
+	text     string
+}
+
+func References(ctx context.Context, loader *Loader, dir string, file string, symbol string, line int) (string, error) {
+	object, pkg, err := Resolve(loader, dir, file, symbol, line)
+	if err != nil {
+		return "", err
+	}
+	key := loader.Key(object)
+	if key == "" {
+		return "", fmt.Errorf("%s is a builtin and has no references to report", symbol)
+	}
+	dirs, err := loader.PackageDirs()
+	if err != nil {
+		return "", err
+	}
+	seen := make(map[string]bool)
+	var references []reference
+	for _, packageDir := range dirs {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		packages, err := loader.LoadWithTests(packageDir)
+		if err != nil {
+			continue
+		}
+		for _, candidate := range packages {
+			for ident, used := range candidate.Info.Uses {
+				if loader.Key(used) != key {
+					continue
+				}
+				position := loader.Fset.Position(ident.Pos())
+				id := fmt.Sprintf("%s:%d", position.Filename, position.Offset)
+				if seen[id] {
+					continue
+				}
+				seen[id] = true
+				references = append(references, reference{position: position})
+			}
+		}
+	}
+	sort.Slice(references, func(left int, right int) bool {
+		if references[left].position.Filename != references[right].position.Filename {
+			return references[left].position.Filename < references[right].position.Filename
+		}
+		return references[left].position.Offset < references[right].position.Offset
+	})
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%d references to %s (defined at %s)\n", len(references), Describe(object, pkg.Types), loader.Relative(loader.Fset.Position(object.Pos())))
+	cache := make(map[string][]string)
+	for index, reference := range references {
+		if index == MaxReferences {
+			fmt.Fprintf(&builder, "[%d more references not shown]\n", len(references)-MaxReferences)
+			break
+		}
+		lines, ok := cache[reference.position.Filename]
+		if !ok {
+			if data, err := os.ReadFile(reference.position.Filename); err == nil {
+				lines = strings.Split(string(data), "\n")
+			}
+			cache[reference.position.Filename] = lines
+		}
+		text := ""
+		if reference.position.Line <= len(lines) {
+			text = strings.TrimSpace(lines[reference.position.Line-1])
+		}
+		fmt.Fprintf(&builder, "%s: %s\n", loader.Relative(reference.position), text)
+	}
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func Methods(loader *Loader, dir string, file string, symbol string, line int) (string, error) {
+	object, pkg, err := Resolve(loader, dir, file, symbol, line)
+	if err != nil {
+		return "", err
+	}
+	typeName, ok := object.(*types.TypeName)
+	if !ok {
+		return "", fmt.Errorf("%s is not a type", symbol)
+	}
+	qualifier := types.RelativeTo(pkg.Types)
+	var builder strings.Builder
+	named := typeName.Type()
+	writeSet := func(title string, set *types.MethodSet) {
+		fmt.Fprintf(&builder, "%s (%d methods):\n", title, set.Len())
+		for index := 0; index < set.Len(); index++ {
+			selection := set.At(index)
+			method := selection.Obj()
+			note := ""
+			if len(selection.Index()) > 1 {
+				note = " (promoted)"
+			}
+			fmt.Fprintf(&builder, "  %s%s  %s\n", types.ObjectString(method, qualifier), note, loader.Relative(loader.Fset.Position(method.Pos())))
+		}
+	}
+	if types.IsInterface(named) {
+		writeSet("Method set of interface "+typeName.Name(), types.NewMethodSet(named))
+		return strings.TrimSuffix(builder.String(), "\n"), nil
+	}
+	writeSet("Method set of "+typeName.Name(), types.NewMethodSet(named))
+	builder.WriteString("\n")
+	writeSet("Method set of *"+typeName.Name(), types.NewMethodSet(types.NewPointer(named)))
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,233 @@
+package gosymbols
+
+import (
+	"DevCode/tools"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var moduleFiles = map[string]string{
+	"go.mod": "module example.com/shop\n\ngo 1.22\n",
+	"store/store.go": `package store
+
+import "fmt"
+
+// Item is something for sale.
+type Item struct {
+	Name  string
+	Price int
+}
+
+// Base carries an ID.
+type Base struct{ ID int }
+
+func (b Base) Identifier() int { return b.ID }
+
+// Store keeps items.
+type Store struct {
+	Base
+	items []Item
+}
+
+const DefaultPrice = 100
+
+var Version = "1.0"
+
+// Add stores an item and returns how many there are.
+func (s *Store) Add(item Item) int {
+	s.items = append(s.items, item)
+	return len(s.items)
+}
+
+func (s Store) Count() int { return len(s.items) }
+
+func Describe(item Item) string {
+	label := fmt.Sprintf("%s costs %d", item.Name, item.Price)
+	return label
+}
+`,
+	"store/store_test.go": `package store
+
+import "testing"
+
+func TestAdd(t *testing.T) {
+	s := &Store{}
+	if s.Add(Item{Name: "pen"}) != 1 {
+		t.Fatal("expected one item")
+	}
+}
+`,
+	"cmd/main.go": `package main
+
+import (
+	"fmt"
+
+	"example.com/shop/store"
+)
+
+func main() {
+	s := &store.Store{}
+	s.Add(store.Item{Name: "book", Price: store.DefaultPrice})
+	fmt.Println(store.Describe(store.Item{}))
+}
+`,
+}
+
+func newModule(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range moduleFiles {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return root
+}
+
+func TestSymbols(t *testing.T) {
+	root := newModule(t)
+
+	output, err := tools.CallText(context.Background(), NewTool(), Input{Action: ActionSymbols, Path: filepath.Join(root, "store")})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(output, "package store (example.com/shop/store)\n"))
+	assert.Contains(t, output, "type Item struct (2 fields)  store/store.go:6:6")
+	assert.Contains(t, output, "func (*Store).Add(item Item) int  store/store.go:27:17")
+	assert.Contains(t, output, "const DefaultPrice untyped int")
+	assert.Contains(t, output, "var Version string")
+	assert.NotContains(t, output, "TestAdd")
+
+	// 파일을 주면 그 파일의 선언만
+	output, err = tools.CallText(context.Background(), NewTool(), Input{Action: ActionSymbols, Path: filepath.Join(root, "store", "store_test.go")})
+	require.NoError(t, err)
+	assert.Contains(t, output, "func TestAdd(t *testing.T)")
+	assert.NotContains(t, output, "type Item")
+}
+
+func TestDefinition(t *testing.T) {
+	root := newModule(t)
+
+	output, err := tools.CallText(context.Background(), NewTool(), Input{Action: ActionDefinition, Path: filepath.Join(root, "store"), Symbol: "Store.Add"})
+	require.NoError(t, err)
+	assert.Contains(t, output, "func (*Store).Add(item Item) int\nDefined at store/store.go:27:17\n")
+	assert.Contains(t, output, "    26→\t// Add stores an item and returns how many there are.\n")
+	assert.Contains(t, output, "    30→\t}")
+
+	// 다른 패키지에서 사용하는 줄로 찾기
+	output, err = tools.CallText(context.Background(), NewTool(), Input{Action: ActionDefinition, Path: filepath.Join(root, "cmd", "main.go"), Symbol: "store.Describe", Line: 12})
+	require.NoError(t, err)
+	assert.Contains(t, output, "Package: example.com/shop/store")
+	assert.Contains(t, output, "Defined at store/store.go:34:6")
+
+	// 표준 라이브러리
+	output, err = tools.CallText(context.Background(), NewTool(), Input{Action: ActionDefinition, Path: filepath.Join(root, "cmd"), Symbol: "fmt.Println"})
+	require.NoError(t, err)
+	assert.Contains(t, output, "func fmt.Println(a ...any) (n int, err error)")
+	assert.Contains(t, output, "Package: fmt")
+
+	// 지역 변수
+	output, err = tools.CallText(context.Background(), NewTool(), Input{Action: ActionDefinition, Path: filepath.Join(root, "store", "store.go"), Symbol: "label", Line: 36})
+	require.NoError(t, err)
+	assert.Contains(t, output, "var label string\nDefined at store/store.go:35:2")
+
+	// 필드
+	output, err = tools.CallText(context.Background(), NewTool(), Input{Action: ActionDefinition, Path: filepath.Join(root, "store"), Symbol: "Item.Price"})
+	require.NoError(t, err)
+	assert.Contains(t, output, "field Price int")
+	assert.Contains(t, output, "     8→\t\tPrice int")
+}
+
+func TestReferences(t *testing.T) {
+	root := newModule(t)
+
+	output, err := tools.CallText(context.Background(), NewTool(), Input{Action: ActionReferences, Path: filepath.Join(root, "store"), Symbol: "Store.Add"})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(output, "2 references to func (*Store).Add(item Item) int"))
+	assert.Contains(t, output, "cmd/main.go:11:4: s.Add(store.Item{Name: \"book\", Price: store.DefaultPrice})")
+	assert.Contains(t, output, "store/store_test.go:7:7: if s.Add(Item{Name: \"pen\"}) != 1 {")
+
+	output, err = tools.CallText(context.Background(), NewTool(), Input{Action: ActionReferences, Path: filepath.Join(root, "store"), Symbol: "Item"})
+	require.NoError(t, err)
+	assert.Contains(t, output, "store/store.go:19:10:")
+	assert.Contains(t, output, "cmd/main.go:12:35:")
+}
+
+func TestMethods(t *testing.T) {
+	root := newModule(t)
+
+	output, err := tools.CallText(context.Background(), NewTool(), Input{Action: ActionMethods, Path: filepath.Join(root, "store"), Symbol: "Store"})
+	require.NoError(t, err)
+	assert.Contains(t, output, "Method set of Store (2 methods):\n")
+	assert.Contains(t, output, "func (Store).Count() int")
+	assert.Contains(t, output, "func (Base).Identifier() int (promoted)")
+	assert.Contains(t, output, "Method set of *Store (3 methods):\n")
+	assert.Contains(t, output, "func (*Store).Add(item Item) int")
+
+	_, err = tools.CallText(context.Background(), NewTool(), Input{Action: ActionMethods, Path: filepath.Join(root, "store"), Symbol: "Version"})
+	assert.ErrorContains(t, err, "is not a type")
+}
+
+func TestErrors(t *testing.T) {
+	root := newModule(t)
+
+	_, err := tools.CallText(context.Background(), NewTool(), Input{Action: ActionDefinition, Path: "store"})
+	assert.ErrorContains(t, err, "invalid path format")
+
+	_, err = tools.CallText(context.Background(), NewTool(), Input{Action: ActionDefinition, Path: filepath.Join(root, "store")})
+	assert.ErrorContains(t, err, "symbol is required")
+
+	_, err = tools.CallText(context.Background(), NewTool(), Input{Action: "rename", Path: filepath.Join(root, "store"), Symbol: "Item"})
+	assert.ErrorContains(t, err, "unknown action")
+
+	_, err = tools.CallText(context.Background(), NewTool(), Input{Action: ActionDefinition, Path: filepath.Join(root, "store"), Symbol: "Missing"})
+	assert.ErrorContains(t, err, "symbol Missing not found")
+
+	_, err = tools.CallText(context.Background(), NewTool(), Input{Action: ActionDefinition, Path: filepath.Join(root, "store"), Symbol: "Item.Weight"})
+	assert.ErrorContains(t, err, "has no field or method Weight")
+}
+
+func TestLoaderIsReusedUntilFilesChange(t *testing.T) {
+	root := newModule(t)
+	tool := NewTool()
+	run := func(input Input) string {
+		t.Helper()
+		output, err := tools.CallText(context.Background(), tool, input)
+		require.NoError(t, err)
+		return output
+	}
+
+	run(Input{Action: ActionReferences, Path: filepath.Join(root, "store"), Symbol: "Item"})
+	loader := tool.loaders[root]
+	require.NotNil(t, loader)
+	store := loader.packages[filepath.Join(root, "store")]
+	main := loader.packages[filepath.Join(root, "cmd")]
+	require.NotNil(t, store)
+	require.NotNil(t, main)
+	// 표준 라이브러리는 소스가 아니라 export data에서 읽는다
+	assert.NotEmpty(t, loader.external.exports["fmt"])
+
+	// 바뀐 것이 없으면 같은 Loader와 패키지를 그대로 쓴다
+	run(Input{Action: ActionSymbols, Path: filepath.Join(root, "cmd")})
+	assert.Same(t, loader, tool.loaders[root])
+	assert.Same(t, store, loader.packages[filepath.Join(root, "store")])
+
+	// 파일이 바뀌면 그 패키지와 그것을 import하는 패키지만 다시 검사한다
+	path := filepath.Join(root, "store", "store.go")
+	require.NoError(t, os.WriteFile(path, []byte(moduleFiles["store/store.go"]+"\nfunc Restock() {}\n"), 0644))
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, later, later))
+	output := run(Input{Action: ActionSymbols, Path: filepath.Join(root, "store")})
+	assert.Contains(t, output, "func Restock()")
+	assert.Same(t, loader, tool.loaders[root])
+	assert.NotSame(t, store, loader.packages[filepath.Join(root, "store")])
+	_, cached := loader.packages[filepath.Join(root, "cmd")]
+	assert.False(t, cached)
+	_, cached = loader.tested[filepath.Join(root, "store")]
+	assert.True(t, cached)
+}
@@ -0,0 +1,351 @@
+package gosymbols
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Package is a type-checked package together with the files it was built
+// from and their modification times when it was checked.
+type Package struct {
+	Dir      string
+	Path     string
+	Files    []*ast.File
+	Types    *types.Package
+	Info     *types.Info
+	modified map[string]time.Time
+}
+
+// Changed reports whether the package directory or one of its files was
+// modified, added or removed since the package was checked.
+func (instance *Package) Changed() bool {
+	for path, modified := range instance.modified {
+		if info, err := os.Stat(path); err != nil || !info.ModTime().Equal(modified) {
+			return true
+		}
+	}
+	return false
+}
+
+// Loader type-checks packages of one module from source and keeps them until
+// their files change. Packages outside the module, including the standard
+// library, are imported from export data; see exportImporter. A Loader is not
+// safe for concurrent use; hold Mutex while using it.
+type Loader struct {
+	Fset       *token.FileSet
+	Root       string
+	ModulePath string
+	Mutex      sync.Mutex
+	context    build.Context
+	external   *exportImporter
+	packages   map[string]*Package
+	tested     map[string][]*Package
+	loading    map[string]bool
+	modFile    time.Time
+}
+
+func NewLoader(dir string) (*Loader, error) {
+	root, modulePath, err := FindModule(dir)
+	if err != nil {
+		return nil, err
+	}
+	context := build.Default
+	context.CgoEnabled = false
+	loader := &Loader{
+		Root:       root,
+		ModulePath: modulePath,
+		context:    context,
+	}
+	loader.reset()
+	return loader, nil
+}
+
+func (instance *Loader) reset() {
+	instance.Fset = token.NewFileSet()
+	instance.external = newExportImporter(instance.Fset, instance.Root)
+	instance.packages = make(map[string]*Package)
+	instance.tested = make(map[string][]*Package)
+	instance.loading = make(map[string]bool)
+	instance.modFile = modTime(filepath.Join(instance.Root, "go.mod"))
+}
+
+// Refresh drops the cached packages whose files changed, together with every
+// cached package that imports them. A changed go.mod can change any import,
+// so then everything is dropped.
+func (instance *Loader) Refresh() error {
+	if !modTime(filepath.Join(instance.Root, "go.mod")).Equal(instance.modFile) {
+		_, modulePath, err := FindModule(instance.Root)
+		if err != nil {
+			return err
+		}
+		instance.ModulePath = modulePath
+		instance.reset()
+		return nil
+	}
+	cached := instance.Cached()
+	stale := make(map[*types.Package]bool)
+	for _, pkg := range cached {
+		if pkg.Changed() {
+			stale[pkg.Types] = true
+		}
+	}
+	for grown := len(stale) > 0; grown; {
+		grown = false
+		for _, pkg := range cached {
+			if stale[pkg.Types] {
+				continue
+			}
+			for _, imported := range pkg.Types.Imports() {
+				if stale[imported] {
+					stale[pkg.Types] = true
+					grown = true
+					break
+				}
+			}
+		}
+	}
+	for dir, pkg := range instance.packages {
+		if stale[pkg.Types] {
+			delete(instance.packages, dir)
+		}
+	}
+	for dir, packages := range instance.tested {
+		if slices.ContainsFunc(packages, func(pkg *Package) bool { return stale[pkg.Types] }) {
+			delete(instance.tested, dir)
+		}
+	}
+	return nil
+}
+
+// Cached lists every package checked so far, with and without test files.
+func (instance *Loader) Cached() []*Package {
+	cached := make([]*Package, 0, len(instance.packages)+len(instance.tested))
+	for _, pkg := range instance.packages {
+		cached = append(cached, pkg)
+	}
+	for _, packages := range instance.tested {
+		cached = append(cached, packages...)
+	}
+	return cached
+}
+
+func modTime(path string) time.Time {
+	if info, err := os.Stat(path); err == nil {
+		return info.ModTime()
+	}
+	return time.Time{}
+}
+
+// FindModule walks up from dir to the nearest go.mod and returns its
+// directory and module path. Without a go.mod, dir itself is used as a
+// module named after the directory.
+func FindModule(dir string) (string, string, error) {
+	for current := dir; ; current = filepath.Dir(current) {
+		file, err := os.Open(filepath.Join(current, "go.mod"))
+		if err == nil {
+			defer file.Close()
+			scanner := bufio.NewScanner(file)
+			for scanner.Scan() {
+				line := strings.TrimSpace(scanner.Text())
+				if strings.HasPrefix(line, "module ") {
+					return current, strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`), nil
+				}
+			}
+			return "", "", fmt.Errorf("go.mod has no module line: %s", filepath.Join(current, "go.mod"))
+		}
+		if current == filepath.Dir(current) {
+			return dir, filepath.Base(dir), nil
+		}
+	}
+}
+
+func (instance *Loader) ImportPath(dir string) string {
+	relative, err := filepath.Rel(instance.Root, dir)
+	if err != nil || relative == "." {
+		return instance.ModulePath
+	}
+	return instance.ModulePath + "/" + filepath.ToSlash(relative)
+}
+
+func (instance *Loader) dirFor(importPath string) (string, bool) {
+	if importPath == instance.ModulePath {
+		return instance.Root, true
+	}
+	if strings.HasPrefix(importPath, instance.ModulePath+"/") {
+		return filepath.Join(instance.Root, filepath.FromSlash(strings.TrimPrefix(importPath, instance.ModulePath+"/"))), true
+	}
+	return "", false
+}
+
+func (instance *Loader) Import(path string) (*types.Package, error) {
+	return instance.ImportFrom(path, instance.Root, 0)
+}
+
+func (instance *Loader) ImportFrom(path string, dir string, mode types.ImportMode) (*types.Package, error) {
+	if local, ok := instance.dirFor(path); ok {
+		pkg, err := instance.Load(local)
+		if err != nil {
+			return nil, err
+		}
+		return pkg.Types, nil
+	}
+	return instance.external.ImportFrom(path, dir, mode)
+}
+
+// Load type-checks the package in dir without its test files. Results are
+// cached, so packages imported by several others are only checked once.
+func (instance *Loader) Load(dir string) (*Package, error) {
+	if pkg, ok := instance.packages[dir]; ok {
+		return pkg, nil
+	}
+	if instance.loading[dir] {
+		return nil, fmt.Errorf("import cycle through %s", dir)
+	}
+	instance.loading[dir] = true
+	defer delete(instance.loading, dir)
+	buildPackage, err := instance.context.ImportDir(dir, 0)
+	if err != nil {
+		return nil, fmt.Errorf("no Go package in %s: %w", dir, err)
+	}
+	pkg, err := instance.check(dir, instance.ImportPath(dir), buildPackage.GoFiles)
+	if err != nil {
+		return nil, err
+	}
+	instance.packages[dir] = pkg
+	return pkg, nil
+}
+
+// LoadWithTests type-checks the package in dir together with its in-package
+// test files, and the external _test package when there is one. Results are
+// cached like Load.
+func (instance *Loader) LoadWithTests(dir string) ([]*Package, error) {
+	if packages, ok := instance.tested[dir]; ok {
+		return packages, nil
+	}
+	buildPackage, err := instance.context.ImportDir(dir, 0)
+	if err != nil {
+		return nil, fmt.Errorf("no Go package in %s: %w", dir, err)
+	}
+	if len(buildPackage.TestGoFiles) == 0 && len(buildPackage.XTestGoFiles) == 0 {
+		pkg, err := instance.Load(dir)
+		if err != nil {
+			return nil, err
+		}
+		return []*Package{pkg}, nil
+	}
+	path := instance.ImportPath(dir)
+	files := append(append([]string{}, buildPackage.GoFiles...), buildPackage.TestGoFiles...)
+	pkg, err := instance.check(dir, path, files)
+	if err != nil {
+		return nil, err
+	}
+	packages := []*Package{pkg}
+	if len(buildPackage.XTestGoFiles) > 0 {
+		external, err := instance.check(dir, path+"_test", buildPackage.XTestGoFiles)
+		if err != nil {
+			return nil, err
+		}
+		packages = append(packages, external)
+	}
+	instance.tested[dir] = packages
+	return packages, nil
+}
+
+func (instance *Loader) check(dir string, path string, names []string) (*Package, error) {
+	sort.Strings(names)
+	modified := map[string]time.Time{dir: modTime(dir)}
+	files := make([]*ast.File, 0, len(names))
+	var imports []string
+	for _, name := range names {
+		filename := filepath.Join(dir, name)
+		modified[filename] = modTime(filename)
+		file, err := parser.ParseFile(instance.Fset, filename, nil, parser.ParseComments)
+		if file == nil {
+			return nil, err
+		}
+		files = append(files, file)
+		for _, spec := range file.Imports {
+			if imported, err := strconv.Unquote(spec.Path.Value); err == nil {
+				if _, local := instance.dirFor(imported); !local {
+					imports = append(imports, imported)
+				}
+			}
+		}
+	}
+	// 외부 import는 go list 한 번으로 export data를 찾아 둔다
+	instance.external.Prefetch(imports)
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		Implicits:  make(map[ast.Node]types.Object),
+	}
+	config := &types.Config{
+		Importer:    instance,
+		FakeImportC: true,
+		// 일부 import를 찾지 못해도 가능한 만큼 결과를 남긴다
+		Error: func(error) {},
+	}
+	checked, _ := config.Check(path, instance.Fset, files, info)
+	return &Package{Dir: dir, Path: path, Files: files, Types: checked, Info: info, modified: modified}, nil
+}
+
+// PackageDirs lists every directory of the module that contains Go files.
+func (instance *Loader) PackageDirs() ([]string, error) {
+	var dirs []string
+	err := filepath.WalkDir(instance.Root, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		name := entry.Name()
+		if path != instance.Root && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata" || name == "vendor") {
+			return filepath.SkipDir
+		}
+		if path != instance.Root {
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+		}
+		matches, _ := filepath.Glob(filepath.Join(path, "*.go"))
+		if len(matches) > 0 {
+			dirs = append(dirs, path)
+		}
+		return nil
+	})
+	return dirs, err
+}
+
+// Key identifies an object by where it is declared. Packages loaded with
+// and without test files produce different objects for the same
+// declaration, but they share this key.
+func (instance *Loader) Key(object types.Object) string {
+	if object == nil || !object.Pos().IsValid() {
+		return ""
+	}
+	position := instance.Fset.Position(object.Pos())
+	return fmt.Sprintf("%s:%d:%s", position.Filename, position.Offset, object.Name())
+}
+
+func (instance *Loader) Relative(position token.Position) string {
+	if relative, err := filepath.Rel(instance.Root, position.Filename); err == nil && !strings.HasPrefix(relative, "..") {
+		return fmt.Sprintf("%s:%d:%d", relative, position.Line, position.Column)
+	}
+	return position.String()
+}
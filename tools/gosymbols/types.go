@@ -0,0 +1,15 @@
+package gosymbols
+
+type Input struct {
+	Action string `json:"action" jsonschema:"description:One of symbols, definition, references or methods"`
+	Path   string `json:"path" jsonschema:"description:The absolute path of a Go file or package directory"`
+	Symbol string `json:"symbol,omitempty" jsonschema:"description:The identifier to look up, such as Name, Type.Method or pkg.Name. Required for definition, references and methods"`
+	Line   int    `json:"line,omitempty" jsonschema:"description:Optional line in path where symbol appears. Use it for local variables or to pick one of several identifiers with the same name"`
+}
+
+const (
+	ActionSymbols    = "symbols"
+	ActionDefinition = "definition"
+	ActionReferences = "references"
+	ActionMethods    = "methods"
+)
@@ -0,0 +1,126 @@
+package grep
+
+import (
+	"DevCode/tools"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	GrepDescription = `A powerful search tool for file contents written in pure Go\n\nUsage:\n-
+  ALWAYS use Grep for search tasks. NEVER invoke grep or rg as a Bash command.\n- Supports
+  full regex syntax (RE2, e.g. "log.*Error", "function\s+\w+")\n- Filter files with glob
+  parameter (e.g. "*.js", "**/*.tsx") or type parameter (e.g. "go", "py", "rust")\n- Output
+  modes: "content" shows matching lines with line numbers, "files_with_matches" shows only
+  file paths (default), "count" shows match counts\n- Use -A, -B and -C for context lines in
+  content mode and head_limit to limit the output\n- Use -i for case insensitive search and
+  multiline for patterns that span lines\n- Binary files and files ignored by .gitignore are
+  skipped`
+	Name = "Grep"
+
+	DefaultHeadLimit = 250
+)
+
+type Tool struct {
+}
+
+func (*Tool) Name() string {
+	return Name
+}
+
+func (*Tool) Description() string {
+	return GrepDescription
+}
+
+func (instance *Tool) Handler() mcp.ToolHandlerFor[Input, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[Input]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		if input.Pattern == "" {
+			return nil, fmt.Errorf("pattern must not be empty")
+		}
+		if input.OutputMode == "" {
+			input.OutputMode = FilesWithMatches
+		}
+		if input.OutputMode != Content && input.OutputMode != FilesWithMatches && input.OutputMode != Count {
+			return nil, fmt.Errorf("invalid output_mode: %s", input.OutputMode)
+		}
+		root := input.Path
+		if root == "" {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return nil, fmt.Errorf("fail to read working directory: %v", err)
+			}
+			root = cwd
+		}
+		if !filepath.IsAbs(root) {
+			return nil, fmt.Errorf("invalid path format: %s", root)
+		}
+		options, err := NewOptions(input)
+		if err != nil {
+			return nil, err
+		}
+		files, err := CollectFiles(ctx, root, options)
+		if err != nil {
+			return nil, err
+		}
+		results := Search(ctx, files, options)
+		if len(results) == 0 {
+			return tools.TextReturn("No matches found")
+		}
+		limit := input.HeadLimit
+		if limit <= 0 {
+			limit = DefaultHeadLimit
+		}
+		return tools.TextReturn(Format(results, input.OutputMode, limit))
+	}
+}
+
+func Format(results []FileResult, mode string, limit int) string {
+	lines := make([]string, 0, limit)
+	total := 0
+	switch mode {
+	case FilesWithMatches:
+		for _, result := range results {
+			lines = append(lines, result.Path)
+		}
+	case Count:
+		for _, result := range results {
+			lines = append(lines, fmt.Sprintf("%s:%d", result.Path, result.Count))
+			total += result.Count
+		}
+	case Content:
+		for _, result := range results {
+			previous := 0
+			for _, line := range result.Lines {
+				if previous != 0 && line.Number > previous+1 {
+					lines = append(lines, "--")
+				}
+				separator := "-"
+				if line.IsMatch {
+					separator = ":"
+				}
+				lines = append(lines, fmt.Sprintf("%s%s%d%s%s", result.Path, separator, line.Number, separator, line.Text))
+				previous = line.Number
+			}
+		}
+	}
+	omitted := 0
+	if len(lines) > limit {
+		omitted = len(lines) - limit
+		lines = lines[:limit]
+	}
+	var builder strings.Builder
+	builder.WriteString(strings.Join(lines, "\n"))
+	if mode == Count {
+		fmt.Fprintf(&builder, "\n\nFound %d total occurrences across %d files.", total, len(results))
+	}
+	if omitted > 0 {
+		fmt.Fprintf(&builder, "\n\n(%d more lines omitted. Use head_limit or a more specific pattern.)", omitted)
+	}
+	return builder.String()
+}
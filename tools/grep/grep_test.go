@@ -0,0 +1,115 @@
+package grep
+
+import (
+	"DevCode/tools"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTree(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	files := map[string]string{
+		"main.go":         "package main\n\nfunc main() {\n\tHandler()\n}\n",
+		"handler.go":      "package main\n\n// handler\nfunc Handler() {\n\tprintln(\"hi\")\n}\n",
+		"web/app.js":      "function Handler() {}\n",
+		"ignored/skip.go": "func Handler() {}\n",
+		".gitignore":      "ignored/\n",
+		"bin.dat":         "Handler\x00\x01\x02",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return root
+}
+
+func TestGrepFilesWithMatches(t *testing.T) {
+	root := setupTree(t)
+
+	text, err := tools.CallText(context.Background(), &Tool{}, Input{Pattern: "Handler", Path: root})
+	require.NoError(t, err)
+	assert.Contains(t, text, filepath.Join(root, "main.go"))
+	assert.Contains(t, text, filepath.Join(root, "handler.go"))
+	assert.Contains(t, text, filepath.Join(root, "web/app.js"))
+	assert.NotContains(t, text, "skip.go")
+	assert.NotContains(t, text, "bin.dat")
+}
+
+func TestGrepTypeAndGlobFilters(t *testing.T) {
+	root := setupTree(t)
+
+	text, err := tools.CallText(context.Background(), &Tool{}, Input{Pattern: "Handler", Path: root, Type: "js"})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "web/app.js"), text)
+
+	text, err = tools.CallText(context.Background(), &Tool{}, Input{Pattern: "Handler", Path: root, Glob: "*.go"})
+	require.NoError(t, err)
+	assert.NotContains(t, text, "app.js")
+	assert.Contains(t, text, "main.go")
+}
+
+func TestGrepContentWithContext(t *testing.T) {
+	root := setupTree(t)
+
+	text, err := tools.CallText(context.Background(), &Tool{}, Input{Pattern: "^func Handler", Path: filepath.Join(root, "handler.go"), OutputMode: Content, Before: 1, After: 1})
+	require.NoError(t, err)
+	lines := strings.Split(text, "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasSuffix(lines[0], "handler.go-3-// handler"))
+	assert.True(t, strings.HasSuffix(lines[1], "handler.go:4:func Handler() {"))
+	assert.True(t, strings.HasSuffix(lines[2], "handler.go-5-\tprintln(\"hi\")"))
+}
+
+func TestGrepCountAndIgnoreCase(t *testing.T) {
+	root := setupTree(t)
+
+	text, err := tools.CallText(context.Background(), &Tool{}, Input{Pattern: "handler", Path: root, OutputMode: Count, IgnoreCase: true, Type: "go"})
+	require.NoError(t, err)
+	assert.Contains(t, text, filepath.Join(root, "handler.go")+":2")
+	assert.Contains(t, text, filepath.Join(root, "main.go")+":1")
+	assert.Contains(t, text, "Found 3 total occurrences across 2 files.")
+}
+
+func TestGrepMultiline(t *testing.T) {
+	root := setupTree(t)
+
+	text, err := tools.CallText(context.Background(), &Tool{}, Input{Pattern: `func Handler\(\) \{.*?println`, Path: root, OutputMode: Content, Multiline: true})
+	require.NoError(t, err)
+	assert.Contains(t, text, "handler.go:4:func Handler() {")
+	assert.Contains(t, text, "handler.go:5:\tprintln")
+
+	// ^와 $는 파일 전체가 아니라 각 줄의 시작과 끝에 맞는다
+	text, err = tools.CallText(context.Background(), &Tool{}, Input{Pattern: `^func Handler\(\) \{\n\tprintln\("hi"\)$`, Path: root, OutputMode: Content, Multiline: true})
+	require.NoError(t, err)
+	assert.Contains(t, text, "handler.go:4:func Handler() {")
+	assert.Contains(t, text, "handler.go:5:\tprintln")
+	assert.NotContains(t, text, "handler.go:6:")
+}
+
+func TestGrepHeadLimit(t *testing.T) {
+	root := setupTree(t)
+
+	text, err := tools.CallText(context.Background(), &Tool{}, Input{Pattern: "Handler", Path: root, HeadLimit: 1})
+	require.NoError(t, err)
+	assert.Contains(t, text, "more lines omitted")
+}
+
+func TestGrepNoMatchAndErrors(t *testing.T) {
+	root := setupTree(t)
+	text, err := tools.CallText(context.Background(), &Tool{}, Input{Pattern: "nothing-here", Path: root})
+	require.NoError(t, err)
+	assert.Equal(t, "No matches found", text)
+
+	_, err = tools.Call(context.Background(), &Tool{}, Input{Pattern: "(", Path: root})
+	assert.Error(t, err)
+	_, err = tools.Call(context.Background(), &Tool{}, Input{Pattern: "x", Path: root, Type: "unknown"})
+	assert.Error(t, err)
+}
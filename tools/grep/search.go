@@ -0,0 +1,235 @@
+package grep
+
+import (
+	"DevCode/tools/gitignore"
+	"DevCode/tools/pattern"
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	MaxFileSize  = 10 * 1024 * 1024
+	BinaryProbe  = 8000
+	MaxLineWidth = 500
+)
+
+type Options struct {
+	Regexp    *regexp.Regexp
+	Glob      *regexp.Regexp
+	Types     []string
+	Before    int
+	After     int
+	Lines     bool
+	Multiline bool
+	Workers   int
+}
+
+func NewOptions(input Input) (Options, error) {
+	expression := input.Pattern
+	flags := ""
+	if input.IgnoreCase {
+		flags += "i"
+	}
+	// a multiline pattern runs over the whole file, so ^ and $ are made to
+	// match at line boundaries as they do in line mode
+	if input.Multiline {
+		flags += "sm"
+	}
+	if flags != "" {
+		expression = "(?" + flags + ")" + expression
+	}
+	compiled, err := regexp.Compile(expression)
+	if err != nil {
+		return Options{}, fmt.Errorf("invalid regex pattern: %v", err)
+	}
+	options := Options{
+		Regexp:    compiled,
+		Before:    max(input.Before, input.Context),
+		After:     max(input.After, input.Context),
+		Lines:     input.OutputMode == Content,
+		Multiline: input.Multiline,
+		Workers:   runtime.NumCPU(),
+	}
+	if input.Glob != "" {
+		glob := input.Glob
+		if !strings.Contains(glob, "/") {
+			glob = "**/" + glob
+		}
+		if options.Glob, err = pattern.Compile(glob); err != nil {
+			return Options{}, fmt.Errorf("invalid glob: %v", err)
+		}
+	}
+	if input.Type != "" {
+		globs, exist := FileTypes[strings.ToLower(input.Type)]
+		if !exist {
+			return Options{}, fmt.Errorf("unknown file type: %s", input.Type)
+		}
+		options.Types = globs
+	}
+	return options, nil
+}
+
+func (instance Options) accept(root string, path string) bool {
+	if instance.Glob != nil {
+		relative, err := filepath.Rel(root, path)
+		if err != nil || !instance.Glob.MatchString(filepath.ToSlash(relative)) {
+			return false
+		}
+	}
+	if len(instance.Types) > 0 {
+		name := filepath.Base(path)
+		for _, glob := range instance.Types {
+			if matched, _ := filepath.Match(glob, name); matched {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func CollectFiles(ctx context.Context, root string, options Options) ([]string, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("path not found: %s", root)
+	}
+	if !info.IsDir() {
+		return []string{root}, nil
+	}
+	files := make([]string, 0, 100)
+	err = gitignore.Walk(ctx, root, gitignore.ForPath(root), func(path string, entry fs.DirEntry) error {
+		if entry.IsDir() || !entry.Type().IsRegular() {
+			return nil
+		}
+		if options.accept(root, path) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func Search(ctx context.Context, files []string, options Options) []FileResult {
+	results := make([]*FileResult, len(files))
+	jobs := make(chan int)
+	var group sync.WaitGroup
+	for range max(options.Workers, 1) {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for index := range jobs {
+				results[index] = SearchFile(files[index], options)
+			}
+		}()
+	}
+	for index := range files {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- index
+	}
+	close(jobs)
+	group.Wait()
+
+	matched := make([]FileResult, 0, len(files))
+	for _, result := range results {
+		if result != nil && result.Count > 0 {
+			matched = append(matched, *result)
+		}
+	}
+	return matched
+}
+
+func SearchFile(path string, options Options) *FileResult {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() > MaxFileSize {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || IsBinary(data) {
+		return nil
+	}
+	result := &FileResult{Path: path}
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	matchedLines := make(map[int]bool)
+	if options.Multiline {
+		content := strings.Join(lines, "\n")
+		offsets := LineOffsets(lines)
+		for _, location := range options.Regexp.FindAllStringIndex(content, -1) {
+			result.Count++
+			first := LineAt(offsets, location[0])
+			last := LineAt(offsets, max(location[1]-1, location[0]))
+			for line := first; line <= last; line++ {
+				matchedLines[line] = true
+			}
+		}
+	} else {
+		for index, line := range lines {
+			if found := options.Regexp.FindAllStringIndex(line, -1); len(found) > 0 {
+				result.Count += len(found)
+				matchedLines[index] = true
+			}
+		}
+	}
+	if !options.Lines || result.Count == 0 {
+		return result
+	}
+	for index, line := range lines {
+		include := matchedLines[index]
+		if !include {
+			for distance := 1; distance <= options.After && !include; distance++ {
+				include = matchedLines[index-distance]
+			}
+			for distance := 1; distance <= options.Before && !include; distance++ {
+				include = matchedLines[index+distance]
+			}
+		}
+		if include {
+			result.Lines = append(result.Lines, Line{Number: index + 1, Text: TruncateLine(line), IsMatch: matchedLines[index]})
+		}
+	}
+	return result
+}
+
+func IsBinary(data []byte) bool {
+	return bytes.IndexByte(data[:min(len(data), BinaryProbe)], 0) >= 0
+}
+
+func LineOffsets(lines []string) []int {
+	offsets := make([]int, len(lines))
+	offset := 0
+	for index, line := range lines {
+		offsets[index] = offset
+		offset += len(line) + 1
+	}
+	return offsets
+}
+
+func LineAt(offsets []int, position int) int {
+	return sort.Search(len(offsets), func(index int) bool {
+		return offsets[index] > position
+	}) - 1
+}
+
+func TruncateLine(line string) string {
+	if len(line) <= MaxLineWidth {
+		return line
+	}
+	return strings.ToValidUTF8(line[:MaxLineWidth], "") + "... [truncated]"
+}
@@ -0,0 +1,60 @@
+package grep
+
+type Input struct {
+	Pattern    string `json:"pattern" jsonschema:"description:The regular expression pattern to search for in file contents"`
+	Path       string `json:"path,omitempty" jsonschema:"description:File or directory to search in (absolute path). Defaults to current working directory"`
+	Glob       string `json:"glob,omitempty" jsonschema:"description:Glob pattern to filter files (e.g. *.js, **/*.{ts,tsx})"`
+	Type       string `json:"type,omitempty" jsonschema:"description:File type to search (e.g. go, js, py, rust). More efficient than glob for standard file types"`
+	OutputMode string `json:"output_mode,omitempty" jsonschema:"description:Output mode: content shows matching lines, files_with_matches shows file paths (default), count shows match counts"`
+	IgnoreCase bool   `json:"-i,omitempty" jsonschema:"description:Case insensitive search"`
+	After      int    `json:"-A,omitempty" jsonschema:"description:Number of lines to show after each match. Requires output_mode content"`
+	Before     int    `json:"-B,omitempty" jsonschema:"description:Number of lines to show before each match. Requires output_mode content"`
+	Context    int    `json:"-C,omitempty" jsonschema:"description:Number of lines to show before and after each match. Requires output_mode content"`
+	Multiline  bool   `json:"multiline,omitempty" jsonschema:"description:Enable multiline mode where . matches newlines, ^ and $ match at the start and end of each line, and patterns can span lines"`
+	HeadLimit  int    `json:"head_limit,omitempty" jsonschema:"description:Limit output to first N lines or entries"`
+}
+
+type FileResult struct {
+	Path  string
+	Count int
+	Lines []Line
+}
+
+type Line struct {
+	Number  int
+	Text    string
+	IsMatch bool
+}
+
+const (
+	Content          = "content"
+	FilesWithMatches = "files_with_matches"
+	Count            = "count"
+)
+
+var FileTypes = map[string][]string{
+	"c":        {"*.c", "*.h"},
+	"cpp":      {"*.cpp", "*.cc", "*.cxx", "*.hpp", "*.hh", "*.hxx", "*.h"},
+	"cs":       {"*.cs"},
+	"css":      {"*.css", "*.scss", "*.sass", "*.less"},
+	"go":       {"*.go"},
+	"html":     {"*.html", "*.htm"},
+	"java":     {"*.java"},
+	"js":       {"*.js", "*.jsx", "*.mjs", "*.cjs"},
+	"json":     {"*.json"},
+	"kotlin":   {"*.kt", "*.kts"},
+	"md":       {"*.md", "*.markdown"},
+	"php":      {"*.php"},
+	"py":       {"*.py", "*.pyi"},
+	"rb":       {"*.rb"},
+	"rust":     {"*.rs"},
+	"sh":       {"*.sh", "*.bash", "*.zsh"},
+	"sql":      {"*.sql"},
+	"swift":    {"*.swift"},
+	"toml":     {"*.toml"},
+	"ts":       {"*.ts", "*.tsx", "*.mts", "*.cts"},
+	"yaml":     {"*.yaml", "*.yml"},
+	"xml":      {"*.xml"},
+	"proto":    {"*.proto"},
+	"makefile": {"Makefile", "makefile", "*.mk"},
+}
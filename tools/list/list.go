@@ -2,6 +2,7 @@ package list
 
 import (
 	"DevCode/tools"
+	"DevCode/tools/gitignore"
 	"context"
 	"fmt"
 	"os"
@@ -13,12 +14,19 @@ import (
 
 const (
 	LsDescription = `Lists files and directories in a given path. The path parameter
-  must be an absolute path, not a relative path. You can optionally provide an array
-  of glob patterns to ignore with the ignore parameter. You should generally prefer
-  the Glob and Grep tools, if you know which directories to search.`
+  must be an absolute path, not a relative path. Files ignored by .gitignore and the .git,
+  node_modules and vendor directories are skipped. Use max_depth to limit how deep the
+  listing goes, ignore to skip more paths with gitignore-style patterns, and sizes to show
+  file sizes. At most max_entries entries are shown. You should generally prefer the Glob
+  and Grep tools, if you know which directories to search.`
 	Name = "List"
+
+	DefaultMaxEntries = 500
 )
 
+// DefaultIgnore is skipped in addition to the .gitignore rules.
+var DefaultIgnore = []string{"node_modules/", "vendor/"}
+
 type Tool struct {
 }
 
@@ -46,20 +54,48 @@ func (instance *Tool) Handler() mcp.ToolHandlerFor[Input, any] {
 			}
 			return nil, fmt.Errorf("invalid path : %s", input.Path)
 		}
-		result := input.Path + "/\n" + instance.Helper(input.Path, input.Ignore)
-		return tools.TextReturn(result)
+		if input.MaxDepth < 0 {
+			return nil, fmt.Errorf("max_depth must not be negative: %d", input.MaxDepth)
+		}
+		if input.MaxEntries <= 0 {
+			input.MaxEntries = DefaultMaxEntries
+		}
+		result, err := instance.Helper(ctx, input.Path, input)
+		if err != nil {
+			return nil, err
+		}
+		return tools.TextReturn(input.Path + "/\n" + result)
 	}
 }
 
-func (instance *Tool) Helper(dir string, ignore []string) string {
+// Helper lists dir as an indented tree. Directories at input.MaxDepth are not
+// opened. Once input.MaxEntries entries are shown no more directories are
+// opened either, so the omitted count covers only what was already read and
+// is reported as a lower bound when something was left unread.
+func (instance *Tool) Helper(ctx context.Context, dir string, input Input) (string, error) {
+	matcher := gitignore.ForPath(dir)
+	matcher.AddPatterns(dir, DefaultIgnore...)
+	matcher.AddPatterns(dir, input.Ignore...)
 	visited := make(map[string]bool, 10)
 	stack := make([]*Dir, 0, 10)
 	stack = append(stack, &Dir{Path: dir, Depth: 0, Index: 0})
 	indent := ""
+	shown := 0
+	omitted := 0
+	unread := false
+	collapsed := false
 	var current *Dir
 	var builder strings.Builder
 	for len(stack) > 0 {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
 		current = stack[len(stack)-1]
+		if current.Children == nil && shown >= input.MaxEntries {
+			unread = true
+			stack = stack[:len(stack)-1]
+			continue
+		}
 		if current.Children == nil {
 			realPath, err := filepath.EvalSymlinks(current.Path)
 			if err != nil {
@@ -75,6 +111,7 @@ func (instance *Tool) Helper(dir string, ignore []string) string {
 				stack = stack[:len(stack)-1]
 				continue
 			}
+			matcher.Load(current.Path)
 			current.Children = datas
 		}
 		if current.Index >= len(current.Children) {
@@ -83,29 +120,61 @@ func (instance *Tool) Helper(dir string, ignore []string) string {
 		}
 		child := current.Children[current.Index]
 		current.Index++
-		if instance.ShouldIgnore(child.Name(), ignore) {
+		path := filepath.Join(current.Path, child.Name())
+		if matcher.Match(path, child.IsDir()) {
 			continue
 		}
-		indent = strings.Repeat(" ", current.Depth+1)
-		if child.IsDir() {
-			builder.WriteString(fmt.Sprintf("%s- %s/\n", indent, child.Name()))
+		expand := child.IsDir() && (input.MaxDepth == 0 || current.Depth+1 < input.MaxDepth)
+		if shown >= input.MaxEntries {
+			omitted++
+			unread = unread || expand
+			continue
+		}
+		if child.IsDir() && !expand {
+			collapsed = true
+		}
+		if expand {
 			stack = append(stack, &Dir{
-				Path:  filepath.Join(current.Path, child.Name()),
+				Path:  path,
 				Depth: current.Depth + 1,
 				Index: 0,
 			})
+		}
+		shown++
+		indent = strings.Repeat(" ", current.Depth+1)
+		if child.IsDir() {
+			builder.WriteString(fmt.Sprintf("%s- %s/\n", indent, child.Name()))
+		} else if info, err := child.Info(); input.Sizes && err == nil {
+			builder.WriteString(fmt.Sprintf("%s- %s (%s)\n", indent, child.Name(), FormatSize(info.Size())))
 		} else {
 			builder.WriteString(fmt.Sprintf("%s- %s\n", indent, child.Name()))
 		}
 	}
-	return builder.String()
+	if omitted > 0 || unread {
+		more := fmt.Sprintf("%d", omitted)
+		if unread {
+			more += "+"
+		}
+		builder.WriteString(fmt.Sprintf("(%s more entries omitted. Use max_depth, ignore or a more specific path to narrow the listing.)\n", more))
+	}
+	if collapsed {
+		builder.WriteString(fmt.Sprintf("(Directories deeper than max_depth %d are not expanded.)\n", input.MaxDepth))
+	}
+	return builder.String(), nil
 }
 
-func (*Tool) ShouldIgnore(name string, patterns []string) bool {
-	for _, pattern := range patterns {
-		if matched, _ := filepath.Match(pattern, name); matched {
-			return true
+// FormatSize writes a byte count with a binary unit, such as 512 B or 1.5 KB.
+func FormatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size) / unit
+	for _, suffix := range []string{"KB", "MB", "GB"} {
+		if value < unit {
+			return fmt.Sprintf("%.1f %s", value, suffix)
 		}
+		value /= unit
 	}
-	return false
+	return fmt.Sprintf("%.1f TB", value)
 }
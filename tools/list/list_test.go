@@ -0,0 +1,155 @@
+package list
+
+import (
+	"DevCode/tools"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func createTree(t *testing.T) string {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".git", "objects"), 0755))
+	writeFile(t, filepath.Join(root, ".gitignore"), "*.log\nbuild/\n")
+	writeFile(t, filepath.Join(root, "main.go"), "package main\n")
+	writeFile(t, filepath.Join(root, "debug.log"), "log")
+	writeFile(t, filepath.Join(root, "build", "app"), "binary")
+	writeFile(t, filepath.Join(root, "node_modules", "lib", "index.js"), "")
+	writeFile(t, filepath.Join(root, "vendor", "mod", "a.go"), "")
+	writeFile(t, filepath.Join(root, "pkg", "store", "store.go"), strings.Repeat("a", 1536))
+	writeFile(t, filepath.Join(root, "pkg", "store", "testdata", "case.txt"), "")
+	writeFile(t, filepath.Join(root, "docs", "store", "index.md"), "")
+	return root
+}
+
+func TestListGitignore(t *testing.T) {
+	root := createTree(t)
+
+	text, err := tools.CallText(context.Background(), &Tool{}, Input{Path: root})
+	require.NoError(t, err)
+	lines := strings.Split(text, "\n")
+	assert.Equal(t, root+"/", lines[0])
+	assert.Contains(t, text, " - main.go\n")
+	assert.Contains(t, text, "  - store/\n")
+	assert.Contains(t, text, "   - store.go\n")
+	// .git, node_modules, vendor와 .gitignore에 있는 항목은 빠진다
+	for _, name := range []string{".git/", "node_modules", "vendor", "debug.log", "build/"} {
+		assert.NotContains(t, text, name)
+	}
+	assert.Contains(t, text, ".gitignore")
+}
+
+func TestListIgnoreRelativePath(t *testing.T) {
+	root := createTree(t)
+
+	// 슬래시가 있는 패턴은 상대 경로 전체에 맞춘다
+	text, err := tools.CallText(context.Background(), &Tool{}, Input{Path: root, Ignore: []string{"pkg/store"}})
+	require.NoError(t, err)
+	assert.NotContains(t, text, "store.go")
+	assert.Contains(t, text, "index.md")
+
+	// 슬래시가 없는 패턴은 모든 깊이의 이름에 맞춘다
+	text, err = tools.CallText(context.Background(), &Tool{}, Input{Path: root, Ignore: []string{"store"}})
+	require.NoError(t, err)
+	assert.NotContains(t, text, "store")
+
+	text, err = tools.CallText(context.Background(), &Tool{}, Input{Path: root, Ignore: []string{"**/testdata/**"}})
+	require.NoError(t, err)
+	assert.Contains(t, text, "testdata/")
+	assert.NotContains(t, text, "case.txt")
+}
+
+func TestListMaxDepth(t *testing.T) {
+	root := createTree(t)
+
+	text, err := tools.CallText(context.Background(), &Tool{}, Input{Path: root, MaxDepth: 1})
+	require.NoError(t, err)
+	assert.Contains(t, text, " - pkg/\n")
+	assert.NotContains(t, text, "store")
+	assert.Contains(t, text, "(Directories deeper than max_depth 1 are not expanded.)")
+
+	text, err = tools.CallText(context.Background(), &Tool{}, Input{Path: root, MaxDepth: 2})
+	require.NoError(t, err)
+	assert.Contains(t, text, "  - store/\n")
+	assert.NotContains(t, text, "store.go")
+
+	_, err = tools.CallText(context.Background(), &Tool{}, Input{Path: root, MaxDepth: -1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "max_depth must not be negative")
+}
+
+func TestListMaxEntries(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"a.go", "b.go", "c.go", "d.go", "e.go"} {
+		writeFile(t, filepath.Join(root, name), "")
+	}
+
+	text, err := tools.CallText(context.Background(), &Tool{}, Input{Path: root, MaxEntries: 2})
+	require.NoError(t, err)
+	assert.Equal(t, root+"/\n - a.go\n - b.go\n(3 more entries omitted. Use max_depth, ignore or a more specific path to narrow the listing.)\n", text)
+}
+
+func TestListMaxEntriesStopsReading(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "a", "one.go"), "")
+	writeFile(t, filepath.Join(root, "b", "two.go"), "")
+	writeFile(t, filepath.Join(root, "c.go"), "")
+
+	// 상한에 닿은 뒤에는 하위 디렉터리를 더 읽지 않고 하한으로 알린다
+	text, err := tools.CallText(context.Background(), &Tool{}, Input{Path: root, MaxEntries: 2})
+	require.NoError(t, err)
+	assert.Equal(t, root+"/\n - a/\n  - one.go\n(2+ more entries omitted. Use max_depth, ignore or a more specific path to narrow the listing.)\n", text)
+
+	text, err = tools.CallText(context.Background(), &Tool{}, Input{Path: root, MaxEntries: 1})
+	require.NoError(t, err)
+	assert.Equal(t, root+"/\n - a/\n(2+ more entries omitted. Use max_depth, ignore or a more specific path to narrow the listing.)\n", text)
+}
+
+func TestListSizes(t *testing.T) {
+	root := createTree(t)
+
+	text, err := tools.CallText(context.Background(), &Tool{}, Input{Path: root, Sizes: true})
+	require.NoError(t, err)
+	assert.Contains(t, text, "   - store.go (1.5 KB)\n")
+	assert.Contains(t, text, " - main.go (13 B)\n")
+	assert.Contains(t, text, " - pkg/\n")
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		size     int64
+		expected string
+	}{
+		{size: 0, expected: "0 B"},
+		{size: 1023, expected: "1023 B"},
+		{size: 1024, expected: "1.0 KB"},
+		{size: 5 * 1024 * 1024, expected: "5.0 MB"},
+		{size: 3 * 1024 * 1024 * 1024, expected: "3.0 GB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatSize(tt.size))
+		})
+	}
+}
+
+func TestListInvalidPath(t *testing.T) {
+	_, err := tools.CallText(context.Background(), &Tool{}, Input{Path: "relative"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid Path")
+
+	_, err = tools.CallText(context.Background(), &Tool{}, Input{Path: filepath.Join(t.TempDir(), "missing")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "directory not found")
+}
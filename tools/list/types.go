@@ -3,8 +3,11 @@ package list
 import "os"
 
 type Input struct {
-	Path   string   `json:"path" jsonschema:"description:The absolute path to the directory to list (must be absolute, not relative)"`
-	Ignore []string `json:"ignore,omitempty" jsonschema:"description:List of glob patterns to ignore"`
+	Path       string   `json:"path" jsonschema:"description:The absolute path to the directory to list (must be absolute, not relative)"`
+	Ignore     []string `json:"ignore,omitempty" jsonschema:"description:List of gitignore-style patterns to ignore, matched against paths relative to path. Patterns without a slash match names at any depth (e.g. *.log), patterns with a slash match the relative path (e.g. docs/generated/**)"`
+	MaxDepth   int      `json:"max_depth,omitempty" jsonschema:"description:How many directory levels to list. 1 lists only the direct children of path. Defaults to no limit"`
+	MaxEntries int      `json:"max_entries,omitempty" jsonschema:"description:The maximum number of entries to show (default 500)"`
+	Sizes      bool     `json:"sizes,omitempty" jsonschema:"description:Show the size of every file (default false)"`
 }
 
 type Dir struct {
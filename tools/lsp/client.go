@@ -0,0 +1,354 @@
+package lsp
+
+import (
+	"DevCode/config"
+	"DevCode/tools/bash"
+	"DevCode/tools/edit"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DiagnosticsSettle is how long a document must stay quiet after a
+	// publish before its diagnostics are considered complete.
+	DiagnosticsSettle = 200 * time.Millisecond
+	ShutdownTimeout   = 2 * time.Second
+	stderrLimit       = 4096
+)
+
+type document struct {
+	version int
+	content string
+}
+
+type diagnosticsEntry struct {
+	items    []Diagnostic
+	received int
+}
+
+// Client is one running language server process.
+type Client struct {
+	config      config.LspServerConfig
+	root        string
+	cmd         *exec.Cmd
+	conn        *Conn
+	stderr      *bash.OutputBuffer
+	exited      chan struct{}
+	documents   map[string]*document
+	diagnostics map[string]*diagnosticsEntry
+	updated     chan struct{}
+	mutex       sync.Mutex
+}
+
+func StartClient(ctx context.Context, server config.LspServerConfig, root string) (*Client, error) {
+	client := &Client{
+		config:      server,
+		root:        root,
+		stderr:      bash.NewOutputBuffer(stderrLimit),
+		exited:      make(chan struct{}),
+		documents:   make(map[string]*document),
+		diagnostics: make(map[string]*diagnosticsEntry),
+		updated:     make(chan struct{}),
+	}
+	cmd := exec.Command(server.Command, server.Args...)
+	cmd.Dir = root
+	cmd.Env = os.Environ()
+	for key, value := range server.Env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+	cmd.Stderr = client.stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("fail to start language server %s: %w", server.Name, err)
+	}
+	client.cmd = cmd
+	client.conn = NewConn(stdout, stdin, client)
+	go func() {
+		cmd.Wait()
+		close(client.exited)
+	}()
+
+	params := map[string]any{
+		"processId": os.Getpid(),
+		"rootUri":   FileURI(root),
+		"workspaceFolders": []map[string]string{
+			{"uri": FileURI(root), "name": server.Name},
+		},
+		"capabilities": map[string]any{
+			"general": map[string]any{"positionEncodings": []string{"utf-16"}},
+			"textDocument": map[string]any{
+				"synchronization":    map[string]any{"didSave": true},
+				"publishDiagnostics": map[string]any{"versionSupport": true},
+				"hover":              map[string]any{"contentFormat": []string{"markdown", "plaintext"}},
+				"definition":         map[string]any{"linkSupport": true},
+				"references":         map[string]any{},
+			},
+			"workspace": map[string]any{"workspaceFolders": true, "configuration": true},
+		},
+	}
+	if server.InitOptions != nil {
+		params["initializationOptions"] = server.InitOptions
+	}
+	if err := client.conn.Call(ctx, "initialize", params, nil); err != nil {
+		client.Kill()
+		return nil, client.explain(fmt.Errorf("fail to initialize language server %s: %w", server.Name, err))
+	}
+	if err := client.conn.Notify("initialized", map[string]any{}); err != nil {
+		client.Kill()
+		return nil, client.explain(err)
+	}
+	return client, nil
+}
+
+func (instance *Client) Name() string {
+	return instance.config.Name
+}
+
+func (instance *Client) Alive() bool {
+	select {
+	case <-instance.exited:
+		return false
+	case <-instance.conn.Done():
+		return false
+	default:
+		return true
+	}
+}
+
+// explain adds the tail of the server's stderr to errors caused by a crash.
+func (instance *Client) explain(err error) error {
+	output := strings.TrimSpace(instance.stderr.String())
+	if output == "" {
+		return err
+	}
+	return fmt.Errorf("%w\nstderr: %s", err, output)
+}
+
+func (instance *Client) Notify(method string, params json.RawMessage) {
+	if method != "textDocument/publishDiagnostics" {
+		return
+	}
+	var published PublishDiagnosticsParams
+	if err := json.Unmarshal(params, &published); err != nil {
+		return
+	}
+	instance.mutex.Lock()
+	entry, ok := instance.diagnostics[published.URI]
+	if !ok {
+		entry = &diagnosticsEntry{}
+		instance.diagnostics[published.URI] = entry
+	}
+	entry.items = published.Diagnostics
+	entry.received++
+	close(instance.updated)
+	instance.updated = make(chan struct{})
+	instance.mutex.Unlock()
+}
+
+// Request answers the few requests servers send to clients. Configuration
+// requests get one null per item so that servers fall back to defaults.
+func (instance *Client) Request(method string, params json.RawMessage) (any, error) {
+	switch method {
+	case "workspace/configuration":
+		var request struct {
+			Items []json.RawMessage `json:"items"`
+		}
+		json.Unmarshal(params, &request)
+		return make([]any, len(request.Items)), nil
+	case "workspace/workspaceFolders":
+		return []map[string]string{{"uri": FileURI(instance.root), "name": instance.config.Name}}, nil
+	case "window/workDoneProgress/create", "client/registerCapability", "client/unregisterCapability", "window/showMessageRequest":
+		return nil, nil
+	}
+	return nil, fmt.Errorf("method not supported: %s", method)
+}
+
+// Sync makes the server's copy of path match the file on disk, opening the
+// document the first time. Every open document is checked as well, so edits
+// made by other tools are picked up before each request. It returns how many
+// diagnostics publishes had been seen for path before any change was sent.
+func (instance *Client) Sync(path string) (int, bool, error) {
+	instance.mutex.Lock()
+	paths := make([]string, 0, len(instance.documents)+1)
+	for open := range instance.documents {
+		if open != path {
+			paths = append(paths, open)
+		}
+	}
+	instance.mutex.Unlock()
+	paths = append(paths, path)
+
+	received, changed := 0, false
+	for _, current := range paths {
+		before := instance.received(FileURI(current))
+		updated, err := instance.syncDocument(current, current == path)
+		if err != nil {
+			return 0, false, err
+		}
+		if current == path {
+			received, changed = before, updated
+		}
+	}
+	return received, changed, nil
+}
+
+func (instance *Client) syncDocument(path string, required bool) (bool, error) {
+	uri := FileURI(path)
+	content, _, err := edit.ReadFile(path)
+	instance.mutex.Lock()
+	current, open := instance.documents[path]
+	instance.mutex.Unlock()
+	if err != nil {
+		if !open {
+			if required {
+				return false, err
+			}
+			return false, nil
+		}
+		instance.mutex.Lock()
+		delete(instance.documents, path)
+		instance.mutex.Unlock()
+		if required {
+			return false, err
+		}
+		return true, instance.conn.Notify("textDocument/didClose", map[string]any{"textDocument": TextDocumentIdentifier{URI: uri}})
+	}
+	if !open {
+		instance.mutex.Lock()
+		instance.documents[path] = &document{version: 1, content: content}
+		instance.mutex.Unlock()
+		return true, instance.conn.Notify("textDocument/didOpen", map[string]any{
+			"textDocument": TextDocumentItem{URI: uri, LanguageID: instance.config.LanguageID, Version: 1, Text: content},
+		})
+	}
+	if current.content == content {
+		return false, nil
+	}
+	instance.mutex.Lock()
+	current.version++
+	current.content = content
+	version := current.version
+	instance.mutex.Unlock()
+	if err := instance.conn.Notify("textDocument/didChange", map[string]any{
+		"textDocument":   VersionedTextDocumentIdentifier{URI: uri, Version: version},
+		"contentChanges": []map[string]string{{"text": content}},
+	}); err != nil {
+		return true, err
+	}
+	return true, instance.conn.Notify("textDocument/didSave", map[string]any{"textDocument": TextDocumentIdentifier{URI: uri}})
+}
+
+func (instance *Client) received(uri string) int {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	if entry, ok := instance.diagnostics[uri]; ok {
+		return entry.received
+	}
+	return 0
+}
+
+// Diagnostics waits for the server to publish diagnostics for path after
+// publish number after, then for DiagnosticsSettle without further
+// publishes. When the server stays silent until timeout, the last known
+// diagnostics are returned.
+func (instance *Client) Diagnostics(ctx context.Context, path string, after int, timeout time.Duration) ([]Diagnostic, bool) {
+	uri := FileURI(path)
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	var settle <-chan time.Time
+	for {
+		instance.mutex.Lock()
+		received := 0
+		if entry, ok := instance.diagnostics[uri]; ok {
+			received = entry.received
+		}
+		updated := instance.updated
+		instance.mutex.Unlock()
+		if received > after && settle == nil {
+			settle = time.After(DiagnosticsSettle)
+		}
+		select {
+		case <-updated:
+			if instance.received(uri) > after {
+				settle = time.After(DiagnosticsSettle)
+			}
+		case <-settle:
+			return instance.lastDiagnostics(uri), true
+		case <-deadline.C:
+			return instance.lastDiagnostics(uri), instance.received(uri) > 0
+		case <-ctx.Done():
+			return instance.lastDiagnostics(uri), false
+		case <-instance.conn.Done():
+			return instance.lastDiagnostics(uri), false
+		}
+	}
+}
+
+func (instance *Client) lastDiagnostics(uri string) []Diagnostic {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	if entry, ok := instance.diagnostics[uri]; ok {
+		return append([]Diagnostic{}, entry.items...)
+	}
+	return nil
+}
+
+// Content returns the text the server was last given for path.
+func (instance *Client) Content(path string) (string, bool) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	if current, ok := instance.documents[path]; ok {
+		return current.content, true
+	}
+	return "", false
+}
+
+func (instance *Client) Call(ctx context.Context, method string, params any, result any) error {
+	if err := instance.conn.Call(ctx, method, params, result); err != nil {
+		if !instance.Alive() {
+			return instance.Stopped()
+		}
+		return err
+	}
+	return nil
+}
+
+func (instance *Client) Stopped() error {
+	return instance.explain(fmt.Errorf("language server %s stopped: %w", instance.config.Name, ErrClosed))
+}
+
+// Shutdown asks the server to exit and kills it when it does not.
+func (instance *Client) Shutdown() {
+	if instance.Alive() {
+		ctx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+		defer cancel()
+		if err := instance.conn.Call(ctx, "shutdown", nil, nil); err == nil {
+			instance.conn.Notify("exit", nil)
+		}
+	}
+	select {
+	case <-instance.exited:
+	case <-time.After(ShutdownTimeout):
+		instance.Kill()
+	}
+}
+
+func (instance *Client) Kill() {
+	if instance.cmd != nil && instance.cmd.Process != nil {
+		instance.cmd.Process.Kill()
+	}
+	<-instance.exited
+}
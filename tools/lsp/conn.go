@@ -0,0 +1,234 @@
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrClosed is returned for requests that were pending or sent after the
+// connection to the language server was lost.
+var ErrClosed = errors.New("language server connection closed")
+
+type Message struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  json.RawMessage  `json:"result,omitempty"`
+	Error   *ResponseError   `json:"error,omitempty"`
+}
+
+type ResponseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (instance *ResponseError) Error() string {
+	return fmt.Sprintf("%s (code %d)", instance.Message, instance.Code)
+}
+
+// ReadMessage reads one message framed with a Content-Length header.
+func ReadMessage(reader *bufio.Reader) (*Message, error) {
+	header, err := textproto.NewReader(reader).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+	length, err := strconv.Atoi(strings.TrimSpace(header.Get("Content-Length")))
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("invalid Content-Length header: %q", header.Get("Content-Length"))
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(reader, body); err != nil {
+		return nil, err
+	}
+	message := &Message{}
+	if err := json.Unmarshal(body, message); err != nil {
+		return nil, fmt.Errorf("invalid message: %w", err)
+	}
+	return message, nil
+}
+
+func WriteMessage(writer io.Writer, message *Message) error {
+	message.JSONRPC = "2.0"
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(writer, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = writer.Write(body)
+	return err
+}
+
+// Handler receives notifications and requests sent by the server. For
+// requests, the returned value or error becomes the response.
+type Handler interface {
+	Notify(method string, params json.RawMessage)
+	Request(method string, params json.RawMessage) (any, error)
+}
+
+type Conn struct {
+	writer     io.Writer
+	writeMutex sync.Mutex
+	handler    Handler
+	pending    map[int64]chan *Message
+	nextID     int64
+	mutex      sync.Mutex
+	closed     chan struct{}
+}
+
+func NewConn(reader io.Reader, writer io.Writer, handler Handler) *Conn {
+	conn := &Conn{
+		writer:  writer,
+		handler: handler,
+		pending: make(map[int64]chan *Message),
+		closed:  make(chan struct{}),
+	}
+	go conn.read(bufio.NewReader(reader))
+	return conn
+}
+
+func (instance *Conn) read(reader *bufio.Reader) {
+	for {
+		message, err := ReadMessage(reader)
+		if err != nil {
+			instance.close()
+			return
+		}
+		switch {
+		case message.Method != "" && message.ID != nil:
+			go instance.reply(message)
+		case message.Method != "":
+			instance.handler.Notify(message.Method, message.Params)
+		case message.ID != nil:
+			id, err := strconv.ParseInt(string(*message.ID), 10, 64)
+			if err != nil {
+				continue
+			}
+			instance.mutex.Lock()
+			response, ok := instance.pending[id]
+			delete(instance.pending, id)
+			instance.mutex.Unlock()
+			if ok {
+				response <- message
+			}
+		}
+	}
+}
+
+func (instance *Conn) reply(request *Message) {
+	result, err := instance.handler.Request(request.Method, request.Params)
+	response := &Message{ID: request.ID}
+	if err != nil {
+		response.Error = &ResponseError{Code: -32601, Message: err.Error()}
+	} else {
+		data, err := json.Marshal(result)
+		if err != nil {
+			response.Error = &ResponseError{Code: -32603, Message: err.Error()}
+		} else {
+			response.Result = data
+		}
+	}
+	instance.write(response)
+}
+
+func (instance *Conn) close() {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	select {
+	case <-instance.closed:
+		return
+	default:
+	}
+	close(instance.closed)
+	for id, response := range instance.pending {
+		close(response)
+		delete(instance.pending, id)
+	}
+}
+
+func (instance *Conn) write(message *Message) error {
+	instance.writeMutex.Lock()
+	defer instance.writeMutex.Unlock()
+	return WriteMessage(instance.writer, message)
+}
+
+// Call sends a request and decodes its result into result, which may be nil.
+func (instance *Conn) Call(ctx context.Context, method string, params any, result any) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	instance.mutex.Lock()
+	select {
+	case <-instance.closed:
+		instance.mutex.Unlock()
+		return ErrClosed
+	default:
+	}
+	instance.nextID++
+	id := instance.nextID
+	response := make(chan *Message, 1)
+	instance.pending[id] = response
+	instance.mutex.Unlock()
+
+	raw := json.RawMessage(strconv.FormatInt(id, 10))
+	if err := instance.write(&Message{ID: &raw, Method: method, Params: data}); err != nil {
+		instance.forget(id)
+		return fmt.Errorf("%w: %v", ErrClosed, err)
+	}
+	select {
+	case message, ok := <-response:
+		if !ok {
+			return ErrClosed
+		}
+		if message.Error != nil {
+			return message.Error
+		}
+		if result == nil || len(message.Result) == 0 {
+			return nil
+		}
+		return json.Unmarshal(message.Result, result)
+	case <-ctx.Done():
+		instance.forget(id)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%s timed out", method)
+		}
+		return ctx.Err()
+	}
+}
+
+func (instance *Conn) forget(id int64) {
+	instance.mutex.Lock()
+	delete(instance.pending, id)
+	instance.mutex.Unlock()
+}
+
+func (instance *Conn) Notify(method string, params any) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	select {
+	case <-instance.closed:
+		return ErrClosed
+	default:
+	}
+	if err := instance.write(&Message{Method: method, Params: data}); err != nil {
+		return fmt.Errorf("%w: %v", ErrClosed, err)
+	}
+	return nil
+}
+
+func (instance *Conn) Done() <-chan struct{} {
+	return instance.closed
+}
@@ -0,0 +1,65 @@
+package lsp
+
+import (
+	"DevCode/tools"
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	DefinitionDescription = `Finds where a symbol is defined using the file's language server.\n\nUsage:\n-
+  The path parameter must be an absolute path to a source file whose extension has a
+  language server configured\n- Give the line of a place where the symbol is used, and
+  either the symbol name or its column. Line and column numbers are 1-based, as shown by
+  the Read tool\n- Works across files and packages, including dependencies and the
+  standard library`
+	DefinitionName = "GoToDefinition"
+)
+
+func NewDefinitionTool(manager *Manager) *DefinitionTool {
+	return &DefinitionTool{manager: manager}
+}
+
+type DefinitionTool struct {
+	manager *Manager
+}
+
+func (*DefinitionTool) Name() string {
+	return DefinitionName
+}
+
+func (*DefinitionTool) Description() string {
+	return DefinitionDescription
+}
+
+func (instance *DefinitionTool) Handler() mcp.ToolHandlerFor[PositionInput, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[PositionInput]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		if err := checkPath(input.Path); err != nil {
+			return nil, err
+		}
+		var output string
+		err := instance.manager.Do(ctx, input.Path, func(ctx context.Context, client *Client, received int, changed bool) error {
+			request, err := position(client, input.Path, input.Line, input.Column, input.Symbol)
+			if err != nil {
+				return err
+			}
+			found, err := locations(ctx, client, "textDocument/definition", request)
+			if err != nil {
+				return err
+			}
+			if len(found) == 0 {
+				output = fmt.Sprintf("No definition found at %s:%d", instance.manager.Relative(input.Path), input.Line)
+				return nil
+			}
+			output = "Defined at:\n" + FormatLocations(instance.manager, client, found)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return tools.TextReturn(output)
+	}
+}
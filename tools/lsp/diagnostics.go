@@ -0,0 +1,109 @@
+package lsp
+
+import (
+	"DevCode/tools"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	DiagnosticsDescription = `Reports compiler errors and warnings for a file from its language
+  server.\n\nUsage:\n- The path parameter must be an absolute path to a source file whose
+  extension has a language server configured, for example .go with gopls\n- The server
+  sees the current contents of the file on disk, so run this after editing to check that
+  the code still compiles\n- Each line has the form file:line:column: severity: message`
+	DiagnosticsName = "LspDiagnostics"
+)
+
+func NewDiagnosticsTool(manager *Manager) *DiagnosticsTool {
+	return &DiagnosticsTool{manager: manager}
+}
+
+type DiagnosticsTool struct {
+	manager *Manager
+}
+
+func (*DiagnosticsTool) Name() string {
+	return DiagnosticsName
+}
+
+func (*DiagnosticsTool) Description() string {
+	return DiagnosticsDescription
+}
+
+func (instance *DiagnosticsTool) Handler() mcp.ToolHandlerFor[DiagnosticsInput, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[DiagnosticsInput]) (*mcp.CallToolResultFor[any], error) {
+		path := params.Arguments.Path
+		if err := checkPath(path); err != nil {
+			return nil, err
+		}
+		var output string
+		err := instance.manager.Do(ctx, path, func(ctx context.Context, client *Client, received int, changed bool) error {
+			after := received
+			if !changed && received > 0 {
+				// 바뀐 것이 없으면 마지막으로 받은 진단을 그대로 쓴다
+				after = received - 1
+			}
+			diagnostics, complete := client.Diagnostics(ctx, path, after, instance.manager.DiagnosticsTimeout())
+			if !client.Alive() {
+				return client.Stopped()
+			}
+			output = FormatDiagnostics(instance.manager.Relative(path), client, path, diagnostics, complete)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return tools.TextReturn(output)
+	}
+}
+
+func FormatDiagnostics(name string, client *Client, path string, diagnostics []Diagnostic, complete bool) string {
+	if len(diagnostics) == 0 {
+		if !complete {
+			return fmt.Sprintf("No diagnostics reported for %s (the language server did not answer in time; the result may be incomplete)", name)
+		}
+		return fmt.Sprintf("No diagnostics reported for %s", name)
+	}
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		if diagnostics[i].Range.Start.Line != diagnostics[j].Range.Start.Line {
+			return diagnostics[i].Range.Start.Line < diagnostics[j].Range.Start.Line
+		}
+		return diagnostics[i].Range.Start.Character < diagnostics[j].Range.Start.Character
+	})
+	content, _ := client.Content(path)
+	lines := splitLines(content)
+	counts := make(map[string]int)
+	var builder strings.Builder
+	for _, diagnostic := range diagnostics {
+		severity := SeverityName(diagnostic.Severity)
+		counts[severity]++
+		start := diagnostic.Range.Start
+		column := start.Character + 1
+		if start.Line < len(lines) {
+			column = FromUTF16(lines[start.Line], start.Character) + 1
+		}
+		fmt.Fprintf(&builder, "\n%s:%d:%d: %s: %s", name, start.Line+1, column, severity, strings.TrimSpace(diagnostic.Message))
+		var details []string
+		if diagnostic.Source != "" {
+			details = append(details, diagnostic.Source)
+		}
+		if diagnostic.Code != nil {
+			details = append(details, fmt.Sprint(diagnostic.Code))
+		}
+		if len(details) > 0 {
+			fmt.Fprintf(&builder, " [%s]", strings.Join(details, " "))
+		}
+	}
+	var summary []string
+	for _, severity := range []string{"error", "warning", "info", "hint"} {
+		if counts[severity] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[severity], severity))
+		}
+	}
+	return fmt.Sprintf("%s: %s%s", name, strings.Join(summary, ", "), builder.String())
+}
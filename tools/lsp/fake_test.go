@@ -0,0 +1,145 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"strings"
+	"unicode/utf16"
+)
+
+// 테스트 바이너리를 가짜 language server로 실행할 때 쓰는 환경 변수
+const (
+	fakeServerEnv = "DEVCODE_FAKE_LSP"
+	fakeLogEnv    = "DEVCODE_FAKE_LSP_LOG"
+)
+
+// runFakeServer is a tiny language server over stdio. It reports an error
+// for every line containing BROKEN, resolves definitions to "func <name>"
+// lines, finds references by whole word and exits when asked to hover over
+// "crash".
+func runFakeServer() {
+	if path := os.Getenv(fakeLogEnv); path != "" {
+		file, _ := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		file.WriteString("start\n")
+		file.Close()
+	}
+	reader := bufio.NewReader(os.Stdin)
+	documents := make(map[string]string)
+	reply := func(request *Message, result any) {
+		data, _ := json.Marshal(result)
+		WriteMessage(os.Stdout, &Message{ID: request.ID, Result: data})
+	}
+	publish := func(uri string, version int) {
+		var diagnostics []Diagnostic
+		for index, line := range splitLines(documents[uri]) {
+			if column := strings.Index(line, "BROKEN"); column >= 0 {
+				start := utf16Length(line[:column])
+				diagnostics = append(diagnostics, Diagnostic{
+					Range:    Range{Start: Position{Line: index, Character: start}, End: Position{Line: index, Character: start + 6}},
+					Severity: SeverityError,
+					Source:   "fake",
+					Message:  "undefined: BROKEN",
+				})
+			}
+		}
+		params, _ := json.Marshal(PublishDiagnosticsParams{URI: uri, Version: &version, Diagnostics: diagnostics})
+		WriteMessage(os.Stdout, &Message{Method: "textDocument/publishDiagnostics", Params: params})
+	}
+	for {
+		message, err := ReadMessage(reader)
+		if err != nil {
+			return
+		}
+		switch message.Method {
+		case "initialize":
+			reply(message, map[string]any{"capabilities": map[string]any{"textDocumentSync": 1, "hoverProvider": true}})
+		case "initialized":
+			// 클라이언트가 서버 요청에 응답하는지 확인
+			id := json.RawMessage(`"config-1"`)
+			params, _ := json.Marshal(map[string]any{"items": []map[string]string{{"section": "fake"}}})
+			WriteMessage(os.Stdout, &Message{ID: &id, Method: "workspace/configuration", Params: params})
+		case "textDocument/didOpen":
+			var params struct {
+				TextDocument TextDocumentItem `json:"textDocument"`
+			}
+			json.Unmarshal(message.Params, &params)
+			documents[params.TextDocument.URI] = params.TextDocument.Text
+			publish(params.TextDocument.URI, params.TextDocument.Version)
+		case "textDocument/didChange":
+			var params struct {
+				TextDocument   VersionedTextDocumentIdentifier `json:"textDocument"`
+				ContentChanges []struct {
+					Text string `json:"text"`
+				} `json:"contentChanges"`
+			}
+			json.Unmarshal(message.Params, &params)
+			documents[params.TextDocument.URI] = params.ContentChanges[len(params.ContentChanges)-1].Text
+			publish(params.TextDocument.URI, params.TextDocument.Version)
+		case "textDocument/definition", "textDocument/references", "textDocument/hover":
+			var params ReferenceParams
+			json.Unmarshal(message.Params, &params)
+			word := wordAt(documents[params.TextDocument.URI], params.Position)
+			switch message.Method {
+			case "textDocument/hover":
+				if word == "crash" {
+					os.Exit(3)
+				}
+				if word == "" {
+					reply(message, nil)
+					continue
+				}
+				reply(message, Hover{Contents: json.RawMessage(`{"kind":"markdown","value":"` + "```go\\nfunc " + word + "()\\n```" + `"}`)})
+			case "textDocument/definition":
+				var found []LocationLink
+				for uri, text := range documents {
+					for index, line := range splitLines(text) {
+						if column := strings.Index(line, "func "+word+"("); column >= 0 {
+							start := utf16Length(line[:column+5])
+							target := Range{Start: Position{Line: index, Character: start}, End: Position{Line: index, Character: start + utf16Length(word)}}
+							found = append(found, LocationLink{TargetURI: uri, TargetRange: target, TargetSelectionRange: target})
+						}
+					}
+				}
+				reply(message, found)
+			case "textDocument/references":
+				var found []Location
+				for uri, text := range documents {
+					for index, line := range splitLines(text) {
+						if column := findWord(line, word); column >= 0 {
+							isDeclaration := strings.Contains(line, "func "+word+"(")
+							if isDeclaration && !params.Context.IncludeDeclaration {
+								continue
+							}
+							start := utf16Length(line[:column])
+							found = append(found, Location{URI: uri, Range: Range{Start: Position{Line: index, Character: start}}})
+						}
+					}
+				}
+				reply(message, found)
+			}
+		case "shutdown":
+			reply(message, nil)
+		case "exit":
+			os.Exit(0)
+		}
+	}
+}
+
+func utf16Length(text string) int {
+	return len(utf16.Encode([]rune(text)))
+}
+
+func wordAt(content string, position Position) string {
+	lines := splitLines(content)
+	if position.Line >= len(lines) {
+		return ""
+	}
+	runes := []rune(lines[position.Line])
+	start := FromUTF16(lines[position.Line], position.Character)
+	end := start
+	for end < len(runes) && (runes[end] == '_' || ('a' <= runes[end] && runes[end] <= 'z') || ('A' <= runes[end] && runes[end] <= 'Z') || ('0' <= runes[end] && runes[end] <= '9')) {
+		end++
+	}
+	return string(runes[start:end])
+}
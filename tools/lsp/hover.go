@@ -0,0 +1,66 @@
+package lsp
+
+import (
+	"DevCode/tools"
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	HoverDescription = `Shows the type, signature and documentation of a symbol using the
+  file's language server.\n\nUsage:\n- The path parameter must be an absolute path to a
+  source file whose extension has a language server configured\n- Give the line where the
+  symbol appears, and either the symbol name or its column. Line and column numbers are
+  1-based, as shown by the Read tool\n- Use this to learn what a function expects or
+  returns without opening its definition`
+	HoverName = "Hover"
+)
+
+func NewHoverTool(manager *Manager) *HoverTool {
+	return &HoverTool{manager: manager}
+}
+
+type HoverTool struct {
+	manager *Manager
+}
+
+func (*HoverTool) Name() string {
+	return HoverName
+}
+
+func (*HoverTool) Description() string {
+	return HoverDescription
+}
+
+func (instance *HoverTool) Handler() mcp.ToolHandlerFor[PositionInput, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[PositionInput]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		if err := checkPath(input.Path); err != nil {
+			return nil, err
+		}
+		var output string
+		err := instance.manager.Do(ctx, input.Path, func(ctx context.Context, client *Client, received int, changed bool) error {
+			request, err := position(client, input.Path, input.Line, input.Column, input.Symbol)
+			if err != nil {
+				return err
+			}
+			var hover *Hover
+			if err := client.Call(ctx, "textDocument/hover", request, &hover); err != nil {
+				return err
+			}
+			if hover != nil {
+				output = HoverText(hover.Contents)
+			}
+			if output == "" {
+				output = fmt.Sprintf("No hover information at %s:%d", instance.manager.Relative(input.Path), input.Line)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return tools.TextReturn(output)
+	}
+}
@@ -0,0 +1,79 @@
+package lsp
+
+import (
+	"DevCode/tools/edit"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// MaxLocations caps how many locations a definition or references result lists.
+const MaxLocations = 200
+
+func checkPath(path string) error {
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("invalid path format: %s", path)
+	}
+	return nil
+}
+
+// position resolves the tool input against the text the server was given.
+func position(client *Client, path string, line int, column int, symbol string) (TextDocumentPositionParams, error) {
+	content, _ := client.Content(path)
+	resolved, err := ResolvePosition(content, line, column, symbol)
+	if err != nil {
+		return TextDocumentPositionParams{}, err
+	}
+	return TextDocumentPositionParams{TextDocument: TextDocumentIdentifier{URI: FileURI(path)}, Position: resolved}, nil
+}
+
+// FormatLocations prints one "file:line:column: source" line per location,
+// with one-based rune columns.
+func FormatLocations(manager *Manager, client *Client, locations []Location) string {
+	sort.SliceStable(locations, func(i, j int) bool {
+		if locations[i].URI != locations[j].URI {
+			return locations[i].URI < locations[j].URI
+		}
+		if locations[i].Range.Start.Line != locations[j].Range.Start.Line {
+			return locations[i].Range.Start.Line < locations[j].Range.Start.Line
+		}
+		return locations[i].Range.Start.Character < locations[j].Range.Start.Character
+	})
+	files := make(map[string][]string)
+	var builder strings.Builder
+	for index, location := range locations {
+		if index == MaxLocations {
+			fmt.Fprintf(&builder, "... %d more not shown\n", len(locations)-MaxLocations)
+			break
+		}
+		path := URIPath(location.URI)
+		lines, ok := files[path]
+		if !ok {
+			content, open := client.Content(path)
+			if !open {
+				content, _, _ = edit.ReadFile(path)
+			}
+			lines = splitLines(content)
+			files[path] = lines
+		}
+		start := location.Range.Start
+		text, column := "", start.Character+1
+		if start.Line < len(lines) {
+			text = lines[start.Line]
+			column = FromUTF16(text, start.Character) + 1
+		}
+		fmt.Fprintf(&builder, "%s:%d:%d: %s\n", manager.Relative(path), start.Line+1, column, strings.TrimSpace(text))
+	}
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+func locations(ctx context.Context, client *Client, method string, params any) ([]Location, error) {
+	var raw json.RawMessage
+	if err := client.Call(ctx, method, params, &raw); err != nil {
+		return nil, err
+	}
+	return ParseLocations(raw), nil
+}
@@ -0,0 +1,209 @@
+package lsp
+
+import (
+	"DevCode/config"
+	"DevCode/tools"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	if os.Getenv(fakeServerEnv) == "1" {
+		runFakeServer()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+const source = "package main\n\nfunc helper() {}\n\nfunc main() {\n\t// 🙂 helper\n\thelper()\n\tBROKEN\n}\n"
+
+func newWorkspace(t *testing.T, maxRestarts int) (*Manager, string, string) {
+	t.Helper()
+	root := t.TempDir()
+	path := filepath.Join(root, "main.fake")
+	require.NoError(t, os.WriteFile(path, []byte(source), 0644))
+	log := filepath.Join(t.TempDir(), "starts.log")
+	lspConfig := config.LspConfig{
+		Servers: []config.LspServerConfig{{
+			Name:       "fake",
+			Command:    os.Args[0],
+			Extensions: []string{"fake"},
+			Env:        map[string]string{fakeServerEnv: "1", fakeLogEnv: log},
+		}},
+		DiagnosticsTimeout: 2000,
+		MaxRestarts:        maxRestarts,
+	}
+	lspConfig.Default()
+	manager := NewManager(lspConfig, root)
+	t.Cleanup(manager.Shutdown)
+	return manager, path, log
+}
+
+func starts(t *testing.T, log string) int {
+	data, err := os.ReadFile(log)
+	require.NoError(t, err)
+	return strings.Count(string(data), "start\n")
+}
+
+func TestDiagnostics(t *testing.T) {
+	manager, path, _ := newWorkspace(t, 3)
+	handler := NewDiagnosticsTool(manager).Handler()
+
+	result, err := handler(context.Background(), nil, &mcp.CallToolParamsFor[DiagnosticsInput]{Arguments: DiagnosticsInput{Path: path}})
+	require.NoError(t, err)
+	assert.Equal(t, "main.fake: 1 error\nmain.fake:8:2: error: undefined: BROKEN [fake]", tools.Text(result))
+
+	// 파일을 고치면 다음 호출에서 서버에 변경이 전달된다
+	require.NoError(t, os.WriteFile(path, []byte(strings.Replace(source, "\tBROKEN\n", "", 1)), 0644))
+	result, err = handler(context.Background(), nil, &mcp.CallToolParamsFor[DiagnosticsInput]{Arguments: DiagnosticsInput{Path: path}})
+	require.NoError(t, err)
+	assert.Equal(t, "No diagnostics reported for main.fake", tools.Text(result))
+}
+
+func TestDefinition(t *testing.T) {
+	manager, path, _ := newWorkspace(t, 3)
+	handler := NewDefinitionTool(manager).Handler()
+
+	result, err := handler(context.Background(), nil, &mcp.CallToolParamsFor[PositionInput]{Arguments: PositionInput{Path: path, Line: 7, Symbol: "helper"}})
+	require.NoError(t, err)
+	assert.Equal(t, "Defined at:\nmain.fake:3:6: func helper() {}", tools.Text(result))
+
+	// 이모지 뒤의 열도 UTF-16으로 바꿔서 보낸다
+	result, err = handler(context.Background(), nil, &mcp.CallToolParamsFor[PositionInput]{Arguments: PositionInput{Path: path, Line: 6, Column: 7}})
+	require.NoError(t, err)
+	assert.Equal(t, "Defined at:\nmain.fake:3:6: func helper() {}", tools.Text(result))
+
+	// 편집된 내용도 반영된다
+	updated := strings.Replace(source, "func helper() {}\n", "\n\nfunc helper() {}\n", 1)
+	require.NoError(t, os.WriteFile(path, []byte(updated), 0644))
+	result, err = handler(context.Background(), nil, &mcp.CallToolParamsFor[PositionInput]{Arguments: PositionInput{Path: path, Line: 9, Symbol: "helper"}})
+	require.NoError(t, err)
+	assert.Equal(t, "Defined at:\nmain.fake:5:6: func helper() {}", tools.Text(result))
+
+	result, err = handler(context.Background(), nil, &mcp.CallToolParamsFor[PositionInput]{Arguments: PositionInput{Path: path, Line: 1, Symbol: "package"}})
+	require.NoError(t, err)
+	assert.Equal(t, "No definition found at main.fake:1", tools.Text(result))
+
+	_, err = handler(context.Background(), nil, &mcp.CallToolParamsFor[PositionInput]{Arguments: PositionInput{Path: path, Line: 9, Symbol: "missing"}})
+	assert.ErrorContains(t, err, `symbol "missing" not found on line 9`)
+}
+
+func TestReferences(t *testing.T) {
+	manager, path, _ := newWorkspace(t, 3)
+	handler := NewReferencesTool(manager).Handler()
+
+	result, err := handler(context.Background(), nil, &mcp.CallToolParamsFor[ReferencesInput]{Arguments: ReferencesInput{Path: path, Line: 3, Symbol: "helper"}})
+	require.NoError(t, err)
+	assert.Equal(t, "2 references:\nmain.fake:6:7: // 🙂 helper\nmain.fake:7:2: helper()", tools.Text(result))
+
+	result, err = handler(context.Background(), nil, &mcp.CallToolParamsFor[ReferencesInput]{Arguments: ReferencesInput{Path: path, Line: 3, Symbol: "helper", IncludeDeclaration: true}})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(tools.Text(result), "3 references:\nmain.fake:3:6: func helper() {}\n"))
+}
+
+func TestHover(t *testing.T) {
+	manager, path, _ := newWorkspace(t, 3)
+	handler := NewHoverTool(manager).Handler()
+
+	result, err := handler(context.Background(), nil, &mcp.CallToolParamsFor[PositionInput]{Arguments: PositionInput{Path: path, Line: 7, Symbol: "helper"}})
+	require.NoError(t, err)
+	assert.Equal(t, "```go\nfunc helper()\n```", tools.Text(result))
+
+	result, err = handler(context.Background(), nil, &mcp.CallToolParamsFor[PositionInput]{Arguments: PositionInput{Path: path, Line: 2, Column: 1}})
+	require.NoError(t, err)
+	assert.Equal(t, "No hover information at main.fake:2", tools.Text(result))
+}
+
+func TestRestartAfterCrash(t *testing.T) {
+	manager, path, log := newWorkspace(t, 3)
+	handler := NewHoverTool(manager).Handler()
+	require.NoError(t, os.WriteFile(path, []byte(source+"// crash\n"), 0644))
+
+	_, err := handler(context.Background(), nil, &mcp.CallToolParamsFor[PositionInput]{Arguments: PositionInput{Path: path, Line: 10, Symbol: "crash"}})
+	assert.ErrorContains(t, err, "language server fake stopped")
+	// 요청 중에 죽으면 한 번 다시 시작해서 재시도한다
+	assert.Equal(t, 2, starts(t, log))
+
+	result, err := handler(context.Background(), nil, &mcp.CallToolParamsFor[PositionInput]{Arguments: PositionInput{Path: path, Line: 7, Symbol: "helper"}})
+	require.NoError(t, err)
+	assert.Equal(t, "```go\nfunc helper()\n```", tools.Text(result))
+	assert.Equal(t, 3, starts(t, log))
+}
+
+func TestRestartLimit(t *testing.T) {
+	manager, path, log := newWorkspace(t, 1)
+	handler := NewHoverTool(manager).Handler()
+	require.NoError(t, os.WriteFile(path, []byte(source+"// crash\n"), 0644))
+
+	_, err := handler(context.Background(), nil, &mcp.CallToolParamsFor[PositionInput]{Arguments: PositionInput{Path: path, Line: 10, Symbol: "crash"}})
+	assert.ErrorContains(t, err, "language server fake stopped")
+
+	_, err = handler(context.Background(), nil, &mcp.CallToolParamsFor[PositionInput]{Arguments: PositionInput{Path: path, Line: 7, Symbol: "helper"}})
+	assert.ErrorContains(t, err, "language server fake crashed 2 times and will not be restarted")
+	assert.Equal(t, 2, starts(t, log))
+}
+
+func TestErrors(t *testing.T) {
+	manager, path, _ := newWorkspace(t, 3)
+	handler := NewDiagnosticsTool(manager).Handler()
+
+	_, err := handler(context.Background(), nil, &mcp.CallToolParamsFor[DiagnosticsInput]{Arguments: DiagnosticsInput{Path: "main.fake"}})
+	assert.ErrorContains(t, err, "invalid path format")
+
+	_, err = handler(context.Background(), nil, &mcp.CallToolParamsFor[DiagnosticsInput]{Arguments: DiagnosticsInput{Path: filepath.Join(filepath.Dir(path), "notes.txt")}})
+	assert.ErrorContains(t, err, "no language server configured for .txt files")
+
+	_, err = handler(context.Background(), nil, &mcp.CallToolParamsFor[DiagnosticsInput]{Arguments: DiagnosticsInput{Path: filepath.Join(filepath.Dir(path), "missing.fake")}})
+	assert.Error(t, err)
+
+	broken := NewManager(config.LspConfig{
+		Servers:        []config.LspServerConfig{{Name: "nothing", Command: "/nonexistent/language-server", Extensions: []string{".fake"}}},
+		RequestTimeout: 1000,
+	}, filepath.Dir(path))
+	_, err = NewDiagnosticsTool(broken).Handler()(context.Background(), nil, &mcp.CallToolParamsFor[DiagnosticsInput]{Arguments: DiagnosticsInput{Path: path}})
+	assert.ErrorContains(t, err, "fail to start language server nothing")
+}
+
+func TestResolvePosition(t *testing.T) {
+	content := "a := 1\n// 🙂 value\n"
+
+	position, err := ResolvePosition(content, 2, 0, "value")
+	require.NoError(t, err)
+	assert.Equal(t, Position{Line: 1, Character: 6}, position)
+
+	position, err = ResolvePosition(content, 2, 6, "")
+	require.NoError(t, err)
+	assert.Equal(t, Position{Line: 1, Character: 6}, position)
+	assert.Equal(t, 5, FromUTF16("// 🙂 value", 6))
+
+	_, err = ResolvePosition(content, 5, 1, "")
+	assert.ErrorContains(t, err, "line 5 is out of range (file has 3 lines)")
+	_, err = ResolvePosition(content, 1, 0, "")
+	assert.ErrorContains(t, err, "either column or symbol is required")
+	_, err = ResolvePosition(content, 1, 0, "b")
+	assert.ErrorContains(t, err, `symbol "b" not found on line 1`)
+}
+
+func TestParseResults(t *testing.T) {
+	assert.Nil(t, ParseLocations(json.RawMessage("null")))
+	assert.Equal(t, []Location{{URI: "file:///a.go"}}, ParseLocations(json.RawMessage(`{"uri":"file:///a.go","range":{"start":{"line":0,"character":0},"end":{"line":0,"character":0}}}`)))
+	assert.Len(t, ParseLocations(json.RawMessage(`[{"uri":"file:///a.go"},{"uri":"file:///b.go"}]`)), 2)
+	links := ParseLocations(json.RawMessage(`[{"targetUri":"file:///c.go","targetSelectionRange":{"start":{"line":4,"character":2},"end":{"line":4,"character":5}}}]`))
+	require.Len(t, links, 1)
+	assert.Equal(t, "file:///c.go", links[0].URI)
+	assert.Equal(t, 4, links[0].Range.Start.Line)
+
+	assert.Equal(t, "plain", HoverText(json.RawMessage(`"plain"`)))
+	assert.Equal(t, "**doc**", HoverText(json.RawMessage(`{"kind":"markdown","value":"**doc**"}`)))
+	assert.Equal(t, "```go\nfunc f()\n```\n\ntext", HoverText(json.RawMessage(`[{"language":"go","value":"func f()"},"text"]`)))
+
+	assert.Equal(t, "/tmp/a b.go", URIPath(FileURI("/tmp/a b.go")))
+}
@@ -0,0 +1,142 @@
+package lsp
+
+import (
+	"DevCode/config"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Manager starts language servers on demand, one per configured server, and
+// restarts a server that crashed the next time it is needed.
+type Manager struct {
+	config   config.LspConfig
+	root     string
+	clients  map[string]*Client
+	restarts map[string]int
+	mutex    sync.Mutex
+}
+
+func NewManager(config config.LspConfig, root string) *Manager {
+	return &Manager{
+		config:   config,
+		root:     root,
+		clients:  make(map[string]*Client),
+		restarts: make(map[string]int),
+	}
+}
+
+func (instance *Manager) Root() string {
+	return instance.root
+}
+
+func (instance *Manager) RequestTimeout() time.Duration {
+	return time.Duration(instance.config.RequestTimeout) * time.Millisecond
+}
+
+func (instance *Manager) DiagnosticsTimeout() time.Duration {
+	return time.Duration(instance.config.DiagnosticsTimeout) * time.Millisecond
+}
+
+// Server returns the configuration of the server handling path's extension.
+func (instance *Manager) Server(path string) (config.LspServerConfig, error) {
+	extension := strings.ToLower(filepath.Ext(path))
+	for _, server := range instance.config.Servers {
+		for _, candidate := range server.Extensions {
+			if strings.ToLower(candidate) == extension {
+				return server, nil
+			}
+		}
+	}
+	if extension == "" {
+		return config.LspServerConfig{}, fmt.Errorf("no language server configured for %s", filepath.Base(path))
+	}
+	return config.LspServerConfig{}, fmt.Errorf("no language server configured for %s files; add one under [[lsp.servers]] in env.toml", extension)
+}
+
+// Client returns a running client for path, starting or restarting the
+// server as needed.
+func (instance *Manager) Client(ctx context.Context, path string) (*Client, error) {
+	server, err := instance.Server(path)
+	if err != nil {
+		return nil, err
+	}
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	if client, ok := instance.clients[server.Name]; ok {
+		if client.Alive() {
+			return client, nil
+		}
+		client.Kill()
+		delete(instance.clients, server.Name)
+		if instance.restarts[server.Name] >= instance.config.MaxRestarts {
+			return nil, client.explain(fmt.Errorf("language server %s crashed %d times and will not be restarted", server.Name, instance.restarts[server.Name]+1))
+		}
+		instance.restarts[server.Name]++
+	}
+	startCtx, cancel := context.WithTimeout(ctx, instance.RequestTimeout())
+	defer cancel()
+	client, err := StartClient(startCtx, server, instance.root)
+	if err != nil {
+		return nil, err
+	}
+	instance.clients[server.Name] = client
+	return client, nil
+}
+
+// Do runs action with a client whose copy of path is in sync with the disk.
+// When the server dies during the action, it is restarted and the action is
+// tried once more.
+func (instance *Manager) Do(ctx context.Context, path string, action func(ctx context.Context, client *Client, received int, changed bool) error) error {
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		var client *Client
+		client, err = instance.Client(ctx, path)
+		if err != nil {
+			return err
+		}
+		requestCtx, cancel := context.WithTimeout(ctx, instance.RequestTimeout())
+		received, changed, syncErr := client.Sync(path)
+		if syncErr != nil {
+			err = syncErr
+		} else {
+			err = action(requestCtx, client, received, changed)
+		}
+		cancel()
+		if err == nil || !errors.Is(err, ErrClosed) {
+			return err
+		}
+	}
+	return err
+}
+
+func (instance *Manager) Shutdown() {
+	instance.mutex.Lock()
+	clients := make([]*Client, 0, len(instance.clients))
+	for name, client := range instance.clients {
+		clients = append(clients, client)
+		delete(instance.clients, name)
+	}
+	instance.mutex.Unlock()
+	var group sync.WaitGroup
+	for _, client := range clients {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			client.Shutdown()
+		}()
+	}
+	group.Wait()
+}
+
+// Relative shortens paths inside the workspace root.
+func (instance *Manager) Relative(path string) string {
+	if relative, err := filepath.Rel(instance.root, path); err == nil && !strings.HasPrefix(relative, "..") {
+		return relative
+	}
+	return path
+}
@@ -0,0 +1,83 @@
+package lsp
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+func splitLines(content string) []string {
+	return strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+}
+
+// ToUTF16 converts a zero-based rune column into a UTF-16 character offset.
+func ToUTF16(line string, column int) int {
+	units := 0
+	for index, r := range []rune(line) {
+		if index >= column {
+			break
+		}
+		units += len(utf16.Encode([]rune{r}))
+	}
+	return units
+}
+
+// FromUTF16 converts a UTF-16 character offset into a zero-based rune column.
+func FromUTF16(line string, character int) int {
+	column, units := 0, 0
+	for _, r := range line {
+		if units >= character {
+			break
+		}
+		units += len(utf16.Encode([]rune{r}))
+		column++
+	}
+	return column
+}
+
+// ResolvePosition turns the one-based line and column of the tool input into
+// an LSP position. Without a column, the first whole-word occurrence of
+// symbol on the line is used.
+func ResolvePosition(content string, line int, column int, symbol string) (Position, error) {
+	lines := splitLines(content)
+	if line < 1 || line > len(lines) {
+		return Position{}, fmt.Errorf("line %d is out of range (file has %d lines)", line, len(lines))
+	}
+	text := lines[line-1]
+	switch {
+	case column > 0:
+		if column > utf8.RuneCountInString(text)+1 {
+			return Position{}, fmt.Errorf("column %d is beyond the end of line %d", column, line)
+		}
+		return Position{Line: line - 1, Character: ToUTF16(text, column-1)}, nil
+	case symbol != "":
+		offset := findWord(text, symbol)
+		if offset < 0 {
+			return Position{}, fmt.Errorf("symbol %q not found on line %d: %s", symbol, line, strings.TrimSpace(text))
+		}
+		return Position{Line: line - 1, Character: ToUTF16(text, utf8.RuneCountInString(text[:offset]))}, nil
+	}
+	return Position{}, fmt.Errorf("either column or symbol is required")
+}
+
+func findWord(text string, word string) int {
+	isWord := func(r rune) bool {
+		return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+	for start := 0; start <= len(text)-len(word); {
+		index := strings.Index(text[start:], word)
+		if index < 0 {
+			return -1
+		}
+		index += start
+		before, _ := utf8.DecodeLastRuneInString(text[:index])
+		after, _ := utf8.DecodeRuneInString(text[index+len(word):])
+		if (index == 0 || !isWord(before)) && (index+len(word) == len(text) || !isWord(after)) {
+			return index
+		}
+		start = index + 1
+	}
+	return -1
+}
@@ -0,0 +1,67 @@
+package lsp
+
+import (
+	"DevCode/tools"
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	ReferencesDescription = `Finds every reference to a symbol using the file's language server.\n\nUsage:\n-
+  The path parameter must be an absolute path to a source file whose extension has a
+  language server configured\n- Give the line where the symbol appears, and either the
+  symbol name or its column. Line and column numbers are 1-based, as shown by the Read
+  tool\n- Set include_declaration to also list the declaration\n- Unlike Grep, this only
+  finds real uses of the symbol, not other identifiers with the same name`
+	ReferencesName = "FindReferences"
+)
+
+func NewReferencesTool(manager *Manager) *ReferencesTool {
+	return &ReferencesTool{manager: manager}
+}
+
+type ReferencesTool struct {
+	manager *Manager
+}
+
+func (*ReferencesTool) Name() string {
+	return ReferencesName
+}
+
+func (*ReferencesTool) Description() string {
+	return ReferencesDescription
+}
+
+func (instance *ReferencesTool) Handler() mcp.ToolHandlerFor[ReferencesInput, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[ReferencesInput]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		if err := checkPath(input.Path); err != nil {
+			return nil, err
+		}
+		var output string
+		err := instance.manager.Do(ctx, input.Path, func(ctx context.Context, client *Client, received int, changed bool) error {
+			position, err := position(client, input.Path, input.Line, input.Column, input.Symbol)
+			if err != nil {
+				return err
+			}
+			request := ReferenceParams{TextDocumentPositionParams: position}
+			request.Context.IncludeDeclaration = input.IncludeDeclaration
+			found, err := locations(ctx, client, "textDocument/references", request)
+			if err != nil {
+				return err
+			}
+			if len(found) == 0 {
+				output = fmt.Sprintf("No references found at %s:%d", instance.manager.Relative(input.Path), input.Line)
+				return nil
+			}
+			output = fmt.Sprintf("%d references:\n%s", len(found), FormatLocations(instance.manager, client, found))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return tools.TextReturn(output)
+	}
+}
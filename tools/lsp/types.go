@@ -0,0 +1,20 @@
+package lsp
+
+type DiagnosticsInput struct {
+	Path string `json:"path" jsonschema:"description:The absolute path to the file to check"`
+}
+
+type PositionInput struct {
+	Path   string `json:"path" jsonschema:"description:The absolute path to the file containing the symbol"`
+	Line   int    `json:"line" jsonschema:"description:The line number (1-based) where the symbol appears"`
+	Column int    `json:"column,omitempty" jsonschema:"description:The column (1-based) of the symbol on the line. Optional when symbol is given"`
+	Symbol string `json:"symbol,omitempty" jsonschema:"description:The identifier to look up. When column is omitted, its first occurrence on the line is used"`
+}
+
+type ReferencesInput struct {
+	Path               string `json:"path" jsonschema:"description:The absolute path to the file containing the symbol"`
+	Line               int    `json:"line" jsonschema:"description:The line number (1-based) where the symbol appears"`
+	Column             int    `json:"column,omitempty" jsonschema:"description:The column (1-based) of the symbol on the line. Optional when symbol is given"`
+	Symbol             string `json:"symbol,omitempty" jsonschema:"description:The identifier to look up. When column is omitted, its first occurrence on the line is used"`
+	IncludeDeclaration bool   `json:"include_declaration,omitempty" jsonschema:"description:Also list the declaration itself. Defaults to false"`
+}
@@ -0,0 +1,33 @@
+package tools
+
+import (
+	"DevCode/types"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// McpModule puts the IDs of the chat tool call in _meta, so a tool that waits
+// on the bus can tag its events with the call they belong to.
+const (
+	MetaRequestID  = "devcode/requestID"
+	MetaToolCallID = "devcode/toolCallID"
+)
+
+// CallIDs reads the request and tool call IDs that McpModule puts in _meta.
+// A call made without them gets fresh IDs so it can still be answered.
+func CallIDs(meta mcp.Meta) (types.RequestID, types.ToolCallID) {
+	requestID := types.NewRequestID()
+	if text, ok := meta[MetaRequestID].(string); ok {
+		if parsed, err := uuid.Parse(text); err == nil {
+			requestID = types.RequestID(parsed)
+		}
+	}
+	toolCallID := types.NewToolCallID()
+	if text, ok := meta[MetaToolCallID].(string); ok {
+		if parsed, err := uuid.Parse(text); err == nil {
+			toolCallID = types.ToolCallID(parsed)
+		}
+	}
+	return requestID, toolCallID
+}
@@ -0,0 +1,25 @@
+package tools
+
+import (
+	"DevCode/types"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallIDs(t *testing.T) {
+	requestID := types.NewRequestID()
+	toolCallID := types.NewToolCallID()
+	gotRequest, gotToolCall := CallIDs(mcp.Meta{
+		MetaRequestID:  requestID.String(),
+		MetaToolCallID: toolCallID.String(),
+	})
+	assert.Equal(t, requestID, gotRequest)
+	assert.Equal(t, toolCallID, gotToolCall)
+
+	// _meta가 없거나 잘못되면 새 ID를 만든다
+	gotRequest, gotToolCall = CallIDs(mcp.Meta{MetaRequestID: "not-a-uuid"})
+	assert.False(t, gotRequest.IsNil())
+	assert.False(t, gotToolCall.IsNil())
+}
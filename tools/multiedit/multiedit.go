@@ -0,0 +1,69 @@
+package multiedit
+
+import (
+	"DevCode/tools"
+	"DevCode/tools/edit"
+	"DevCode/tools/write"
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	MultiEditDescription = `This is a tool for making multiple edits to a single file in one
+  operation. It is built on top of the Edit tool and allows you to perform multiple
+  find-and-replace operations efficiently. Prefer this tool over the Edit tool when you need
+  to make multiple edits to the same file.\n\nUsage:\n- Use the Read tool to understand the
+  file's contents and context before editing\n- The file_path parameter must be an absolute
+  path\n- Each edit in edits has old_string, new_string and optional replace_all, with the
+  same rules as the Edit tool\n- All edits are applied in sequence, in the order they are
+  provided. Each edit operates on the result of the previous edit.\n- All edits must be
+  valid for the operation to succeed - if any edit fails, none will be applied.\n- Plan
+  your edits carefully so that earlier edits do not change the text that later edits are
+  trying to find.`
+	Name = "MultiEdit"
+)
+
+type Tool struct {
+}
+
+func (*Tool) Name() string {
+	return Name
+}
+
+func (*Tool) Description() string {
+	return MultiEditDescription
+}
+
+func (instance *Tool) Handler() mcp.ToolHandlerFor[Input, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[Input]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		if len(input.Edits) == 0 {
+			return nil, fmt.Errorf("edits must contain at least one operation")
+		}
+		content, mode, err := edit.ReadFile(input.FilePath)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := Apply(content, input.Edits)
+		if err != nil {
+			return nil, err
+		}
+		if err := write.WriteFile(input.FilePath, []byte(updated), mode); err != nil {
+			return nil, err
+		}
+		return tools.TextReturn(fmt.Sprintf("Applied %d edits to %s", len(input.Edits), input.FilePath))
+	}
+}
+
+func Apply(content string, operations []Operation) (string, error) {
+	for index, operation := range operations {
+		updated, _, err := edit.Replace(content, operation.OldString, operation.NewString, operation.ReplaceAll)
+		if err != nil {
+			return "", fmt.Errorf("edit %d of %d failed, no changes were applied: %w", index+1, len(operations), err)
+		}
+		content = updated
+	}
+	return content, nil
+}
@@ -0,0 +1,54 @@
+package multiedit
+
+import (
+	"DevCode/tools"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiEditAppliesInSequence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.go")
+	require.NoError(t, os.WriteFile(path, []byte("func Foo() {}\nfunc Bar() { Foo() }\n"), 0644))
+
+	_, err := tools.Call(context.Background(), &Tool{}, Input{FilePath: path, Edits: []Operation{
+		{OldString: "Foo", NewString: "Baz", ReplaceAll: true},
+		{OldString: "Baz()", NewString: "Qux()", ReplaceAll: true},
+		{OldString: "func Bar", NewString: "func Quux"},
+	}})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "func Qux() {}\nfunc Quux() { Qux() }\n", string(data))
+}
+
+func TestMultiEditIsAllOrNothing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.go")
+	original := "alpha\nbeta\n"
+	require.NoError(t, os.WriteFile(path, []byte(original), 0644))
+
+	_, err := tools.Call(context.Background(), &Tool{}, Input{FilePath: path, Edits: []Operation{
+		{OldString: "alpha", NewString: "gamma"},
+		{OldString: "missing", NewString: "value"},
+	}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "edit 2 of 2")
+
+	// 실패 시 파일은 원본 그대로여야 함
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, original, string(data))
+}
+
+func TestMultiEditRequiresEdits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.go")
+	require.NoError(t, os.WriteFile(path, []byte("x"), 0644))
+
+	_, err := tools.Call(context.Background(), &Tool{}, Input{FilePath: path})
+	assert.Error(t, err)
+}
@@ -0,0 +1,12 @@
+package multiedit
+
+type Input struct {
+	FilePath string      `json:"file_path" jsonschema:"description:The absolute path to the file to modify"`
+	Edits    []Operation `json:"edits" jsonschema:"description:Array of edit operations to perform sequentially on the file"`
+}
+
+type Operation struct {
+	OldString  string `json:"old_string" jsonschema:"description:The text to replace"`
+	NewString  string `json:"new_string" jsonschema:"description:The text to replace it with"`
+	ReplaceAll bool   `json:"replace_all,omitempty" jsonschema:"description:Replace all occurences of old_string (default false)"`
+}
@@ -0,0 +1,204 @@
+package notebook
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*[A-Za-z]")
+
+func Load(path string) (*Notebook, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("file not found: %s", path)
+		}
+		if os.IsPermission(err) {
+			return nil, fmt.Errorf("permission denied: %s", path)
+		}
+		return nil, fmt.Errorf("fail to read notebook: %s", path)
+	}
+	return Parse(data)
+}
+
+func Parse(data []byte) (*Notebook, error) {
+	raw := make(map[string]any)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid notebook json: %v", err)
+	}
+	notebook := &Notebook{Raw: raw, Cells: make([]map[string]any, 0)}
+	if cells, ok := raw["cells"].([]any); ok {
+		for index, cell := range cells {
+			typed, ok := cell.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("invalid notebook: cell %d is not an object", index)
+			}
+			notebook.Cells = append(notebook.Cells, typed)
+		}
+	}
+	return notebook, nil
+}
+
+func (instance *Notebook) Marshal() ([]byte, error) {
+	cells := make([]any, 0, len(instance.Cells))
+	for _, cell := range instance.Cells {
+		cells = append(cells, cell)
+	}
+	instance.Raw["cells"] = cells
+	// json.MarshalIndent escapes <, > and &, which would rewrite every cell
+	// that contains them on each save
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", " ")
+	if err := encoder.Encode(instance.Raw); err != nil {
+		return nil, err
+	}
+	data := bytes.TrimRight(buffer.Bytes(), "\n")
+	return append(data, '\n'), nil
+}
+
+func (instance *Notebook) Language() string {
+	if metadata, ok := instance.Raw["metadata"].(map[string]any); ok {
+		if info, ok := metadata["language_info"].(map[string]any); ok {
+			if name, ok := info["name"].(string); ok {
+				return name
+			}
+		}
+	}
+	return "python"
+}
+
+func (instance *Notebook) HasCellIDs() bool {
+	major, _ := instance.Raw["nbformat"].(float64)
+	minor, _ := instance.Raw["nbformat_minor"].(float64)
+	return major > 4 || (major == 4 && minor >= 5)
+}
+
+func (instance *Notebook) FindCell(cellID string) (int, error) {
+	for index, cell := range instance.Cells {
+		if id, ok := cell["id"].(string); ok && id == cellID {
+			return index, nil
+		}
+	}
+	index, err := strconv.Atoi(strings.TrimPrefix(cellID, "cell-"))
+	if err != nil || index < 0 || index >= len(instance.Cells) {
+		return -1, fmt.Errorf("cell not found: %s", cellID)
+	}
+	return index, nil
+}
+
+func CellID(cell map[string]any, index int) string {
+	if id, ok := cell["id"].(string); ok && id != "" {
+		return id
+	}
+	return fmt.Sprintf("cell-%d", index)
+}
+
+func NewCellID() string {
+	buffer := make([]byte, 4)
+	rand.Read(buffer)
+	return hex.EncodeToString(buffer)
+}
+
+func JoinText(value any) string {
+	switch typed := value.(type) {
+	case string:
+		return typed
+	case []any:
+		var builder strings.Builder
+		for _, line := range typed {
+			if text, ok := line.(string); ok {
+				builder.WriteString(text)
+			}
+		}
+		return builder.String()
+	}
+	return ""
+}
+
+func SplitSource(source string) []any {
+	lines := make([]any, 0, strings.Count(source, "\n")+1)
+	for source != "" {
+		index := strings.IndexByte(source, '\n')
+		if index < 0 {
+			lines = append(lines, source)
+			break
+		}
+		lines = append(lines, source[:index+1])
+		source = source[index+1:]
+	}
+	return lines
+}
+
+func Render(notebook *Notebook) string {
+	var builder strings.Builder
+	language := notebook.Language()
+	for index, cell := range notebook.Cells {
+		cellType, _ := cell["cell_type"].(string)
+		fmt.Fprintf(&builder, "<cell id=\"%s\" type=\"%s\"", CellID(cell, index), cellType)
+		if cellType == CodeCell {
+			fmt.Fprintf(&builder, " language=\"%s\"", language)
+			if count, ok := cell["execution_count"].(float64); ok {
+				fmt.Fprintf(&builder, " execution_count=\"%d\"", int(count))
+			}
+		}
+		builder.WriteString(">\n")
+		builder.WriteString(strings.TrimRight(JoinText(cell["source"]), "\n"))
+		builder.WriteString("\n</cell>\n")
+		if outputs, ok := cell["outputs"].([]any); ok && len(outputs) > 0 {
+			builder.WriteString("<outputs>\n")
+			for _, output := range outputs {
+				if typed, ok := output.(map[string]any); ok {
+					builder.WriteString(RenderOutput(typed))
+				}
+			}
+			builder.WriteString("</outputs>\n")
+		}
+	}
+	return builder.String()
+}
+
+func RenderOutput(output map[string]any) string {
+	outputType, _ := output["output_type"].(string)
+	var text string
+	switch outputType {
+	case "stream":
+		text = JoinText(output["text"])
+	case "execute_result", "display_data":
+		data, _ := output["data"].(map[string]any)
+		if plain, ok := data["text/plain"]; ok {
+			text = JoinText(plain)
+		}
+		for mime := range data {
+			if strings.HasPrefix(mime, "image/") {
+				text += fmt.Sprintf("\n[%s output omitted]", mime)
+			}
+		}
+	case "error":
+		name, _ := output["ename"].(string)
+		value, _ := output["evalue"].(string)
+		text = fmt.Sprintf("%s: %s", name, value)
+		if traceback, ok := output["traceback"].([]any); ok {
+			lines := make([]string, 0, len(traceback))
+			for _, line := range traceback {
+				if typed, ok := line.(string); ok {
+					lines = append(lines, typed)
+				}
+			}
+			text += "\n" + strings.Join(lines, "\n")
+		}
+	}
+	text = strings.Trim(ansiPattern.ReplaceAllString(text, ""), "\n")
+	if text == "" {
+		return ""
+	}
+	return text + "\n"
+}
@@ -0,0 +1,145 @@
+package notebook
+
+import (
+	"DevCode/tools"
+	"DevCode/tools/write"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	NotebookEditDescription = `Completely replaces the contents of a specific cell in a Jupyter
+  notebook (.ipynb file) with new source. Jupyter notebooks are interactive documents that
+  combine code, text, and visualizations, commonly used for data analysis and scientific
+  computing. The notebook_path parameter must be an absolute path, not a relative path. The
+  cell_id is the id shown by the Read tool (cell-N refers to the N-th cell, starting at 0).
+  Use edit_mode=insert to add a new cell after the cell specified by cell_id (or at the
+  beginning if cell_id is omitted); cell_type is required when inserting. Use
+  edit_mode=delete to delete the cell specified by cell_id.`
+	Name = "NotebookEdit"
+)
+
+type Tool struct {
+}
+
+func (*Tool) Name() string {
+	return Name
+}
+
+func (*Tool) Description() string {
+	return NotebookEditDescription
+}
+
+func (instance *Tool) Handler() mcp.ToolHandlerFor[Input, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[Input]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		if input.NotebookPath == "" || !filepath.IsAbs(input.NotebookPath) {
+			return nil, fmt.Errorf("invalid path format: %s", input.NotebookPath)
+		}
+		if !strings.EqualFold(filepath.Ext(input.NotebookPath), ".ipynb") {
+			return nil, fmt.Errorf("file must be a Jupyter notebook (.ipynb file): %s", input.NotebookPath)
+		}
+		info, err := os.Stat(input.NotebookPath)
+		if err != nil {
+			return nil, fmt.Errorf("file not found: %s", input.NotebookPath)
+		}
+		notebook, err := Load(input.NotebookPath)
+		if err != nil {
+			return nil, err
+		}
+		message, err := Apply(notebook, input)
+		if err != nil {
+			return nil, err
+		}
+		data, err := notebook.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf("fail to encode notebook: %v", err)
+		}
+		if err := write.WriteFile(input.NotebookPath, data, info.Mode().Perm()); err != nil {
+			return nil, err
+		}
+		return tools.TextReturn(message)
+	}
+}
+
+func Apply(notebook *Notebook, input Input) (string, error) {
+	mode := input.EditMode
+	if mode == "" {
+		mode = Replace
+	}
+	if input.CellType != "" && input.CellType != CodeCell && input.CellType != MarkdownCell && input.CellType != RawCell {
+		return "", fmt.Errorf("invalid cell_type: %s", input.CellType)
+	}
+	switch mode {
+	case Insert:
+		if input.CellType == "" {
+			return "", fmt.Errorf("cell_type is required when using edit_mode=insert")
+		}
+		position := 0
+		if input.CellID != "" {
+			index, err := notebook.FindCell(input.CellID)
+			if err != nil {
+				return "", err
+			}
+			position = index + 1
+		}
+		cell := NewCell(input.CellType, input.NewSource)
+		if notebook.HasCellIDs() {
+			cell["id"] = NewCellID()
+		}
+		notebook.Cells = append(notebook.Cells[:position], append([]map[string]any{cell}, notebook.Cells[position:]...)...)
+		return fmt.Sprintf("Inserted cell %s at position %d", CellID(cell, position), position), nil
+	case Delete:
+		if input.CellID == "" {
+			return "", fmt.Errorf("cell_id is required when using edit_mode=delete")
+		}
+		index, err := notebook.FindCell(input.CellID)
+		if err != nil {
+			return "", err
+		}
+		notebook.Cells = append(notebook.Cells[:index], notebook.Cells[index+1:]...)
+		return fmt.Sprintf("Deleted cell %s", input.CellID), nil
+	case Replace:
+		if input.CellID == "" {
+			return "", fmt.Errorf("cell_id is required when using edit_mode=replace")
+		}
+		index, err := notebook.FindCell(input.CellID)
+		if err != nil {
+			return "", err
+		}
+		cell := notebook.Cells[index]
+		if input.CellType != "" && input.CellType != cell["cell_type"] {
+			replaced := NewCell(input.CellType, input.NewSource)
+			if id, ok := cell["id"]; ok {
+				replaced["id"] = id
+			}
+			notebook.Cells[index] = replaced
+		} else {
+			cell["source"] = SplitSource(input.NewSource)
+			if cell["cell_type"] == CodeCell {
+				cell["outputs"] = []any{}
+				cell["execution_count"] = nil
+			}
+		}
+		return fmt.Sprintf("Updated cell %s", input.CellID), nil
+	}
+	return "", fmt.Errorf("invalid edit_mode: %s", mode)
+}
+
+func NewCell(cellType string, source string) map[string]any {
+	cell := map[string]any{
+		"cell_type": cellType,
+		"metadata":  map[string]any{},
+		"source":    SplitSource(source),
+	}
+	if cellType == CodeCell {
+		cell["outputs"] = []any{}
+		cell["execution_count"] = nil
+	}
+	return cell
+}
@@ -0,0 +1,157 @@
+package notebook
+
+import (
+	"DevCode/tools"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sample = `{
+ "cells": [
+  {
+   "cell_type": "markdown",
+   "id": "intro",
+   "metadata": {},
+   "source": ["# Title\n", "Some text"]
+  },
+  {
+   "cell_type": "code",
+   "execution_count": 3,
+   "id": "calc",
+   "metadata": {},
+   "outputs": [
+    {"name": "stdout", "output_type": "stream", "text": ["hello\n"]},
+    {"data": {"text/plain": ["42"], "image/png": "AAAA"}, "execution_count": 3, "metadata": {}, "output_type": "execute_result"},
+    {"ename": "ValueError", "evalue": "bad", "output_type": "error", "traceback": ["\u001b[0;31mValueError\u001b[0m: bad"]}
+   ],
+   "source": "print('hello')\n40 + 2"
+  }
+ ],
+ "metadata": {"language_info": {"name": "python"}},
+ "nbformat": 4,
+ "nbformat_minor": 5
+}`
+
+func writeNotebook(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "analysis.ipynb")
+	require.NoError(t, os.WriteFile(path, []byte(sample), 0644))
+	return path
+}
+
+func edit(t *testing.T, input Input) error {
+	t.Helper()
+	_, err := tools.Call(context.Background(), &Tool{}, input)
+	return err
+}
+
+func TestRender(t *testing.T) {
+	notebook, err := Parse([]byte(sample))
+	require.NoError(t, err)
+
+	text := Render(notebook)
+	assert.Contains(t, text, "<cell id=\"intro\" type=\"markdown\">\n# Title\nSome text\n</cell>")
+	assert.Contains(t, text, "<cell id=\"calc\" type=\"code\" language=\"python\" execution_count=\"3\">")
+	assert.Contains(t, text, "hello\n")
+	assert.Contains(t, text, "42\n[image/png output omitted]")
+	assert.Contains(t, text, "ValueError: bad")
+	assert.NotContains(t, text, "\x1b")
+}
+
+func TestNotebookEditReplace(t *testing.T) {
+	path := writeNotebook(t)
+
+	require.NoError(t, edit(t, Input{NotebookPath: path, CellID: "calc", NewSource: "1 + 1\nprint(2)"}))
+
+	notebook, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, notebook.Cells, 2)
+	assert.Equal(t, "1 + 1\nprint(2)", JoinText(notebook.Cells[1]["source"]))
+	assert.Empty(t, notebook.Cells[1]["outputs"])
+	assert.Nil(t, notebook.Cells[1]["execution_count"])
+}
+
+func TestNotebookEditInsertAndDelete(t *testing.T) {
+	path := writeNotebook(t)
+
+	require.NoError(t, edit(t, Input{NotebookPath: path, CellID: "cell-0", NewSource: "x = 1", CellType: CodeCell, EditMode: Insert}))
+	notebook, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, notebook.Cells, 3)
+	assert.Equal(t, "x = 1", JoinText(notebook.Cells[1]["source"]))
+	assert.NotEmpty(t, notebook.Cells[1]["id"])
+
+	require.NoError(t, edit(t, Input{NotebookPath: path, CellID: "intro", EditMode: Delete}))
+	notebook, err = Load(path)
+	require.NoError(t, err)
+	require.Len(t, notebook.Cells, 2)
+	assert.Equal(t, CodeCell, notebook.Cells[0]["cell_type"])
+
+	// 결과는 유효한 JSON이어야 함
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, json.Valid(data))
+}
+
+const escaped = `{
+ "cells": [
+  {
+   "cell_type": "markdown",
+   "id": "compare",
+   "metadata": {},
+   "source": [
+    "a < b && c > d\n",
+    "<b>bold</b>"
+   ]
+  },
+  {
+   "cell_type": "code",
+   "execution_count": null,
+   "id": "calc",
+   "metadata": {},
+   "outputs": [],
+   "source": [
+    "x = 1"
+   ]
+  }
+ ],
+ "metadata": {},
+ "nbformat": 4,
+ "nbformat_minor": 5
+}
+`
+
+func TestNotebookEditKeepsHTMLCharacters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "compare.ipynb")
+	require.NoError(t, os.WriteFile(path, []byte(escaped), 0644))
+
+	require.NoError(t, edit(t, Input{NotebookPath: path, CellID: "calc", NewSource: "x = 2"}))
+
+	// <, >, &는 이스케이프되지 않고, 건드리지 않은 cell의 바이트는 그대로
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Replace(escaped, "x = 1", "x = 2", 1), string(data))
+	assert.NotContains(t, string(data), `\u003c`)
+}
+
+func TestNotebookEditErrors(t *testing.T) {
+	path := writeNotebook(t)
+
+	assert.Error(t, edit(t, Input{NotebookPath: path, CellID: "missing", NewSource: "x"}))
+	assert.Error(t, edit(t, Input{NotebookPath: path, NewSource: "x", EditMode: Insert}))
+	assert.Error(t, edit(t, Input{NotebookPath: path, CellID: "calc", EditMode: "move"}))
+	assert.Error(t, edit(t, Input{NotebookPath: "relative.ipynb", CellID: "calc"}))
+}
+
+func TestSplitSource(t *testing.T) {
+	assert.Equal(t, []any{"a\n", "b"}, SplitSource("a\nb"))
+	assert.Equal(t, []any{"a\n"}, SplitSource("a\n"))
+	assert.Empty(t, SplitSource(""))
+}
@@ -0,0 +1,24 @@
+package notebook
+
+type Input struct {
+	NotebookPath string `json:"notebook_path" jsonschema:"description:The absolute path to the Jupyter notebook file to edit (must be absolute, not relative)"`
+	CellID       string `json:"cell_id,omitempty" jsonschema:"description:The ID of the cell to edit, or its index such as cell-3. When inserting, the new cell is inserted after this cell, or at the beginning if not specified"`
+	NewSource    string `json:"new_source,omitempty" jsonschema:"description:The new source for the cell"`
+	CellType     string `json:"cell_type,omitempty" jsonschema:"description:The type of the cell (code or markdown). Required for insert"`
+	EditMode     string `json:"edit_mode,omitempty" jsonschema:"description:The type of edit to make (replace, insert, delete). Defaults to replace"`
+}
+
+type Notebook struct {
+	Raw   map[string]any
+	Cells []map[string]any
+}
+
+const (
+	Replace = "replace"
+	Insert  = "insert"
+	Delete  = "delete"
+
+	CodeCell     = "code"
+	MarkdownCell = "markdown"
+	RawCell      = "raw"
+)
@@ -0,0 +1,113 @@
+package pattern
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+func Compile(glob string) (*regexp.Regexp, error) {
+	expression, err := Translate(glob)
+	if err != nil {
+		return nil, err
+	}
+	return regexp.Compile("^" + expression + "$")
+}
+
+func Match(glob string, path string) bool {
+	compiled, err := Compile(glob)
+	if err != nil {
+		return false
+	}
+	return compiled.MatchString(path)
+}
+
+func Translate(glob string) (string, error) {
+	var builder strings.Builder
+	depth := 0
+	runes := []rune(glob)
+	for index := 0; index < len(runes); index++ {
+		char := runes[index]
+		switch char {
+		case '*':
+			if index+1 < len(runes) && runes[index+1] == '*' {
+				atStart := index == 0 || runes[index-1] == '/'
+				index++
+				if atStart && index+1 < len(runes) && runes[index+1] == '/' {
+					index++
+					builder.WriteString("(?:.*/)?")
+				} else if atStart && index+1 == len(runes) {
+					builder.WriteString(".*")
+				} else {
+					builder.WriteString("[^/]*")
+				}
+			} else {
+				builder.WriteString("[^/]*")
+			}
+		case '?':
+			builder.WriteString("[^/]")
+		case '[':
+			end := index + 1
+			if end < len(runes) && (runes[end] == '!' || runes[end] == '^') {
+				end++
+			}
+			if end < len(runes) && runes[end] == ']' {
+				end++
+			}
+			for end < len(runes) && runes[end] != ']' {
+				end++
+			}
+			if end >= len(runes) {
+				builder.WriteString(`\[`)
+				continue
+			}
+			class := string(runes[index+1 : end])
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			builder.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			index = end
+		case '{':
+			depth++
+			builder.WriteString("(?:")
+		case '}':
+			if depth == 0 {
+				builder.WriteString(`\}`)
+				continue
+			}
+			depth--
+			builder.WriteString(")")
+		case ',':
+			if depth > 0 {
+				builder.WriteString("|")
+			} else {
+				builder.WriteString(",")
+			}
+		case '\\':
+			if index+1 < len(runes) {
+				index++
+				builder.WriteString(regexp.QuoteMeta(string(runes[index])))
+			} else {
+				builder.WriteString(`\\`)
+			}
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+	if depth != 0 {
+		return "", fmt.Errorf("unbalanced braces in pattern: %s", glob)
+	}
+	return builder.String(), nil
+}
+
+func StaticPrefix(glob string) string {
+	segments := strings.Split(glob, "/")
+	prefix := make([]string, 0, len(segments))
+	for _, segment := range segments[:len(segments)-1] {
+		if strings.ContainsAny(segment, `*?[{\`) {
+			break
+		}
+		prefix = append(prefix, segment)
+	}
+	return strings.Join(prefix, "/")
+}
@@ -0,0 +1,51 @@
+package pattern
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		glob     string
+		path     string
+		expected bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "cmd/main.go", false},
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "a/b/c/main.go", true},
+		{"src/**/*.ts", "src/a/b.ts", true},
+		{"src/**/*.ts", "src/b.ts", true},
+		{"src/**/*.ts", "lib/b.ts", false},
+		{"src/**", "src/a/b.ts", true},
+		{"**/*.{go,mod}", "a/go.mod", true},
+		{"**/*.{go,mod}", "a/go.sum", false},
+		{"*_test.go", "x_test.go", true},
+		{"file?.txt", "file1.txt", true},
+		{"file?.txt", "file10.txt", false},
+		{"[abc].txt", "b.txt", true},
+		{"[!abc].txt", "b.txt", false},
+		{"{cmd,internal}/**/*.go", "internal/x/y.go", true},
+		{"a+b(c).txt", "a+b(c).txt", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.glob+" "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Match(tt.glob, tt.path))
+		})
+	}
+}
+
+func TestCompileUnbalanced(t *testing.T) {
+	_, err := Compile("*.{go,mod")
+	assert.Error(t, err)
+}
+
+func TestStaticPrefix(t *testing.T) {
+	assert.Equal(t, "src/app", StaticPrefix("src/app/**/*.go"))
+	assert.Equal(t, "", StaticPrefix("**/*.go"))
+	assert.Equal(t, "", StaticPrefix("*.go"))
+	assert.Equal(t, "a", StaticPrefix("a/{b,c}/*.go"))
+}
@@ -2,7 +2,7 @@ package read
 
 import (
 	"DevCode/tools"
-	"bufio"
+	"DevCode/tools/notebook"
 	"context"
 	"fmt"
 	"os"
@@ -21,7 +21,10 @@ const (
   beginning of the file\n- You can optionally specify a line offset and limit (especially handy
    for long files), but it's recommended to read the whole file by not providing these
   parameters\n- Any lines longer than 2000 characters will be truncated\n- Results are returned
-   using cat -n format, with line numbers starting at 1\n- <good-exam1ple>This tool allows UniCode to read
+   using cat -n format, with line numbers starting at 1\n- Output is capped at 256KB. When not
+  every line is shown, a trailer such as "showing lines 1-2000 of 5400; use offset=2001 to
+  continue" tells you how to read the next page\n- Binary files are not printed; a short
+  summary with the detected type is returned instead\n- <good-exam1ple>This tool allows UniCode to read
    images (eg PNG, JPG, etc). When reading an image file the contents are presented visually as
 	UniCode is a multimodal LLM.\n- This tool can read PDF files (.pdf). PDFs are processed
   page by page, extracting both text and visual content for analysis.\n- This tool can read
@@ -35,8 +38,18 @@ const (
   read a file that exists but has empty contents you will receive a system reminder warning in
   place of file contents.`
 	Name = "Read"
+
+	MaxImageSize = 10 * 1024 * 1024
 )
 
+var ImageTypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+}
+
 type Tool struct {
 }
 
@@ -57,32 +70,47 @@ func (instance *Tool) Handler() mcp.ToolHandlerFor[Input, any] {
 		if _, err := os.Stat(input.FilePath); os.IsNotExist(err) {
 			return nil, fmt.Errorf("file not found: %s", input.FilePath)
 		}
-		file, err := os.Open(input.FilePath)
-		if err != nil {
-			if os.IsPermission(err) {
-				return nil, fmt.Errorf("permission denied: %s", input.FilePath)
-			}
-			return nil, fmt.Errorf("invalid path format: %s", input.FilePath)
+		if mimeType, ok := ImageTypes[strings.ToLower(filepath.Ext(input.FilePath))]; ok {
+			return ReadImage(input.FilePath, mimeType)
 		}
-		defer file.Close()
-		scanner := bufio.NewScanner(file)
-		var content strings.Builder
-		totalLines := 0
-		readLines := 0
-		for scanner.Scan() {
-			totalLines++
-			if totalLines < input.Offset {
-				continue
+		if strings.EqualFold(filepath.Ext(input.FilePath), ".ipynb") {
+			parsed, err := notebook.Load(input.FilePath)
+			if err != nil {
+				return nil, err
 			}
-			if input.Limit > 0 && readLines >= input.Limit {
-				break
-			}
-			fmt.Fprintf(&content, "%6d→\t%s\n", totalLines, scanner.Text())
-			readLines++
-			if totalLines == 2000 {
-				break
+			result, err := Format(strings.NewReader(notebook.Render(parsed)), input.Offset, input.Limit)
+			if err != nil {
+				return nil, err
 			}
+			return tools.TextReturn(result.String())
+		}
+		if binary, summary, err := SniffBinary(input.FilePath); err != nil {
+			return nil, err
+		} else if binary {
+			return tools.TextReturn(summary)
+		}
+		result, err := ReadText(input.FilePath, input.Offset, input.Limit)
+		if err != nil {
+			return nil, err
+		}
+		return tools.TextReturn(result.String())
+	}
+}
+
+func ReadImage(path string, mimeType string) (*mcp.CallToolResultFor[any], error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("file not found: %s", path)
+	}
+	if info.Size() > MaxImageSize {
+		return nil, fmt.Errorf("image too large: %s (%d bytes, max %d bytes)", path, info.Size(), MaxImageSize)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsPermission(err) {
+			return nil, fmt.Errorf("permission denied: %s", path)
 		}
-		return tools.TextReturn(content.String())
+		return nil, fmt.Errorf("invalid path format: %s", path)
 	}
+	return tools.ImageReturn(data, mimeType)
 }
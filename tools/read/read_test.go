@@ -0,0 +1,186 @@
+package read
+
+import (
+	"DevCode/tools"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadImage(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "shot.PNG")
+	data := []byte("\x89PNG\r\n\x1a\nfake")
+	require.NoError(t, os.WriteFile(path, data, 0644))
+
+	result, err := tools.Call(context.Background(), &Tool{}, Input{FilePath: path})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	image, ok := result.Content[0].(*mcp.ImageContent)
+	require.True(t, ok)
+	assert.Equal(t, "image/png", image.MIMEType)
+	assert.Equal(t, data, image.Data)
+}
+
+func TestReadImageTooLarge(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "big.jpg")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, file.Truncate(MaxImageSize+1))
+	require.NoError(t, file.Close())
+
+	_, err = tools.Call(context.Background(), &Tool{}, Input{FilePath: path})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "image too large")
+}
+
+func writeLines(t *testing.T, count int) string {
+	t.Helper()
+	var builder strings.Builder
+	for line := 1; line <= count; line++ {
+		fmt.Fprintf(&builder, "line %d\n", line)
+	}
+	path := filepath.Join(t.TempDir(), "lines.txt")
+	require.NoError(t, os.WriteFile(path, []byte(builder.String()), 0644))
+	return path
+}
+
+func TestReadDefaultLimitAddsTrailer(t *testing.T) {
+	path := writeLines(t, 5400)
+
+	result, err := tools.Call(context.Background(), &Tool{}, Input{FilePath: path})
+	require.NoError(t, err)
+	output := tools.Text(result)
+	assert.Contains(t, output, "  2000→\tline 2000\n")
+	assert.NotContains(t, output, "line 2001\n")
+	assert.Contains(t, output, "(showing lines 1-2000 of 5400; use offset=2001 to continue)")
+}
+
+func TestReadOffsetPastLine2000(t *testing.T) {
+	path := writeLines(t, 5400)
+
+	// 예전에는 offset이 있어도 2000번째 줄에서 멈췄음
+	result, err := tools.Call(context.Background(), &Tool{}, Input{FilePath: path, Offset: 2001})
+	require.NoError(t, err)
+	output := tools.Text(result)
+	assert.True(t, strings.HasPrefix(output, "  2001→\tline 2001\n"))
+	assert.Contains(t, output, "  4000→\tline 4000\n")
+	assert.Contains(t, output, "use offset=4001 to continue")
+
+	result, err = tools.Call(context.Background(), &Tool{}, Input{FilePath: path, Offset: 5399, Limit: 10})
+	require.NoError(t, err)
+	output = tools.Text(result)
+	assert.Equal(t, "  5399→\tline 5399\n  5400→\tline 5400\n", output)
+}
+
+func TestReadOffsetBeyondEnd(t *testing.T) {
+	path := writeLines(t, 3)
+
+	result, err := tools.Call(context.Background(), &Tool{}, Input{FilePath: path, Offset: 10})
+	require.NoError(t, err)
+	assert.Contains(t, tools.Text(result), "shorter than the provided offset (10)")
+}
+
+func TestReadEmptyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.txt")
+	require.NoError(t, os.WriteFile(path, nil, 0644))
+
+	result, err := tools.Call(context.Background(), &Tool{}, Input{FilePath: path})
+	require.NoError(t, err)
+	assert.Equal(t, EmptyFileNotice, tools.Text(result))
+}
+
+func TestReadLongLine(t *testing.T) {
+	// bufio.Scanner의 64KB 제한을 넘는 줄
+	path := filepath.Join(t.TempDir(), "long.txt")
+	long := strings.Repeat("x", 100*1024)
+	require.NoError(t, os.WriteFile(path, []byte("short\r\n"+long+"\nafter\n"), 0644))
+
+	result, err := tools.Call(context.Background(), &Tool{}, Input{FilePath: path})
+	require.NoError(t, err)
+	output := tools.Text(result)
+	assert.Contains(t, output, "     1→\tshort\n")
+	assert.Contains(t, output, "     2→\t"+strings.Repeat("x", MaxLineLength)+"… [line truncated]\n")
+	assert.Contains(t, output, "     3→\tafter\n")
+	assert.Contains(t, output, "(1 lines longer than 2000 characters were truncated)")
+}
+
+func TestReadMultibyteLineNotTruncated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "korean.txt")
+	line := strings.Repeat("가", MaxLineLength)
+	require.NoError(t, os.WriteFile(path, []byte(line+"\n"), 0644))
+
+	result, err := tools.Call(context.Background(), &Tool{}, Input{FilePath: path})
+	require.NoError(t, err)
+	assert.Equal(t, "     1→\t"+line+"\n", tools.Text(result))
+}
+
+func TestReadByteBudget(t *testing.T) {
+	var builder strings.Builder
+	for line := 0; line < 500; line++ {
+		builder.WriteString(strings.Repeat("y", 1000) + "\n")
+	}
+
+	result, err := Format(strings.NewReader(builder.String()), 0, 0)
+	require.NoError(t, err)
+	assert.True(t, result.BudgetExceeded)
+	assert.LessOrEqual(t, len(result.Content), MaxOutputBytes)
+	assert.Less(t, result.LastLine, 500)
+	assert.Contains(t, result.String(), fmt.Sprintf("of 500, output limited to %d bytes; use offset=%d", MaxOutputBytes, result.LastLine+1))
+}
+
+func TestReadNotebookPages(t *testing.T) {
+	source := make([]string, 0, 3000)
+	for line := 1; line <= 3000; line++ {
+		source = append(source, fmt.Sprintf("x = %d\n", line))
+	}
+	data, err := json.Marshal(map[string]any{
+		"cells":          []any{map[string]any{"cell_type": "code", "id": "big", "metadata": map[string]any{}, "outputs": []any{}, "source": source}},
+		"metadata":       map[string]any{},
+		"nbformat":       4,
+		"nbformat_minor": 5,
+	})
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "big.ipynb")
+	require.NoError(t, os.WriteFile(path, data, 0644))
+
+	// 노트북도 일반 텍스트처럼 offset/limit과 trailer를 따른다
+	result, err := tools.Call(context.Background(), &Tool{}, Input{FilePath: path})
+	require.NoError(t, err)
+	output := tools.Text(result)
+	assert.True(t, strings.HasPrefix(output, "     1→\t<cell id=\"big\" type=\"code\""))
+	assert.NotContains(t, output, "x = 2500\n")
+	assert.Contains(t, output, "; use offset=2001 to continue)")
+
+	result, err = tools.Call(context.Background(), &Tool{}, Input{FilePath: path, Offset: 2001, Limit: 2})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(tools.Text(result), "  2001→\tx = 2000\n  2002→\tx = 2001\n"))
+}
+
+func TestReadBinaryFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "program.bin")
+	require.NoError(t, os.WriteFile(path, []byte{0x7f, 'E', 'L', 'F', 0, 0, 1, 2, 3}, 0644))
+
+	result, err := tools.Call(context.Background(), &Tool{}, Input{FilePath: path})
+	require.NoError(t, err)
+	output := tools.Text(result)
+	assert.Contains(t, output, "Binary file: "+path+" (9 bytes")
+	assert.Contains(t, output, "application/octet-stream")
+}
+
+func TestIsBinary(t *testing.T) {
+	assert.False(t, IsBinary([]byte("plain text\n")))
+	assert.False(t, IsBinary([]byte("한국어 텍스트\n")))
+	assert.False(t, IsBinary([]byte("caf\xe9 latin-1 text with one odd byte\n")))
+	assert.True(t, IsBinary([]byte("abc\x00def")))
+	assert.True(t, IsBinary([]byte{0x01, 0x02, 0x03, 0xff, 0xfe, 0x04, 0x05, 0x06, 0x07, 0x08}))
+}
@@ -0,0 +1,202 @@
+package read
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	DefaultLimit   = 2000
+	MaxLineLength  = 2000
+	MaxOutputBytes = 256 * 1024
+	BinaryProbe    = 8000
+
+	EmptyFileNotice = "<system-reminder>Warning: the file exists but the contents are empty.</system-reminder>"
+)
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+type TextResult struct {
+	Content        string
+	FirstLine      int
+	LastLine       int
+	TotalLines     int
+	TruncatedLines int
+	BudgetExceeded bool
+}
+
+func (instance TextResult) Trailer() string {
+	var trailer strings.Builder
+	if instance.TruncatedLines > 0 {
+		fmt.Fprintf(&trailer, "\n(%d lines longer than %d characters were truncated)", instance.TruncatedLines, MaxLineLength)
+	}
+	if instance.LastLine < instance.TotalLines {
+		reason := ""
+		if instance.BudgetExceeded {
+			reason = fmt.Sprintf(", output limited to %d bytes", MaxOutputBytes)
+		}
+		fmt.Fprintf(&trailer, "\n(showing lines %d-%d of %d%s; use offset=%d to continue)",
+			instance.FirstLine, instance.LastLine, instance.TotalLines, reason, instance.LastLine+1)
+	}
+	return trailer.String()
+}
+
+func (instance TextResult) String() string {
+	if instance.TotalLines == 0 {
+		return EmptyFileNotice
+	}
+	if instance.LastLine < instance.FirstLine {
+		return fmt.Sprintf("<system-reminder>Warning: the file has only %d lines, shorter than the provided offset (%d).</system-reminder>",
+			instance.TotalLines, instance.FirstLine)
+	}
+	return instance.Content + instance.Trailer()
+}
+
+func ReadText(path string, offset int, limit int) (TextResult, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsPermission(err) {
+			return TextResult{}, fmt.Errorf("permission denied: %s", path)
+		}
+		return TextResult{}, fmt.Errorf("invalid path format: %s", path)
+	}
+	defer file.Close()
+	return Format(file, offset, limit)
+}
+
+func Format(reader io.Reader, offset int, limit int) (TextResult, error) {
+	if offset < 1 {
+		offset = 1
+	}
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	buffered := bufio.NewReader(reader)
+	if bom, _ := buffered.Peek(len(utf8BOM)); bytes.Equal(bom, utf8BOM) {
+		buffered.Discard(len(utf8BOM))
+	}
+	result := TextResult{FirstLine: offset, LastLine: offset - 1}
+	var content strings.Builder
+	for {
+		line, length, err := readLine(buffered)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return TextResult{}, err
+		}
+		if length == 0 && errors.Is(err, io.EOF) {
+			break
+		}
+		result.TotalLines++
+		number := result.TotalLines
+		collecting := number >= offset && number < offset+limit && !result.BudgetExceeded
+		if collecting {
+			text := strings.TrimSuffix(strings.ToValidUTF8(string(line), "�"), "\r")
+			if length > len(line) || utf8.RuneCountInString(text) > MaxLineLength {
+				text = truncateRunes(text, MaxLineLength) + "… [line truncated]"
+				result.TruncatedLines++
+			}
+			formatted := fmt.Sprintf("%6d→\t%s\n", number, text)
+			if content.Len()+len(formatted) > MaxOutputBytes && content.Len() > 0 {
+				result.BudgetExceeded = true
+			} else {
+				content.WriteString(formatted)
+				result.LastLine = number
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+	}
+	result.Content = content.String()
+	return result, nil
+}
+
+// readLine returns at most MaxLineLength*utf8.UTFMax bytes of the next line
+// and the full length of the line in bytes, so huge lines never sit in memory.
+func readLine(reader *bufio.Reader) ([]byte, int, error) {
+	var line []byte
+	length := 0
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		length += len(chunk)
+		if keep := MaxLineLength*utf8.UTFMax - len(line); keep > 0 {
+			line = append(line, chunk[:min(len(chunk), keep)]...)
+		}
+		if errors.Is(err, bufio.ErrBufferFull) {
+			continue
+		}
+		if bytes.HasSuffix(chunk, []byte("\n")) {
+			line = bytes.TrimSuffix(line, []byte("\n"))
+			length--
+		}
+		return line, length, err
+	}
+}
+
+func truncateRunes(text string, limit int) string {
+	count := 0
+	for index := range text {
+		if count == limit {
+			return text[:index]
+		}
+		count++
+	}
+	return text
+}
+
+func IsBinary(data []byte) bool {
+	probe := data[:min(len(data), BinaryProbe)]
+	if bytes.IndexByte(probe, 0) >= 0 {
+		return true
+	}
+	if bytes.HasPrefix(probe, utf8BOM) || utf8.Valid(probe) {
+		return false
+	}
+	// 잘린 마지막 룬은 무시하고, 제어 문자나 잘못된 바이트가 많으면 바이너리로 판단
+	suspicious := 0
+	for index := 0; index < len(probe); {
+		r, size := utf8.DecodeRune(probe[index:])
+		if (r == utf8.RuneError && size == 1 && index < len(probe)-utf8.UTFMax) ||
+			(r < 0x20 && r != '\n' && r != '\r' && r != '\t' && r != '\f' && r != 0x1b) {
+			suspicious++
+		}
+		index += size
+	}
+	return suspicious*10 > len(probe)
+}
+
+func DescribeBinary(path string, size int64, head []byte) string {
+	return fmt.Sprintf("Binary file: %s (%d bytes, detected type %s). Contents are not shown; use a dedicated tool to inspect it.",
+		path, size, http.DetectContentType(head))
+}
+
+func SniffBinary(path string) (bool, string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsPermission(err) {
+			return false, "", fmt.Errorf("permission denied: %s", path)
+		}
+		return false, "", fmt.Errorf("invalid path format: %s", path)
+	}
+	defer file.Close()
+	head := make([]byte, BinaryProbe)
+	count, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return false, "", fmt.Errorf("invalid path format: %s", path)
+	}
+	head = head[:count]
+	if !IsBinary(head) {
+		return false, "", nil
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return false, "", fmt.Errorf("invalid path format: %s", path)
+	}
+	return true, DescribeBinary(path, info.Size(), head), nil
+}
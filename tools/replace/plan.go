@@ -0,0 +1,222 @@
+package replace
+
+import (
+	"DevCode/tools/edit"
+	"DevCode/tools/grep"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	ContextLines = 2
+	PlanTimeout  = 30 * time.Second
+)
+
+// Root returns the directory a replacement runs in, the current working
+// directory when path is empty.
+func Root(path string) (string, error) {
+	if path == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return "", fmt.Errorf("fail to read working directory: %v", err)
+		}
+		return cwd, nil
+	}
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("invalid path format: %s", path)
+	}
+	return path, nil
+}
+
+// Plan finds every match of the input in the files under root that match the
+// glob and computes the new contents and hunks without writing anything.
+// Files whose contents would not change are left out, binary files are passed
+// over, and files too large or unreadable are returned as skipped. Planning
+// gives up after PlanTimeout.
+func Plan(ctx context.Context, root string, input Input) ([]FileChange, []SkippedFile, error) {
+	if input.Pattern == "" {
+		return nil, nil, fmt.Errorf("pattern must not be empty")
+	}
+	if input.Glob == "" {
+		return nil, nil, fmt.Errorf("glob must not be empty")
+	}
+	expression := input.Pattern
+	if !input.Regex {
+		expression = regexp.QuoteMeta(expression)
+	}
+	options, err := grep.NewOptions(grep.Input{Pattern: expression, Glob: input.Glob})
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, PlanTimeout)
+	defer cancel()
+	files, err := grep.CollectFiles(ctx, root, options)
+	if err != nil {
+		return nil, nil, planError(ctx, err)
+	}
+	changes := make([]FileChange, 0, len(files))
+	skipped := make([]SkippedFile, 0)
+	for _, path := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, planError(ctx, err)
+		}
+		if info, err := os.Stat(path); err != nil {
+			skipped = append(skipped, SkippedFile{Path: path, Reason: "could not be read"})
+			continue
+		} else if info.Size() > grep.MaxFileSize {
+			skipped = append(skipped, SkippedFile{Path: path, Reason: fmt.Sprintf("larger than %d MB", grep.MaxFileSize/(1024*1024))})
+			continue
+		}
+		content, mode, err := edit.ReadFile(path)
+		if err != nil {
+			skipped = append(skipped, SkippedFile{Path: path, Reason: "could not be read"})
+			continue
+		}
+		if grep.IsBinary([]byte(content)) {
+			continue
+		}
+		change, ok := planFile(content, options.Regexp, input)
+		if !ok {
+			continue
+		}
+		change.Path = path
+		change.Mode = mode
+		change.Hash = Hash(content)
+		changes = append(changes, change)
+	}
+	return changes, skipped, nil
+}
+
+func planError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("planning the replacement took longer than %s, narrow the glob or path", PlanTimeout)
+	}
+	return err
+}
+
+// Hash identifies the contents a change was planned from.
+func Hash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// block is a run of lines touched by overlapping matches, with the lines that
+// replace them.
+type block struct {
+	first   int
+	last    int
+	updated []string
+}
+
+func planFile(content string, compiled *regexp.Regexp, input Input) (FileChange, bool) {
+	matches := compiled.FindAllStringSubmatchIndex(content, -1)
+	if len(matches) == 0 {
+		return FileChange{}, false
+	}
+	replacements := make([]string, len(matches))
+	for index, match := range matches {
+		if input.Regex {
+			replacements[index] = string(compiled.ExpandString(nil, input.Replacement, content, match))
+		} else {
+			replacements[index] = input.Replacement
+		}
+	}
+	updated := splice(content, 0, len(content), matches, replacements)
+	if updated == content {
+		return FileChange{}, false
+	}
+
+	lines := strings.Split(content, "\n")
+	offsets := grep.LineOffsets(lines)
+	blocks := make([]block, 0, len(matches))
+	groups := make([][]int, 0, len(matches))
+	for index, match := range matches {
+		first := grep.LineAt(offsets, match[0])
+		last := grep.LineAt(offsets, max(match[1]-1, match[0]))
+		if count := len(blocks); count > 0 && first <= blocks[count-1].last {
+			blocks[count-1].last = max(blocks[count-1].last, last)
+			groups[count-1] = append(groups[count-1], index)
+			continue
+		}
+		blocks = append(blocks, block{first: first, last: last})
+		groups = append(groups, []int{index})
+	}
+	for index := range blocks {
+		start := offsets[blocks[index].first]
+		end := offsets[blocks[index].last] + len(lines[blocks[index].last])
+		selected := make([][]int, 0, len(groups[index]))
+		texts := make([]string, 0, len(groups[index]))
+		for _, match := range groups[index] {
+			selected = append(selected, matches[match])
+			texts = append(texts, replacements[match])
+		}
+		blocks[index].updated = strings.Split(splice(content, start, end, selected, texts), "\n")
+	}
+	return FileChange{Count: len(matches), Content: updated, Hunks: hunks(lines, blocks)}, true
+}
+
+// splice returns content[start:end] with every match replaced by the
+// replacement at the same index. Matches must lie inside start and end.
+func splice(content string, start int, end int, matches [][]int, replacements []string) string {
+	var builder strings.Builder
+	position := start
+	for index, match := range matches {
+		builder.WriteString(content[position:match[0]])
+		builder.WriteString(replacements[index])
+		position = match[1]
+	}
+	builder.WriteString(content[position:end])
+	return builder.String()
+}
+
+// hunks renders the blocks as unified diff hunks with ContextLines lines of
+// context, merging blocks whose context overlaps.
+func hunks(lines []string, blocks []block) []string {
+	lastLine := len(lines) - 1
+	if lastLine > 0 && lines[lastLine] == "" {
+		lastLine--
+	}
+	result := make([]string, 0, len(blocks))
+	delta := 0
+	for start := 0; start < len(blocks); {
+		end := start
+		for end+1 < len(blocks) && blocks[end+1].first-ContextLines <= blocks[end].last+ContextLines+1 {
+			end++
+		}
+		first := max(0, blocks[start].first-ContextLines)
+		last := max(min(lastLine, blocks[end].last+ContextLines), blocks[end].last)
+
+		var body strings.Builder
+		added := 0
+		line := first
+		for _, current := range blocks[start : end+1] {
+			for ; line < current.first; line++ {
+				body.WriteString(" " + lines[line] + "\n")
+			}
+			for ; line <= current.last; line++ {
+				body.WriteString("-" + lines[line] + "\n")
+			}
+			for _, text := range current.updated {
+				body.WriteString("+" + text + "\n")
+			}
+			added += len(current.updated) - (current.last - current.first + 1)
+		}
+		for ; line <= last; line++ {
+			body.WriteString(" " + lines[line] + "\n")
+		}
+		count := last - first + 1
+		header := fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", first+1, count, first+1+delta, count+added)
+		result = append(result, header+strings.TrimSuffix(body.String(), "\n"))
+		delta += added
+		start = end + 1
+	}
+	return result
+}
@@ -0,0 +1,191 @@
+package replace
+
+import (
+	"DevCode/tools"
+	"DevCode/tools/applypatch"
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	ReplaceDescription = `Replaces text in every file matching a glob as one reviewable change. Prefer
+  this tool over many Edit calls when renaming an identifier or updating a repeated string
+  across files.\n\nUsage:\n- pattern is matched literally; set regex to true for an RE2
+  regular expression, where replacement may use $1 or ${name} for capture groups\n- glob
+  selects the files, relative to path or the current working directory. Binary files and
+  files ignored by .gitignore are skipped; files too large or unreadable are listed as
+  skipped\n- Call with preview set to true first to see every affected hunk; nothing is
+  written in preview mode\n- Without preview the user sees the match count per file and must
+  approve before anything is written. If a file changes between approval and writing,
+  nothing is written. All files are then written as one atomic step: if any write fails, no
+  file is changed\n- Check the preview
+  for unintended matches and narrow the pattern or glob before applying`
+	Name = "Replace"
+
+	MaxPreviewLines = 2000
+
+	// MetaPlan carries the hashes of the files the user approved, from Hashes.
+	MetaPlan = "devcode/replacePlan"
+)
+
+type Tool struct {
+}
+
+func (*Tool) Name() string {
+	return Name
+}
+
+func (*Tool) Description() string {
+	return ReplaceDescription
+}
+
+func (instance *Tool) Handler() mcp.ToolHandlerFor[Input, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[Input]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		root, err := Root(input.Path)
+		if err != nil {
+			return nil, err
+		}
+		changes, skipped, err := Plan(ctx, root, input)
+		if err != nil {
+			return nil, err
+		}
+		if approved, ok := params.Meta[MetaPlan]; ok {
+			if err := Verify(root, changes, approved); err != nil {
+				return nil, err
+			}
+		}
+		if len(changes) == 0 {
+			return tools.TextReturn(fmt.Sprintf("No matches found for %s in %s", input.Pattern, input.Glob) + Skipped(root, skipped))
+		}
+		if input.Preview {
+			return tools.TextReturn(Preview(root, changes, skipped))
+		}
+		commit := make([]applypatch.Change, 0, len(changes))
+		for _, change := range changes {
+			commit = append(commit, applypatch.Change{
+				Operation: applypatch.Modify,
+				OldPath:   change.Path,
+				NewPath:   change.Path,
+				Content:   change.Content,
+				Mode:      change.Mode,
+				Hunks:     len(change.Hunks),
+			})
+		}
+		if err := applypatch.Commit(commit); err != nil {
+			return nil, fmt.Errorf("replace failed, no changes were applied: %w", err)
+		}
+		return tools.TextReturn("Applied: " + Counts(root, changes) + Skipped(root, skipped))
+	}
+}
+
+// Hashes maps each planned file to the hash of the contents it was planned
+// from, for the approved call to carry in MetaPlan.
+func Hashes(changes []FileChange) map[string]string {
+	hashes := make(map[string]string, len(changes))
+	for _, change := range changes {
+		hashes[change.Path] = change.Hash
+	}
+	return hashes
+}
+
+// Verify checks a fresh plan against the approved hashes. A file edited, newly
+// matching or no longer matching since approval fails the whole call.
+func Verify(root string, changes []FileChange, approved any) error {
+	hashes := make(map[string]string)
+	switch approved := approved.(type) {
+	case map[string]string:
+		hashes = approved
+	case map[string]any:
+		for path, hash := range approved {
+			if text, ok := hash.(string); ok {
+				hashes[path] = text
+			}
+		}
+	}
+	changed := make([]string, 0)
+	planned := make(map[string]bool, len(changes))
+	for _, change := range changes {
+		planned[change.Path] = true
+		if hashes[change.Path] != change.Hash {
+			changed = append(changed, relative(root, change.Path))
+		}
+	}
+	for path := range hashes {
+		if !planned[path] {
+			changed = append(changed, relative(root, path))
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	sort.Strings(changed)
+	return fmt.Errorf("files changed since the replacement was approved, nothing was written: %s. Call Replace again to review the current changes", strings.Join(changed, ", "))
+}
+
+// Counts summarises the changes as the total followed by the match count per file.
+func Counts(root string, changes []FileChange) string {
+	total := 0
+	for _, change := range changes {
+		total += change.Count
+	}
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%s in %s", plural(total, "match", "matches"), plural(len(changes), "file", "files"))
+	for _, change := range changes {
+		fmt.Fprintf(&builder, "\n  %s: %d", relative(root, change.Path), change.Count)
+	}
+	return builder.String()
+}
+
+// Skipped lists the files Plan could not look into, or is empty when there
+// are none.
+func Skipped(root string, skipped []SkippedFile) string {
+	if len(skipped) == 0 {
+		return ""
+	}
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "\nSkipped %s:", plural(len(skipped), "file", "files"))
+	for _, file := range skipped {
+		fmt.Fprintf(&builder, "\n  %s: %s", relative(root, file.Path), file.Reason)
+	}
+	return builder.String()
+}
+
+// Preview lists every affected hunk, truncated after MaxPreviewLines lines.
+func Preview(root string, changes []FileChange, skipped []SkippedFile) string {
+	var builder strings.Builder
+	builder.WriteString("Preview, nothing has been written: " + Counts(root, changes) + Skipped(root, skipped) + "\n")
+	lines := 0
+	for index, change := range changes {
+		if lines >= MaxPreviewLines {
+			fmt.Fprintf(&builder, "\n(Preview is truncated, %d more files are not shown. Consider a narrower glob.)\n", len(changes)-index)
+			break
+		}
+		fmt.Fprintf(&builder, "\n--- %s\n+++ %s\n", relative(root, change.Path), relative(root, change.Path))
+		for _, hunk := range change.Hunks {
+			builder.WriteString(hunk + "\n")
+			lines += strings.Count(hunk, "\n") + 1
+		}
+	}
+	builder.WriteString("\nCall Replace again without preview to apply these changes.")
+	return builder.String()
+}
+
+func relative(root string, path string) string {
+	if relative, err := filepath.Rel(root, path); err == nil && relative != "." && !strings.HasPrefix(relative, "..") {
+		return filepath.ToSlash(relative)
+	}
+	return path
+}
+
+func plural(count int, one string, many string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, one)
+	}
+	return fmt.Sprintf("%d %s", count, many)
+}
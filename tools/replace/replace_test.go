@@ -0,0 +1,224 @@
+package replace
+
+import (
+	"DevCode/tools"
+	"DevCode/tools/grep"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
+
+const store = `package store
+
+func OldName() int {
+	return 1
+}
+
+func other() {}
+
+func caller() {
+	OldName()
+}
+`
+
+func TestReplacePreview(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "store", "store.go"), store)
+	writeFile(t, filepath.Join(root, "main.go"), "package main\n\nvar x = OldName()\n")
+	writeFile(t, filepath.Join(root, "README.md"), "OldName\n")
+
+	text, err := tools.CallText(context.Background(), &Tool{}, Input{Pattern: "OldName", Replacement: "NewName", Glob: "*.go", Path: root, Preview: true})
+	require.NoError(t, err)
+	expected := strings.Join([]string{
+		"Preview, nothing has been written: 3 matches in 2 files",
+		"  main.go: 1",
+		"  store/store.go: 2",
+		"",
+		"--- main.go",
+		"+++ main.go",
+		"@@ -1,3 +1,3 @@",
+		" package main",
+		" ",
+		"-var x = OldName()",
+		"+var x = NewName()",
+		"",
+		"--- store/store.go",
+		"+++ store/store.go",
+		"@@ -1,5 +1,5 @@",
+		" package store",
+		" ",
+		"-func OldName() int {",
+		"+func NewName() int {",
+		" \treturn 1",
+		" }",
+		"@@ -8,4 +8,4 @@",
+		" ",
+		" func caller() {",
+		"-\tOldName()",
+		"+\tNewName()",
+		" }",
+		"",
+		"Call Replace again without preview to apply these changes.",
+	}, "\n")
+	assert.Equal(t, expected, text)
+
+	// preview 모드에서는 아무것도 쓰지 않는다
+	assert.Equal(t, store, readFile(t, filepath.Join(root, "store", "store.go")))
+}
+
+func TestReplaceApply(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "store", "store.go"), store)
+	writeFile(t, filepath.Join(root, "main.go"), "package main\n\nvar x = OldName()\n")
+	writeFile(t, filepath.Join(root, "README.md"), "OldName\n")
+
+	text, err := tools.CallText(context.Background(), &Tool{}, Input{Pattern: "OldName", Replacement: "NewName", Glob: "*.go", Path: root})
+	require.NoError(t, err)
+	assert.Equal(t, "Applied: 3 matches in 2 files\n  main.go: 1\n  store/store.go: 2", text)
+	assert.Equal(t, "package main\n\nvar x = NewName()\n", readFile(t, filepath.Join(root, "main.go")))
+	assert.Contains(t, readFile(t, filepath.Join(root, "store", "store.go")), "func NewName() int {")
+	assert.NotContains(t, readFile(t, filepath.Join(root, "store", "store.go")), "OldName")
+
+	// glob에 맞지 않는 파일은 그대로
+	assert.Equal(t, "OldName\n", readFile(t, filepath.Join(root, "README.md")))
+
+	text, err = tools.CallText(context.Background(), &Tool{}, Input{Pattern: "OldName", Replacement: "NewName", Glob: "*.go", Path: root})
+	require.NoError(t, err)
+	assert.Equal(t, "No matches found for OldName in *.go", text)
+}
+
+func TestReplaceRegex(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "a.go"), "x := get(a, b)\ny := get(c, d)\n")
+
+	// 리터럴 모드에서는 정규식 문자를 그대로 찾는다
+	text, err := tools.CallText(context.Background(), &Tool{}, Input{Pattern: "get(a, b)", Replacement: "get(b, a)", Glob: "*.go", Path: root})
+	require.NoError(t, err)
+	assert.Contains(t, text, "1 match in 1 file")
+	assert.Equal(t, "x := get(b, a)\ny := get(c, d)\n", readFile(t, filepath.Join(root, "a.go")))
+
+	_, err = tools.CallText(context.Background(), &Tool{}, Input{Pattern: `get\((\w+), (\w+)\)`, Replacement: "fetch($2, $1)", Glob: "*.go", Path: root, Regex: true})
+	require.NoError(t, err)
+	assert.Equal(t, "x := fetch(a, b)\ny := fetch(d, c)\n", readFile(t, filepath.Join(root, "a.go")))
+
+	_, err = tools.CallText(context.Background(), &Tool{}, Input{Pattern: "get(", Replacement: "x", Glob: "*.go", Path: root, Regex: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid regex pattern")
+}
+
+func TestReplaceMultilineHunk(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "a.txt"), "one\ntwo\nthree\nfour\n")
+
+	changes, _, err := Plan(context.Background(), root, Input{Pattern: `two\nthree`, Replacement: "2-3", Glob: "*.txt", Regex: true})
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "one\n2-3\nfour\n", changes[0].Content)
+	assert.Equal(t, []string{"@@ -1,4 +1,3 @@\n one\n-two\n-three\n+2-3\n four"}, changes[0].Hunks)
+}
+
+func TestReplaceSkips(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ".gitignore"), "ignored.go\n")
+	writeFile(t, filepath.Join(root, "ignored.go"), "OldName\n")
+	writeFile(t, filepath.Join(root, "binary.go"), "OldName\x00\n")
+	writeFile(t, filepath.Join(root, "same.go"), "OldName\n")
+
+	// 바뀌는 내용이 없는 파일과 무시된 파일은 제외
+	changes, _, err := Plan(context.Background(), root, Input{Pattern: "OldName", Replacement: "OldName", Glob: "*.go"})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	changes, _, err = Plan(context.Background(), root, Input{Pattern: "OldName", Replacement: "NewName", Glob: "*.go"})
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, filepath.Join(root, "same.go"), changes[0].Path)
+}
+
+func TestReplaceListsSkippedFiles(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "a.go"), "OldName\n")
+	file, err := os.Create(filepath.Join(root, "huge.go"))
+	require.NoError(t, err)
+	require.NoError(t, file.Truncate(grep.MaxFileSize+1))
+	require.NoError(t, file.Close())
+
+	// 너무 큰 파일은 조용히 빠지지 않고 목록에 나온다
+	text, err := tools.CallText(context.Background(), &Tool{}, Input{Pattern: "OldName", Replacement: "NewName", Glob: "*.go", Path: root, Preview: true})
+	require.NoError(t, err)
+	assert.Contains(t, text, "1 match in 1 file\n  a.go: 1\nSkipped 1 file:\n  huge.go: larger than 10 MB\n")
+
+	text, err = tools.CallText(context.Background(), &Tool{}, Input{Pattern: "OldName", Replacement: "NewName", Glob: "*.go", Path: root})
+	require.NoError(t, err)
+	assert.Equal(t, "Applied: 1 match in 1 file\n  a.go: 1\nSkipped 1 file:\n  huge.go: larger than 10 MB", text)
+}
+
+func TestReplaceCommitsApprovedPlan(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "a.go"), "OldName\n")
+	input := Input{Pattern: "OldName", Replacement: "NewName", Glob: "*.go", Path: root}
+	changes, _, err := Plan(context.Background(), root, input)
+	require.NoError(t, err)
+	approved := Hashes(changes)
+
+	// 승인 뒤 파일이 바뀌면 아무것도 쓰지 않는다
+	writeFile(t, filepath.Join(root, "a.go"), "OldName\nOldName\n")
+	writeFile(t, filepath.Join(root, "b.go"), "OldName\n")
+	tool := &Tool{}
+	_, err = tool.Handler()(context.Background(), nil, &mcp.CallToolParamsFor[Input]{
+		Arguments: input,
+		Meta:      mcp.Meta{MetaPlan: approved},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "files changed since the replacement was approved, nothing was written: a.go, b.go.")
+	assert.Equal(t, "OldName\nOldName\n", readFile(t, filepath.Join(root, "a.go")))
+	assert.Equal(t, "OldName\n", readFile(t, filepath.Join(root, "b.go")))
+
+	// 그대로면 승인한 계획을 쓴다
+	changes, _, err = Plan(context.Background(), root, input)
+	require.NoError(t, err)
+	_, err = tool.Handler()(context.Background(), nil, &mcp.CallToolParamsFor[Input]{
+		Arguments: input,
+		Meta:      mcp.Meta{MetaPlan: map[string]any{filepath.Join(root, "a.go"): changes[0].Hash, filepath.Join(root, "b.go"): changes[1].Hash}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "NewName\nNewName\n", readFile(t, filepath.Join(root, "a.go")))
+}
+
+func TestReplaceRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		input Input
+		err   string
+	}{
+		{name: "empty pattern", input: Input{Glob: "*.go", Path: "/tmp"}, err: "pattern must not be empty"},
+		{name: "empty glob", input: Input{Pattern: "a", Path: "/tmp"}, err: "glob must not be empty"},
+		{name: "relative path", input: Input{Pattern: "a", Glob: "*.go", Path: "src"}, err: "invalid path format: src"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tools.CallText(context.Background(), &Tool{}, tt.input)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
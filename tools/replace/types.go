@@ -0,0 +1,29 @@
+package replace
+
+import "os"
+
+type Input struct {
+	Pattern     string `json:"pattern" jsonschema:"description:The text to replace. It is matched literally unless regex is true"`
+	Replacement string `json:"replacement" jsonschema:"description:The text to replace every match with. With regex true, $1 or ${name} insert capture groups"`
+	Glob        string `json:"glob" jsonschema:"description:Glob pattern of the files to change (e.g. **/*.go, internal/**/*.{ts,tsx}). A pattern without / matches at any depth"`
+	Path        string `json:"path,omitempty" jsonschema:"description:The absolute directory to search in. Defaults to the current working directory"`
+	Regex       bool   `json:"regex,omitempty" jsonschema:"description:Treat pattern as an RE2 regular expression instead of literal text (default false)"`
+	Preview     bool   `json:"preview,omitempty" jsonschema:"description:Only return the affected hunks without writing anything (default false)"`
+}
+
+// FileChange is the replacement planned for one file. Hash is the SHA-256 of
+// the contents it was planned from.
+type FileChange struct {
+	Path    string
+	Count   int
+	Content string
+	Mode    os.FileMode
+	Hunks   []string
+	Hash    string
+}
+
+// SkippedFile is a file the glob selected that Plan could not look into.
+type SkippedFile struct {
+	Path   string
+	Reason string
+}
@@ -0,0 +1,215 @@
+package repomap
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const MaxSignatureWidth = 160
+
+// heuristic finds top-level declarations in languages without a parser here.
+// Every pattern has a name group and is matched against single lines.
+type heuristic struct {
+	patterns []*regexp.Regexp
+	// private reports names that are not part of the public surface.
+	private func(name string) bool
+}
+
+var (
+	whitespace = regexp.MustCompile(`\s+`)
+
+	underscore = func(name string) bool { return strings.HasPrefix(name, "_") }
+	never      = func(name string) bool { return false }
+
+	scriptHeuristic = heuristic{
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`^export\s+(?:default\s+)?(?:async\s+)?function\*?\s+(?P<name>[\w$]+)`),
+			regexp.MustCompile(`^export\s+(?:default\s+)?(?:abstract\s+)?class\s+(?P<name>[\w$]+)`),
+			regexp.MustCompile(`^export\s+(?:declare\s+)?(?:interface|type|enum)\s+(?P<name>[\w$]+)`),
+			regexp.MustCompile(`^export\s+const\s+(?P<name>[\w$]+)\s*(?::[^=]+)?=\s*(?:async\s*)?(?:\([^)]*\)|[\w$]+)\s*(?::[^=]+)?=>`),
+		},
+		private: never,
+	}
+
+	heuristics = map[string]heuristic{
+		".py": {
+			patterns: []*regexp.Regexp{
+				regexp.MustCompile(`^(?:async\s+)?def\s+(?P<name>[A-Za-z_]\w*)\s*\(`),
+				regexp.MustCompile(`^class\s+(?P<name>[A-Za-z_]\w*)`),
+			},
+			private: underscore,
+		},
+		".js":  scriptHeuristic,
+		".jsx": scriptHeuristic,
+		".mjs": scriptHeuristic,
+		".cjs": scriptHeuristic,
+		".ts":  scriptHeuristic,
+		".tsx": scriptHeuristic,
+		".rs": {
+			patterns: []*regexp.Regexp{
+				regexp.MustCompile(`^\s*pub\s+(?:async\s+)?(?:unsafe\s+)?(?:fn|struct|enum|trait|type|mod)\s+(?P<name>\w+)`),
+			},
+			private: never,
+		},
+		".java": {
+			patterns: []*regexp.Regexp{
+				regexp.MustCompile(`^\s*public\s+(?:(?:static|final|abstract|sealed)\s+)*(?:class|interface|enum|record)\s+(?P<name>\w+)`),
+				regexp.MustCompile(`^\s*public\s+(?:(?:static|final|abstract|synchronized|default)\s+)*[\w<>\[\], ?]+\s+(?P<name>\w+)\s*\(`),
+			},
+			private: never,
+		},
+		".kt": {
+			patterns: []*regexp.Regexp{
+				regexp.MustCompile(`^(?:(?:data|sealed|open|abstract|enum)\s+)*(?:class|interface|object)\s+(?P<name>\w+)`),
+				regexp.MustCompile(`^(?:suspend\s+)?fun\s+(?:<[^>]+>\s*)?(?:[\w.]+\.)?(?P<name>\w+)\s*\(`),
+			},
+			private: never,
+		},
+		".rb": {
+			patterns: []*regexp.Regexp{
+				regexp.MustCompile(`^\s*(?:class|module)\s+(?P<name>[A-Z]\w*)`),
+				regexp.MustCompile(`^\s*def\s+(?:self\.)?(?P<name>[a-z_]\w*[?!]?)`),
+			},
+			private: underscore,
+		},
+	}
+)
+
+// Supported reports whether Outline understands files with this name.
+func Supported(path string) bool {
+	extension := filepath.Ext(path)
+	if extension == ".go" {
+		return !strings.HasSuffix(path, "_test.go")
+	}
+	_, ok := heuristics[extension]
+	return ok
+}
+
+// Outline returns the exported types and functions declared in a file.
+func Outline(path string, source []byte) []Symbol {
+	if filepath.Ext(path) == ".go" {
+		return goOutline(path, source)
+	}
+	language, ok := heuristics[filepath.Ext(path)]
+	if !ok {
+		return nil
+	}
+	symbols := make([]Symbol, 0, 8)
+	for index, line := range strings.Split(string(source), "\n") {
+		for _, pattern := range language.patterns {
+			match := pattern.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			name := match[pattern.SubexpIndex("name")]
+			if !language.private(name) {
+				signature := strings.TrimRight(strings.TrimSpace(line), "{:")
+				symbols = append(symbols, Symbol{Name: name, Signature: compact(signature), Line: index + 1})
+			}
+			break
+		}
+	}
+	return symbols
+}
+
+func goOutline(path string, source []byte) []Symbol {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, source, parser.SkipObjectResolution)
+	if err != nil {
+		return nil
+	}
+	symbols := make([]Symbol, 0, len(file.Decls))
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if !decl.Name.IsExported() || (decl.Recv != nil && !ast.IsExported(receiverName(decl.Recv))) {
+				continue
+			}
+			signature := &ast.FuncDecl{Recv: decl.Recv, Name: decl.Name, Type: decl.Type}
+			symbols = append(symbols, Symbol{
+				Name:      decl.Name.Name,
+				Signature: compact(render(fset, signature)),
+				Line:      fset.Position(decl.Pos()).Line,
+			})
+		case *ast.GenDecl:
+			if decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if !typeSpec.Name.IsExported() {
+					continue
+				}
+				symbols = append(symbols, Symbol{
+					Name:      typeSpec.Name.Name,
+					Signature: compact(typeSignature(fset, typeSpec)),
+					Line:      fset.Position(typeSpec.Pos()).Line,
+				})
+			}
+		}
+	}
+	return symbols
+}
+
+func typeSignature(fset *token.FileSet, spec *ast.TypeSpec) string {
+	signature := "type " + spec.Name.Name
+	if spec.TypeParams != nil {
+		params := render(fset, &ast.FuncType{Func: token.NoPos, Params: spec.TypeParams})
+		signature += "[" + strings.TrimSuffix(strings.TrimPrefix(params, "func("), ")") + "]"
+	}
+	if spec.Assign.IsValid() {
+		signature += " ="
+	}
+	switch spec.Type.(type) {
+	case *ast.StructType:
+		return signature + " struct"
+	case *ast.InterfaceType:
+		return signature + " interface"
+	}
+	return signature + " " + render(fset, spec.Type)
+}
+
+func receiverName(receiver *ast.FieldList) string {
+	if len(receiver.List) == 0 {
+		return ""
+	}
+	expression := receiver.List[0].Type
+	for {
+		switch current := expression.(type) {
+		case *ast.StarExpr:
+			expression = current.X
+		case *ast.IndexExpr:
+			expression = current.X
+		case *ast.IndexListExpr:
+			expression = current.X
+		case *ast.Ident:
+			return current.Name
+		default:
+			return ""
+		}
+	}
+}
+
+func render(fset *token.FileSet, node any) string {
+	var buffer bytes.Buffer
+	if err := printer.Fprint(&buffer, fset, node); err != nil {
+		return ""
+	}
+	return buffer.String()
+}
+
+// compact puts a signature on one line and cuts it at MaxSignatureWidth.
+func compact(signature string) string {
+	signature = whitespace.ReplaceAllString(strings.TrimSpace(signature), " ")
+	signature = strings.ReplaceAll(strings.ReplaceAll(signature, "( ", "("), ", )", ")")
+	if len(signature) > MaxSignatureWidth {
+		return strings.ToValidUTF8(signature[:MaxSignatureWidth], "") + "..."
+	}
+	return signature
+}
@@ -0,0 +1,288 @@
+package repomap
+
+import (
+	"DevCode/config"
+	"DevCode/tools"
+	"DevCode/tools/gitignore"
+	"DevCode/tools/grep"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	RepoMapDescription = `Builds a compact outline of a repository: directories, files and the exported
+  types and functions of each file with their signatures.\n\nUsage:\n- Use this first in an
+  unfamiliar repository to find where things live before reading files\n- Entries are ranked
+  by how often their names are referenced across the repository, and only the most
+  referenced ones that fit in max_tokens are shown\n- Go files are parsed; Python, JavaScript,
+  TypeScript, Rust, Java, Kotlin and Ruby use heuristics, so some declarations may be
+  missed\n- Pass path to map a sub-directory in more detail\n- Files ignored by .gitignore,
+  hidden directories, vendor and node_modules are skipped`
+	Name = "RepoMap"
+
+	MaxFileSize = 512 * 1024
+)
+
+var (
+	identifier = regexp.MustCompile(`[A-Za-z_$][\w$]*`)
+
+	SkipDirectories = []string{"vendor", "node_modules"}
+
+	errFileLimit = errors.New("file limit reached")
+)
+
+func NewTool(config config.RepoMapConfig) *Tool {
+	return &Tool{config: config}
+}
+
+type Tool struct {
+	config config.RepoMapConfig
+}
+
+func (*Tool) Name() string {
+	return Name
+}
+
+func (*Tool) Description() string {
+	return RepoMapDescription
+}
+
+func (instance *Tool) Handler() mcp.ToolHandlerFor[Input, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[Input]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		root := input.Path
+		if root == "" {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return nil, fmt.Errorf("fail to read working directory: %v", err)
+			}
+			root = cwd
+		}
+		if !filepath.IsAbs(root) {
+			return nil, fmt.Errorf("invalid path format: %s", root)
+		}
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("directory not found: %s", root)
+		}
+		maxTokens := input.MaxTokens
+		if maxTokens <= 0 {
+			maxTokens = instance.config.MaxTokens
+		}
+		repoMap, err := Build(ctx, root, instance.config.MaxFiles)
+		if err != nil {
+			return nil, err
+		}
+		return tools.TextReturn(repoMap.Render(maxTokens))
+	}
+}
+
+// Build walks root, outlines every supported source file and scores each
+// symbol by how often its name is referenced in the scanned files. The walk
+// stops after maxFiles source files.
+func Build(ctx context.Context, root string, maxFiles int) (*Map, error) {
+	repoMap := &Map{Root: root, Files: make([]*File, 0, 64)}
+	references := make(map[string]int)
+	err := gitignore.Walk(ctx, root, gitignore.ForPath(root), func(current string, entry fs.DirEntry) error {
+		if entry.IsDir() {
+			name := entry.Name()
+			if current != root && (strings.HasPrefix(name, ".") || slices.Contains(SkipDirectories, name)) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() || !Supported(current) {
+			return nil
+		}
+		if len(repoMap.Files) >= maxFiles {
+			repoMap.Truncated = true
+			return errFileLimit
+		}
+		info, err := entry.Info()
+		if err != nil || info.Size() > MaxFileSize {
+			return nil
+		}
+		source, err := os.ReadFile(current)
+		if err != nil || grep.IsBinary(source) {
+			return nil
+		}
+		relative, err := filepath.Rel(root, current)
+		if err != nil {
+			return nil
+		}
+		for _, name := range identifier.FindAllString(string(source), -1) {
+			references[name]++
+		}
+		repoMap.Files = append(repoMap.Files, &File{Path: filepath.ToSlash(relative), Symbols: Outline(current, source)})
+		return nil
+	})
+	if err != nil && !errors.Is(err, errFileLimit) {
+		return nil, err
+	}
+
+	// every declaration also counts as an occurrence of its name, and names
+	// declared many times, such as interface methods, share their references
+	definitions := make(map[string]int)
+	for _, file := range repoMap.Files {
+		for _, symbol := range file.Symbols {
+			definitions[symbol.Name]++
+		}
+	}
+	for _, file := range repoMap.Files {
+		for index := range file.Symbols {
+			symbol := &file.Symbols[index]
+			count := definitions[symbol.Name]
+			symbol.Score = max(references[symbol.Name]-count, 0) / count
+			file.Score += symbol.Score
+		}
+	}
+	sort.SliceStable(repoMap.Files, func(i, j int) bool {
+		if repoMap.Files[i].Score != repoMap.Files[j].Score {
+			return repoMap.Files[i].Score > repoMap.Files[j].Score
+		}
+		return repoMap.Files[i].Path < repoMap.Files[j].Path
+	})
+	return repoMap, nil
+}
+
+// EstimateTokens approximates the number of tokens in text at four bytes
+// per token.
+func EstimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+type candidate struct {
+	file   *File
+	symbol *Symbol
+}
+
+// Render writes the map in roughly maxTokens tokens. Symbols are taken in
+// order of score while they fit, then the remaining files by name only.
+// Directories and files are listed by the score of what is shown in them.
+func (instance *Map) Render(maxTokens int) string {
+	total := 0
+	candidates := make([]candidate, 0, 256)
+	for _, file := range instance.Files {
+		for index := range file.Symbols {
+			candidates = append(candidates, candidate{file: file, symbol: &file.Symbols[index]})
+			total++
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].symbol.Score > candidates[j].symbol.Score
+	})
+
+	header := fmt.Sprintf("Repository map of %s (%d files, %d symbols)\n", instance.Root, len(instance.Files), total)
+	budget := maxTokens - EstimateTokens(header)
+	shownFiles := make(map[*File][]*Symbol)
+	shownDirs := make(map[string]bool)
+	cost := func(file *File) int {
+		tokens := 0
+		if _, ok := shownFiles[file]; !ok {
+			tokens += EstimateTokens(fileLine(file))
+			if !shownDirs[path.Dir(file.Path)] {
+				tokens += EstimateTokens(dirLine(path.Dir(file.Path)))
+			}
+		}
+		return tokens
+	}
+	show := func(file *File) {
+		if _, ok := shownFiles[file]; !ok {
+			shownFiles[file] = make([]*Symbol, 0, len(file.Symbols))
+			shownDirs[path.Dir(file.Path)] = true
+		}
+	}
+
+	shown := 0
+	for _, current := range candidates {
+		tokens := cost(current.file) + EstimateTokens(symbolLine(current.symbol))
+		if tokens > budget {
+			break
+		}
+		budget -= tokens
+		show(current.file)
+		shownFiles[current.file] = append(shownFiles[current.file], current.symbol)
+		shown++
+	}
+	for _, file := range instance.Files {
+		if _, ok := shownFiles[file]; ok {
+			continue
+		}
+		tokens := cost(file)
+		if tokens > budget {
+			break
+		}
+		budget -= tokens
+		show(file)
+	}
+
+	dirs := make(map[string][]*File)
+	dirScores := make(map[string]int)
+	order := make([]string, 0, len(shownDirs))
+	for _, file := range instance.Files {
+		symbols, ok := shownFiles[file]
+		if !ok {
+			continue
+		}
+		dir := path.Dir(file.Path)
+		if _, ok := dirs[dir]; !ok {
+			order = append(order, dir)
+		}
+		dirs[dir] = append(dirs[dir], file)
+		for _, symbol := range symbols {
+			dirScores[dir] += symbol.Score
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return dirScores[order[i]] > dirScores[order[j]]
+	})
+
+	var builder strings.Builder
+	builder.WriteString(header)
+	for _, dir := range order {
+		builder.WriteString(dirLine(dir))
+		for _, file := range dirs[dir] {
+			builder.WriteString(fileLine(file))
+			symbols := shownFiles[file]
+			sort.Slice(symbols, func(i, j int) bool {
+				return symbols[i].Line < symbols[j].Line
+			})
+			for _, symbol := range symbols {
+				builder.WriteString(symbolLine(symbol))
+			}
+		}
+	}
+	if omitted := total - shown; omitted > 0 || len(shownFiles) < len(instance.Files) {
+		fmt.Fprintf(&builder, "(%d symbols and %d files omitted to fit %d tokens. Pass a sub-directory as path or a larger max_tokens to see more.)\n",
+			omitted, len(instance.Files)-len(shownFiles), maxTokens)
+	}
+	if instance.Truncated {
+		builder.WriteString("(Only the first files were scanned. Pass a sub-directory as path to map the rest.)\n")
+	}
+	return strings.TrimRight(builder.String(), "\n")
+}
+
+func dirLine(dir string) string {
+	if dir == "." {
+		return "./\n"
+	}
+	return dir + "/\n"
+}
+
+func fileLine(file *File) string {
+	return "  " + path.Base(file.Path) + "\n"
+}
+
+func symbolLine(symbol *Symbol) string {
+	return "    " + symbol.Signature + "\n"
+}
@@ -0,0 +1,194 @@
+package repomap
+
+import (
+	"DevCode/config"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+const storeSource = `package store
+
+type Store struct {
+	items map[string]int
+}
+
+type Option func(*Store)
+
+type Pair[K comparable, V any] struct {
+	Key K
+	Value V
+}
+
+type ID = string
+
+func NewStore(options ...Option) *Store {
+	return &Store{}
+}
+
+func (instance *Store) Get(key string,
+	fallback int) (int, bool) {
+	return 0, false
+}
+
+func (instance *Store) reset() {}
+
+func helper() {}
+
+type hidden struct{}
+
+func (hidden) Visible() {}
+`
+
+func TestOutlineGo(t *testing.T) {
+	symbols := Outline("store.go", []byte(storeSource))
+	signatures := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		signatures = append(signatures, symbol.Signature)
+	}
+	assert.Equal(t, []string{
+		"type Store struct",
+		"type Option func(*Store)",
+		"type Pair[K comparable, V any] struct",
+		"type ID = string",
+		"func NewStore(options ...Option) *Store",
+		"func (instance *Store) Get(key string, fallback int) (int, bool)",
+	}, signatures)
+	assert.Equal(t, 3, symbols[0].Line)
+
+	// 파싱할 수 없는 파일은 비어 있다
+	assert.Empty(t, Outline("broken.go", []byte("package")))
+}
+
+func TestOutlineHeuristics(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		source   string
+		expected []string
+	}{
+		{
+			name:     "python",
+			path:     "app.py",
+			source:   "class Server:\n    def handle(self):\n        pass\n\ndef run(port):\n    pass\n\ndef _private():\n    pass\n",
+			expected: []string{"class Server", "def run(port)"},
+		},
+		{
+			name:     "typescript",
+			path:     "api.ts",
+			source:   "export interface User {\n}\nexport async function load(id: string): Promise<User> {\n}\nexport const save = async (user: User) => {\n}\nfunction local() {}\n",
+			expected: []string{"export interface User", "export async function load(id: string): Promise<User>", "export const save = async (user: User) =>"},
+		},
+		{
+			name:     "rust",
+			path:     "lib.rs",
+			source:   "pub struct Config {\n}\nimpl Config {\n    pub fn new() -> Self {\n    }\n    fn private() {}\n}\n",
+			expected: []string{"pub struct Config", "pub fn new() -> Self"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signatures := make([]string, 0)
+			for _, symbol := range Outline(tt.path, []byte(tt.source)) {
+				signatures = append(signatures, symbol.Signature)
+			}
+			assert.Equal(t, tt.expected, signatures)
+		})
+	}
+	assert.False(t, Supported("main_test.go"))
+	assert.False(t, Supported("README.md"))
+	assert.True(t, Supported("main.go"))
+}
+
+func createRepository(t *testing.T) string {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "store", "store.go"), "package store\n\ntype Store struct{}\n\nfunc NewStore() *Store { return nil }\n\nfunc Rarely() {}\n")
+	writeFile(t, filepath.Join(root, "api", "handler.go"), "package api\n\nfunc Handle() { store.NewStore(); store.NewStore() }\n\nvar s store.Store\n")
+	writeFile(t, filepath.Join(root, "main.go"), "package main\n\nfunc main() { store.NewStore(); api.Handle() }\n")
+	writeFile(t, filepath.Join(root, "node_modules", "lib", "index.js"), "export function NewStore() {}\n")
+	writeFile(t, filepath.Join(root, ".devcode", "x.go"), "package x\n\nfunc NewStore() {}\n")
+	writeFile(t, filepath.Join(root, ".gitignore"), "generated/\n")
+	writeFile(t, filepath.Join(root, "generated", "gen.go"), "package generated\n\nfunc NewStore() {}\n")
+	return root
+}
+
+func TestBuild(t *testing.T) {
+	root := createRepository(t)
+
+	repoMap, err := Build(context.Background(), root, 100)
+	require.NoError(t, err)
+	paths := make([]string, 0, len(repoMap.Files))
+	for _, file := range repoMap.Files {
+		paths = append(paths, file.Path)
+	}
+	// 참조가 많은 파일이 먼저 오고, 무시된 디렉토리는 빠진다
+	assert.Equal(t, []string{"store/store.go", "api/handler.go", "main.go"}, paths)
+	assert.False(t, repoMap.Truncated)
+
+	scores := make(map[string]int)
+	for _, symbol := range repoMap.Files[0].Symbols {
+		scores[symbol.Name] = symbol.Score
+	}
+	assert.Equal(t, map[string]int{"Store": 2, "NewStore": 3, "Rarely": 0}, scores)
+
+	repoMap, err = Build(context.Background(), root, 2)
+	require.NoError(t, err)
+	assert.Len(t, repoMap.Files, 2)
+	assert.True(t, repoMap.Truncated)
+}
+
+func TestRender(t *testing.T) {
+	root := createRepository(t)
+	repoMap, err := Build(context.Background(), root, 100)
+	require.NoError(t, err)
+
+	expected := strings.Join([]string{
+		"Repository map of " + root + " (3 files, 4 symbols)",
+		"store/",
+		"  store.go",
+		"    type Store struct",
+		"    func NewStore() *Store",
+		"    func Rarely()",
+		"api/",
+		"  handler.go",
+		"    func Handle()",
+		"./",
+		"  main.go",
+	}, "\n")
+	assert.Equal(t, expected, repoMap.Render(1000))
+
+	// 예산이 작으면 가장 많이 참조된 심볼만 남는다
+	small := repoMap.Render(EstimateTokens("Repository map of "+root+" (3 files, 4 symbols)\n") + 12)
+	assert.Contains(t, small, "    func NewStore() *Store\n")
+	assert.NotContains(t, small, "Rarely")
+	assert.Contains(t, small, "symbols and 2 files omitted")
+}
+
+func TestTool(t *testing.T) {
+	root := createRepository(t)
+	tool := NewTool(config.RepoMapConfig{MaxTokens: 1000, MaxFiles: 100})
+
+	result, err := tool.Handler()(context.Background(), nil, &mcp.CallToolParamsFor[Input]{Arguments: Input{Path: root}})
+	require.NoError(t, err)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "func NewStore() *Store")
+
+	_, err = tool.Handler()(context.Background(), nil, &mcp.CallToolParamsFor[Input]{Arguments: Input{Path: "relative"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid path format")
+
+	_, err = tool.Handler()(context.Background(), nil, &mcp.CallToolParamsFor[Input]{Arguments: Input{Path: filepath.Join(root, "missing")}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "directory not found")
+}
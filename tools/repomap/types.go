@@ -0,0 +1,28 @@
+package repomap
+
+type Input struct {
+	Path      string `json:"path,omitempty" jsonschema:"description:The absolute directory to map. Defaults to the current working directory"`
+	MaxTokens int    `json:"max_tokens,omitempty" jsonschema:"description:Approximate token budget for the map. Defaults to the configured budget"`
+}
+
+// Symbol is an exported type or function found in a source file.
+type Symbol struct {
+	Name      string
+	Signature string
+	Line      int
+	Score     int
+}
+
+type File struct {
+	// Path is relative to the map root and uses forward slashes.
+	Path    string
+	Symbols []Symbol
+	Score   int
+}
+
+type Map struct {
+	Root  string
+	Files []*File
+	// Truncated is set when the walk stopped at the file limit.
+	Truncated bool
+}
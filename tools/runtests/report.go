@@ -0,0 +1,363 @@
+package runtests
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	MaxFailures     = 20
+	MaxExcerptLines = 40
+	MaxLineLength   = 300
+)
+
+var locationPattern = regexp.MustCompile(`^\s*([^\s:]+\.go):(\d+)`)
+
+// Report collects the events of one go test -json run.
+type Report struct {
+	Packages map[string]*PackageResult
+	order    []string
+	builds   map[string][]string
+	Other    []string
+	// ExitFailed is set when go test itself exited with an error.
+	ExitFailed bool
+}
+
+func NewReport() *Report {
+	return &Report{
+		Packages: make(map[string]*PackageResult),
+		builds:   make(map[string][]string),
+	}
+}
+
+// Parse reads go test -json output. Lines that are not JSON, such as build
+// errors printed by older Go versions, are kept as other output.
+func Parse(reader io.Reader) (*Report, error) {
+	report := NewReport()
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		report.Add(scanner.Text())
+	}
+	return report, scanner.Err()
+}
+
+func (instance *Report) Add(line string) {
+	var event Event
+	if !strings.HasPrefix(line, "{") || json.Unmarshal([]byte(line), &event) != nil {
+		if strings.TrimSpace(line) != "" {
+			instance.Other = append(instance.Other, line)
+		}
+		return
+	}
+	switch event.Action {
+	case "build-output":
+		instance.builds[event.ImportPath] = append(instance.builds[event.ImportPath], strings.TrimRight(event.Output, "\n"))
+		return
+	case "build-fail":
+		return
+	}
+	if event.Package == "" {
+		return
+	}
+	pkg := instance.pkg(event.Package)
+	if event.Test == "" {
+		switch event.Action {
+		case "output":
+			output := strings.TrimRight(event.Output, "\n")
+			if strings.Contains(output, "[no test files]") {
+				pkg.NoTests = true
+			}
+			if strings.Contains(output, "[build failed]") || strings.Contains(output, "[setup failed]") {
+				pkg.BuildFailed = true
+			}
+			pkg.Output = append(pkg.Output, output)
+		case "pass", "fail", "skip":
+			pkg.Action = event.Action
+			pkg.Elapsed = event.Elapsed
+		}
+		return
+	}
+	test := pkg.test(event.Test)
+	switch event.Action {
+	case "output":
+		test.Output = append(test.Output, strings.TrimRight(event.Output, "\n"))
+	case "pass", "fail", "skip":
+		test.Action = event.Action
+		test.Elapsed = event.Elapsed
+	}
+}
+
+func (instance *Report) pkg(name string) *PackageResult {
+	pkg, ok := instance.Packages[name]
+	if !ok {
+		pkg = &PackageResult{Name: name}
+		instance.Packages[name] = pkg
+		instance.order = append(instance.order, name)
+	}
+	return pkg
+}
+
+func (instance *PackageResult) test(name string) *TestResult {
+	for _, test := range instance.Tests {
+		if test.Name == name {
+			return test
+		}
+	}
+	test := &TestResult{Package: instance.Name, Name: name}
+	instance.Tests = append(instance.Tests, test)
+	return test
+}
+
+// Leaves returns the tests that have no subtests. Parents only repeat the
+// result of their subtests, so they are left out of counts and failures.
+func (instance *PackageResult) Leaves() []*TestResult {
+	var leaves []*TestResult
+	for _, test := range instance.Tests {
+		parent := false
+		for _, other := range instance.Tests {
+			if strings.HasPrefix(other.Name, test.Name+"/") {
+				parent = true
+				break
+			}
+		}
+		if !parent {
+			leaves = append(leaves, test)
+		}
+	}
+	return leaves
+}
+
+type Counts struct {
+	Passed  int
+	Failed  int
+	Skipped int
+	Running int
+}
+
+func (instance *Report) Counts() Counts {
+	var counts Counts
+	for _, pkg := range instance.Packages {
+		for _, test := range pkg.Leaves() {
+			switch test.Action {
+			case "pass":
+				counts.Passed++
+			case "fail":
+				counts.Failed++
+			case "skip":
+				counts.Skipped++
+			default:
+				counts.Running++
+			}
+		}
+	}
+	return counts
+}
+
+// Failed reports whether any package or test failed.
+func (instance *Report) Failed() bool {
+	for _, pkg := range instance.Packages {
+		if pkg.Action == "fail" || pkg.BuildFailed {
+			return true
+		}
+	}
+	return instance.ExitFailed || instance.Counts().Failed > 0 || len(instance.builds) > 0
+}
+
+// Locate turns a file:line printed by a test into a path relative to base.
+type Locate func(pkg string, file string) string
+
+func (instance *Report) Summary(elapsed time.Duration, timedOut bool, locate Locate) string {
+	counts := instance.Counts()
+	status := "PASS"
+	if instance.Failed() || timedOut {
+		status = "FAIL"
+	}
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%s: %d passed, %d failed, %d skipped in %.2fs (%d packages)", status, counts.Passed, counts.Failed, counts.Skipped, elapsed.Seconds(), len(instance.Packages))
+	if timedOut {
+		builder.WriteString("\nThe test run was stopped because it exceeded the timeout.")
+		if counts.Running > 0 {
+			fmt.Fprintf(&builder, " %d tests were still running.", counts.Running)
+		}
+	}
+
+	shown := 0
+	for _, name := range instance.order {
+		pkg := instance.Packages[name]
+		for _, test := range pkg.Leaves() {
+			if test.Action != "fail" && !(timedOut && test.Action == "") {
+				continue
+			}
+			shown++
+			if shown > MaxFailures {
+				continue
+			}
+			label := "FAIL"
+			if test.Action == "" {
+				label = "DID NOT FINISH"
+			}
+			fmt.Fprintf(&builder, "\n\n--- %s: %s (%.2fs) %s", label, test.Name, test.Elapsed, pkg.Name)
+			excerpt := Excerpt(test.Output)
+			if location := FindLocation(excerpt); location != "" && locate != nil {
+				fmt.Fprintf(&builder, "\nat %s", locate(pkg.Name, location))
+			}
+			for _, line := range excerpt {
+				builder.WriteString("\n    " + line)
+			}
+		}
+	}
+	if shown > MaxFailures {
+		fmt.Fprintf(&builder, "\n\n... %d more failing tests not shown", shown-MaxFailures)
+	}
+
+	// 테스트 실패 없이 패키지가 실패한 경우 (빌드 실패, TestMain, panic 등)
+	for _, name := range instance.order {
+		pkg := instance.Packages[name]
+		if pkg.Action != "fail" && !pkg.BuildFailed {
+			continue
+		}
+		failed := false
+		for _, test := range pkg.Leaves() {
+			failed = failed || test.Action == "fail"
+		}
+		if failed {
+			continue
+		}
+		lines := instance.builds[name]
+		if len(lines) == 0 {
+			lines = Excerpt(pkg.Output)
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		fmt.Fprintf(&builder, "\n\n--- FAIL: package %s", name)
+		for _, line := range tail(lines) {
+			builder.WriteString("\n    " + line)
+		}
+	}
+	builds := make([]string, 0, len(instance.builds))
+	for path := range instance.builds {
+		builds = append(builds, path)
+	}
+	sort.Strings(builds)
+	for _, path := range builds {
+		lines := instance.builds[path]
+		if _, ok := instance.Packages[path]; ok {
+			continue
+		}
+		fmt.Fprintf(&builder, "\n\n--- BUILD FAILED: %s", path)
+		for _, line := range tail(lines) {
+			builder.WriteString("\n    " + line)
+		}
+	}
+	if len(instance.Other) > 0 && (status == "FAIL" || len(instance.Packages) == 0) {
+		builder.WriteString("\n\nOther output:")
+		for _, line := range tail(instance.Other) {
+			builder.WriteString("\n    " + line)
+		}
+	}
+
+	builder.WriteString("\n\nPackages:")
+	names := append([]string{}, instance.order...)
+	sort.Strings(names)
+	for _, name := range names {
+		pkg := instance.Packages[name]
+		switch {
+		case pkg.BuildFailed:
+			fmt.Fprintf(&builder, "\nFAIL  %s [build failed]", name)
+		case pkg.NoTests:
+			fmt.Fprintf(&builder, "\n?     %s [no test files]", name)
+		case pkg.Action == "fail":
+			fmt.Fprintf(&builder, "\nFAIL  %s %.2fs", name, pkg.Elapsed)
+		case pkg.Action == "pass":
+			fmt.Fprintf(&builder, "\nok    %s %.2fs", name, pkg.Elapsed)
+		case pkg.Action == "skip":
+			fmt.Fprintf(&builder, "\nskip  %s", name)
+		default:
+			fmt.Fprintf(&builder, "\n...   %s [did not finish]", name)
+		}
+	}
+	return builder.String()
+}
+
+// Excerpt drops the lines go test adds around each test and keeps the end
+// of the output, where assertion messages and panics usually are.
+func Excerpt(output []string) []string {
+	lines := make([]string, 0, len(output))
+	for _, line := range output {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "=== ") || strings.HasPrefix(trimmed, "--- PASS") ||
+			strings.HasPrefix(trimmed, "--- FAIL") || strings.HasPrefix(trimmed, "--- SKIP") ||
+			trimmed == "FAIL" || trimmed == "PASS" || strings.HasPrefix(trimmed, "FAIL\t") || strings.HasPrefix(trimmed, "ok  \t") {
+			continue
+		}
+		lines = append(lines, strings.TrimPrefix(line, "    "))
+	}
+	return tail(lines)
+}
+
+func tail(lines []string) []string {
+	if len(lines) > MaxExcerptLines {
+		omitted := len(lines) - MaxExcerptLines
+		lines = append([]string{fmt.Sprintf("... (%d earlier lines omitted)", omitted)}, lines[omitted:]...)
+	}
+	result := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if len(line) > MaxLineLength {
+			line = line[:MaxLineLength] + "..."
+		}
+		result = append(result, line)
+	}
+	return result
+}
+
+// FindLocation returns the file:line where the test failed. That is the
+// last location printed by t.Error or t.Fatal, or for a panic the first
+// _test.go frame of the stack.
+func FindLocation(lines []string) string {
+	panicked := false
+	location := ""
+	for _, line := range lines {
+		if strings.HasPrefix(line, "panic: ") {
+			panicked = true
+			location = ""
+			continue
+		}
+		match := locationPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		if panicked {
+			if strings.HasSuffix(match[1], "_test.go") && location == "" {
+				location = match[1] + ":" + match[2]
+			}
+			continue
+		}
+		location = match[1] + ":" + match[2]
+	}
+	return location
+}
+
+// ModuleLocate resolves locations of packages inside the module at root,
+// relative to base.
+func ModuleLocate(root string, modulePath string, base string) Locate {
+	return func(pkg string, location string) string {
+		if filepath.IsAbs(location) || (pkg != modulePath && !strings.HasPrefix(pkg, modulePath+"/")) {
+			return location
+		}
+		dir := filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(strings.TrimPrefix(pkg, modulePath), "/")))
+		relative, err := filepath.Rel(base, filepath.Join(dir, location))
+		if err != nil {
+			return location
+		}
+		return relative
+	}
+}
@@ -0,0 +1,141 @@
+package runtests
+
+import (
+	"DevCode/tools"
+	"DevCode/tools/gosymbols"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	RunTestsDescription = `Runs Go tests with go test -json and returns a short summary instead of the
+  raw output.\n\nUsage:\n- The summary has the passed, failed and skipped counts, the elapsed
+  time, every failing test with the file:line where it failed and an excerpt of its output,
+  build errors, and one line per package\n- Use packages to choose what to test, for example
+  ["./store"] or ["./..."] (the default), and run to select tests by regular expression,
+  for example "TestAdd$" or "TestStore/empty"\n- Prefer this tool over running go test
+  through Bash, since it keeps the output small\n- After fixing a failing test, run it again
+  with the run filter to confirm the fix`
+	Name = "RunTests"
+
+	DefaultTimeout = 5 * time.Minute
+	MaxTimeout     = 10 * time.Minute
+)
+
+type Tool struct {
+}
+
+func (*Tool) Name() string {
+	return Name
+}
+
+func (*Tool) Description() string {
+	return RunTestsDescription
+}
+
+func (instance *Tool) Handler() mcp.ToolHandlerFor[Input, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[Input]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		dir := input.Path
+		if dir == "" {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return nil, fmt.Errorf("fail to get working directory: %w", err)
+			}
+			dir = cwd
+		}
+		if !filepath.IsAbs(dir) {
+			return nil, fmt.Errorf("invalid path format: %s", input.Path)
+		}
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("directory not found: %s", dir)
+		}
+		args, err := Arguments(input)
+		if err != nil {
+			return nil, err
+		}
+		timeout := DefaultTimeout
+		if input.Timeout > 0 {
+			timeout = min(time.Duration(input.Timeout)*time.Millisecond, MaxTimeout)
+		}
+		report, elapsed, timedOut, err := Run(ctx, dir, args, timeout)
+		if err != nil {
+			return nil, err
+		}
+		var locate Locate
+		if root, modulePath, err := gosymbols.FindModule(dir); err == nil {
+			locate = ModuleLocate(root, modulePath, dir)
+		}
+		return tools.TextReturn(report.Summary(elapsed, timedOut, locate))
+	}
+}
+
+// Arguments builds the go test command line. Package patterns and the run
+// filter are checked so that they cannot be read as other flags.
+func Arguments(input Input) ([]string, error) {
+	args := []string{"test", "-json"}
+	if input.Run != "" {
+		if strings.ContainsAny(input.Run, "\n\x00") {
+			return nil, fmt.Errorf("invalid run filter: %q", input.Run)
+		}
+		args = append(args, "-run", input.Run)
+	}
+	packages := input.Packages
+	if len(packages) == 0 {
+		packages = []string{"./..."}
+	}
+	for _, pattern := range packages {
+		if pattern == "" || strings.HasPrefix(pattern, "-") || strings.ContainsAny(pattern, " \t\n\x00") {
+			return nil, fmt.Errorf("invalid package pattern: %q", pattern)
+		}
+	}
+	return append(args, packages...), nil
+}
+
+// Run executes go test in dir and parses its output. A run that exceeds
+// timeout is stopped and reported with the results collected so far.
+func Run(ctx context.Context, dir string, args []string, timeout time.Duration) (*Report, time.Duration, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = dir
+	cmd.WaitDelay = 5 * time.Second
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	start := time.Now()
+	err := cmd.Run()
+	elapsed := time.Since(start)
+	timedOut := errors.Is(ctx.Err(), context.DeadlineExceeded)
+	if err != nil && !timedOut {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, elapsed, false, fmt.Errorf("fail to run go test: %w", err)
+		}
+	}
+	report, parseErr := Parse(&stdout)
+	if parseErr != nil {
+		return nil, elapsed, timedOut, fmt.Errorf("fail to read go test output: %w", parseErr)
+	}
+	for _, line := range strings.Split(stderr.String(), "\n") {
+		if strings.TrimSpace(line) != "" {
+			report.Other = append(report.Other, line)
+		}
+	}
+	if err != nil && !timedOut {
+		if len(report.Packages) == 0 && len(report.Other) == 0 {
+			return nil, elapsed, false, fmt.Errorf("go test failed: %w", err)
+		}
+		report.ExitFailed = true
+	}
+	return report, elapsed, timedOut, nil
+}
@@ -0,0 +1,162 @@
+package runtests
+
+import (
+	"DevCode/tools"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var moduleFiles = map[string]string{
+	"go.mod": "module example.com/calc\n\ngo 1.22\n",
+	"calc/calc.go": `package calc
+
+func Add(a, b int) int { return a + b }
+
+func Sub(a, b int) int { return a + b }
+`,
+	"calc/calc_test.go": `package calc
+
+import "testing"
+
+func TestAdd(t *testing.T) {
+	if Add(1, 2) != 3 {
+		t.Fatal("Add is broken")
+	}
+}
+
+func TestSub(t *testing.T) {
+	cases := map[string][2]int{"same": {2, 2}, "bigger": {5, 3}}
+	for name, values := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Log("checking", name)
+			if got := Sub(values[0], values[1]); got != values[0]-values[1] {
+				t.Errorf("Sub(%d, %d) = %d", values[0], values[1], got)
+			}
+		})
+	}
+}
+
+func TestLater(t *testing.T) {
+	t.Skip("not ready")
+}
+`,
+	"format/format.go": `package format
+
+func Name(name string) string { return "<" + name + ">" }
+`,
+	"format/format_test.go": `package format
+
+import "testing"
+
+func TestName(t *testing.T) {
+	if Name("a") != "<a>" {
+		t.Fatal("wrong")
+	}
+}
+`,
+	"docs/docs.go": "package docs\n",
+}
+
+func newModule(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range moduleFiles {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return root
+}
+
+func TestRunTests(t *testing.T) {
+	if testing.Short() {
+		t.Skip("go test를 실행하므로 short 모드에서는 건너뛴다")
+	}
+	root := newModule(t)
+
+	output, err := tools.CallText(context.Background(), &Tool{}, Input{Path: root})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(output, "FAIL: 2 passed, 2 failed, 1 skipped in "), output)
+	assert.Contains(t, output, "(3 packages)")
+	assert.Contains(t, output, "--- FAIL: TestSub/same (")
+	assert.Contains(t, output, "--- FAIL: TestSub/bigger (")
+	assert.Contains(t, output, "at calc/calc_test.go:17\n")
+	assert.Contains(t, output, "calc_test.go:17: Sub(5, 3) = 8")
+	assert.Contains(t, output, "calc_test.go:15: checking bigger")
+	assert.NotContains(t, output, "=== RUN")
+	assert.NotContains(t, output, "--- FAIL: TestSub (")
+	assert.Contains(t, output, "\nFAIL  example.com/calc/calc ")
+	assert.Contains(t, output, "\n?     example.com/calc/docs [no test files]")
+	assert.Contains(t, output, "\nok    example.com/calc/format ")
+
+	// run 필터와 패키지 지정
+	output, err = tools.CallText(context.Background(), &Tool{}, Input{Path: root, Packages: []string{"./calc"}, Run: "TestAdd"})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(output, "PASS: 1 passed, 0 failed, 0 skipped in "), output)
+	assert.Contains(t, output, "(1 packages)")
+}
+
+func TestRunTestsBuildFailure(t *testing.T) {
+	if testing.Short() {
+		t.Skip("go test를 실행하므로 short 모드에서는 건너뛴다")
+	}
+	root := newModule(t)
+	require.NoError(t, os.WriteFile(filepath.Join(root, "format", "format.go"), []byte("package format\n\nfunc Name(name string) string { return missing }\n"), 0644))
+
+	output, err := tools.CallText(context.Background(), &Tool{}, Input{Path: root, Packages: []string{"./format"}})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(output, "FAIL: 0 passed, 0 failed, 0 skipped"), output)
+	assert.Contains(t, output, "undefined: missing")
+	assert.Contains(t, output, "FAIL  example.com/calc/format [build failed]")
+}
+
+func TestRunTestsErrors(t *testing.T) {
+	_, err := tools.CallText(context.Background(), &Tool{}, Input{Path: "relative/dir"})
+	assert.ErrorContains(t, err, "invalid path format")
+
+	_, err = tools.CallText(context.Background(), &Tool{}, Input{Path: filepath.Join(t.TempDir(), "missing")})
+	assert.ErrorContains(t, err, "directory not found")
+
+	_, err = tools.CallText(context.Background(), &Tool{}, Input{Path: t.TempDir(), Packages: []string{"-exec=sh"}})
+	assert.ErrorContains(t, err, `invalid package pattern: "-exec=sh"`)
+}
+
+func TestSummaryTimeout(t *testing.T) {
+	report, err := Parse(strings.NewReader(strings.Join([]string{
+		`{"Action":"start","Package":"example.com/slow"}`,
+		`{"Action":"run","Package":"example.com/slow","Test":"TestQuick"}`,
+		`{"Action":"pass","Package":"example.com/slow","Test":"TestQuick","Elapsed":0.01}`,
+		`{"Action":"run","Package":"example.com/slow","Test":"TestForever"}`,
+		`{"Action":"output","Package":"example.com/slow","Test":"TestForever","Output":"    slow_test.go:9: waiting\n"}`,
+		`not json`,
+	}, "\n")))
+	require.NoError(t, err)
+
+	output := report.Summary(2*time.Second, true, nil)
+	assert.True(t, strings.HasPrefix(output, "FAIL: 1 passed, 0 failed, 0 skipped in 2.00s (1 packages)\nThe test run was stopped because it exceeded the timeout. 1 tests were still running."), output)
+	assert.Contains(t, output, "--- DID NOT FINISH: TestForever (0.00s) example.com/slow\n    slow_test.go:9: waiting")
+	assert.Contains(t, output, "Other output:\n    not json")
+	assert.Contains(t, output, "...   example.com/slow [did not finish]")
+}
+
+func TestExcerpt(t *testing.T) {
+	lines := []string{"=== RUN   TestX", "    x_test.go:3: first"}
+	for index := 0; index < MaxExcerptLines+5; index++ {
+		lines = append(lines, "    noise")
+	}
+	lines = append(lines, "--- FAIL: TestX (0.00s)")
+	excerpt := Excerpt(lines)
+	assert.Len(t, excerpt, MaxExcerptLines+1)
+	assert.Equal(t, "... (6 earlier lines omitted)", excerpt[0])
+
+	assert.Equal(t, "x_test.go:5", FindLocation([]string{"x_test.go:3: checking", "x_test.go:5: got 2"}))
+	assert.Equal(t, "/src/x/x_test.go:7", FindLocation([]string{"panic: boom", "\t/usr/lib/go/src/runtime/panic.go:115 +0x1", "\t/src/x/x_test.go:7 +0x2", "\t/src/x/x_test.go:20 +0x2"}))
+	assert.Equal(t, "", FindLocation([]string{"no location"}))
+}
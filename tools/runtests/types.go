@@ -0,0 +1,41 @@
+package runtests
+
+import (
+	"time"
+)
+
+type Input struct {
+	Path     string   `json:"path,omitempty" jsonschema:"description:The absolute path of the directory to run go test in. Defaults to the current working directory"`
+	Packages []string `json:"packages,omitempty" jsonschema:"description:Package patterns to test, relative to path, such as ./... or ./store. Defaults to ./..."`
+	Run      string   `json:"run,omitempty" jsonschema:"description:Only run tests matching this regular expression, as with go test -run"`
+	Timeout  int      `json:"timeout,omitempty" jsonschema:"description:Optional timeout in milliseconds"`
+}
+
+// Event is one line of go test -json output.
+type Event struct {
+	Time       time.Time `json:"Time"`
+	Action     string    `json:"Action"`
+	Package    string    `json:"Package"`
+	ImportPath string    `json:"ImportPath"`
+	Test       string    `json:"Test"`
+	Elapsed    float64   `json:"Elapsed"`
+	Output     string    `json:"Output"`
+}
+
+type TestResult struct {
+	Package string
+	Name    string
+	Action  string
+	Elapsed float64
+	Output  []string
+}
+
+type PackageResult struct {
+	Name        string
+	Action      string
+	Elapsed     float64
+	Output      []string
+	NoTests     bool
+	BuildFailed bool
+	Tests       []*TestResult
+}
@@ -0,0 +1,92 @@
+package semantic
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+)
+
+// Split cuts a file into sections of at most maxLines lines. Go files are cut
+// at top-level declarations, with imports left out; everything else, and Go
+// files that do not parse, is cut into runs of paragraphs separated by blank
+// lines.
+func Split(path string, source []byte, maxLines int) []Section {
+	lines := strings.Split(string(source), "\n")
+	if filepath.Ext(path) == ".go" {
+		if sections, ok := goSections(path, source, lines, maxLines); ok {
+			return sections
+		}
+	}
+	return paragraphSections(lines, maxLines)
+}
+
+func goSections(path string, source []byte, lines []string, maxLines int) ([]Section, bool) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, source, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, false
+	}
+	sections := make([]Section, 0, len(file.Decls))
+	for _, decl := range file.Decls {
+		var doc *ast.CommentGroup
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			doc = decl.Doc
+		case *ast.GenDecl:
+			if decl.Tok == token.IMPORT {
+				continue
+			}
+			doc = decl.Doc
+		}
+		start := fset.Position(decl.Pos()).Line
+		if doc != nil {
+			start = fset.Position(doc.Pos()).Line
+		}
+		sections = append(sections, window(lines, start, fset.Position(decl.End()).Line, maxLines)...)
+	}
+	return sections, true
+}
+
+func paragraphSections(lines []string, maxLines int) []Section {
+	sections := make([]Section, 0, len(lines)/maxLines+1)
+	start, end := 0, 0
+	for index := 0; index < len(lines); {
+		if strings.TrimSpace(lines[index]) == "" {
+			index++
+			continue
+		}
+		paragraphStart := index + 1
+		for index < len(lines) && strings.TrimSpace(lines[index]) != "" {
+			index++
+		}
+		if start > 0 && index-start+1 > maxLines {
+			sections = append(sections, window(lines, start, end, maxLines)...)
+			start = 0
+		}
+		if start == 0 {
+			start = paragraphStart
+		}
+		end = index
+	}
+	if start > 0 {
+		sections = append(sections, window(lines, start, end, maxLines)...)
+	}
+	return sections
+}
+
+// window cuts lines start to end, counted from 1, into sections of at most
+// maxLines lines.
+func window(lines []string, start int, end int, maxLines int) []Section {
+	sections := make([]Section, 0, (end-start)/maxLines+1)
+	for from := start; from <= end; from += maxLines {
+		to := min(from+maxLines-1, end)
+		text := strings.Join(lines[from-1:to], "\n")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		sections = append(sections, Section{StartLine: from, EndLine: to, Text: text})
+	}
+	return sections
+}
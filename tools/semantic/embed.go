@@ -0,0 +1,61 @@
+package semantic
+
+import (
+	"DevCode/constants"
+	"DevCode/dto"
+	"DevCode/events"
+	"DevCode/tools"
+	"DevCode/types"
+	"context"
+	"time"
+)
+
+// Embedder turns texts into vectors with an embedding model.
+type Embedder interface {
+	Embed(ctx context.Context, model string, inputs []string) ([][]float32, error)
+}
+
+// NewBusEmbedder returns an Embedder that asks OllamaModule for embeddings
+// over the event bus, so they come from the same client as the chat.
+func NewBusEmbedder(bus *events.EventBus) *BusEmbedder {
+	embedder := &BusEmbedder{
+		bus:     bus,
+		waiting: tools.NewWaiter[types.CreateID, dto.EmbedResultData](),
+	}
+	events.Subscribe(bus, bus.EmbedResultEvent, constants.SemanticSearchTool, func(event events.Event[dto.EmbedResultData]) {
+		embedder.Complete(event.Data)
+	})
+	return embedder
+}
+
+type BusEmbedder struct {
+	bus     *events.EventBus
+	waiting *tools.Waiter[types.CreateID, dto.EmbedResultData]
+}
+
+func (instance *BusEmbedder) Embed(ctx context.Context, model string, inputs []string) ([][]float32, error) {
+	id := types.NewCreateID()
+	data, err := instance.waiting.Wait(ctx, id, func() {
+		events.Publish(instance.bus, instance.bus.EmbedRequestEvent, events.Event[dto.EmbedRequestData]{
+			Data: dto.EmbedRequestData{
+				ID:     id,
+				Model:  model,
+				Inputs: inputs,
+			},
+			TimeStamp: time.Now(),
+			Source:    constants.SemanticSearchTool,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if data.Error != nil {
+		return nil, data.Error
+	}
+	return data.Embeddings, nil
+}
+
+// Complete hands embeddings to the Embed call waiting for them.
+func (instance *BusEmbedder) Complete(data dto.EmbedResultData) {
+	instance.waiting.Complete(data.ID, data)
+}
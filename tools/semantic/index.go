@@ -0,0 +1,342 @@
+package semantic
+
+import (
+	"DevCode/config"
+	"DevCode/tools/gitignore"
+	"DevCode/tools/grep"
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	IndexVersion = 1
+	IndexFile    = "index.gob"
+
+	MaxFileSize = 256 * 1024
+	// MaxInputBytes caps the text sent for one chunk, which keeps long
+	// lines of data from overflowing the embedding model's context.
+	MaxInputBytes = 4096
+)
+
+var (
+	SkipDirectories = []string{"vendor", "node_modules"}
+	SkipFiles       = []string{"go.sum", "package-lock.json", "yarn.lock", "pnpm-lock.yaml", "Cargo.lock"}
+
+	errFileLimit = errors.New("file limit reached")
+)
+
+type indexData struct {
+	Version int
+	Model   string
+	Files   map[string]*FileEntry
+}
+
+// Index keeps one vector per chunk of every text file under root in a gob
+// file in dir. Files are keyed by their slash-separated path relative to root
+// and are embedded again only when the hash of their content changes.
+type Index struct {
+	root     string
+	dir      string
+	config   config.IndexConfig
+	embedder Embedder
+	data     *indexData
+	mutex    sync.Mutex
+}
+
+func NewIndex(root string, dir string, config config.IndexConfig, embedder Embedder) *Index {
+	return &Index{root: root, dir: dir, config: config, embedder: embedder}
+}
+
+type pendingFile struct {
+	path      string
+	hash      string
+	sections  []Section
+	vectors   [][]float32
+	remaining int
+}
+
+// Update brings the entries under scope, a directory inside root, up to date
+// with the files on disk: changed and new files are embedded in batches and
+// deleted ones are dropped. Files embedded before an error are kept.
+func (instance *Index) Update(ctx context.Context, scope string) (Stats, error) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	stats := Stats{}
+	reset := instance.load()
+	prefix, err := instance.prefix(scope)
+	if err != nil {
+		return stats, err
+	}
+
+	seen := make(map[string]bool)
+	pending := make([]*pendingFile, 0, 16)
+	err = gitignore.Walk(ctx, scope, gitignore.ForPath(scope), func(current string, entry fs.DirEntry) error {
+		if entry.IsDir() {
+			name := entry.Name()
+			if current != scope && (strings.HasPrefix(name, ".") || slices.Contains(SkipDirectories, name)) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() || slices.Contains(SkipFiles, entry.Name()) {
+			return nil
+		}
+		if len(seen) >= instance.config.MaxFiles {
+			stats.Truncated = true
+			return errFileLimit
+		}
+		info, err := entry.Info()
+		if err != nil || info.Size() > MaxFileSize {
+			return nil
+		}
+		source, err := os.ReadFile(current)
+		if err != nil || grep.IsBinary(source) {
+			return nil
+		}
+		relative, err := filepath.Rel(instance.root, current)
+		if err != nil {
+			return nil
+		}
+		relative = filepath.ToSlash(relative)
+		seen[relative] = true
+		sum := sha256.Sum256(source)
+		hash := hex.EncodeToString(sum[:])
+		if existing, ok := instance.data.Files[relative]; ok && existing.Hash == hash {
+			return nil
+		}
+		sections := Split(current, source, instance.config.ChunkLines)
+		pending = append(pending, &pendingFile{
+			path:      relative,
+			hash:      hash,
+			sections:  sections,
+			vectors:   make([][]float32, len(sections)),
+			remaining: len(sections),
+		})
+		return nil
+	})
+	if err != nil && !errors.Is(err, errFileLimit) {
+		return stats, err
+	}
+
+	// a walk cut short by MaxFiles has not seen every file, so nothing is
+	// dropped for being missing
+	if !stats.Truncated {
+		for path := range instance.data.Files {
+			if inScope(path, prefix) && !seen[path] {
+				delete(instance.data.Files, path)
+				stats.Removed++
+			}
+		}
+	}
+
+	commit := func(file *pendingFile) {
+		chunks := make([]Chunk, 0, len(file.sections))
+		for index, section := range file.sections {
+			chunks = append(chunks, Chunk{StartLine: section.StartLine, EndLine: section.EndLine, Vector: file.vectors[index]})
+		}
+		instance.data.Files[file.path] = &FileEntry{Hash: file.hash, Chunks: chunks}
+		stats.Embedded++
+	}
+	type item struct {
+		file  *pendingFile
+		index int
+	}
+	items := make([]item, 0, 64)
+	for _, file := range pending {
+		if file.remaining == 0 {
+			commit(file)
+		}
+		for index := range file.sections {
+			items = append(items, item{file: file, index: index})
+		}
+	}
+	for start := 0; start < len(items); start += instance.config.BatchSize {
+		batch := items[start:min(start+instance.config.BatchSize, len(items))]
+		inputs := make([]string, 0, len(batch))
+		for _, current := range batch {
+			inputs = append(inputs, embedInput(current.file.path, current.file.sections[current.index].Text))
+		}
+		vectors, err := instance.embedder.Embed(ctx, instance.config.Model, inputs)
+		if err == nil && len(vectors) != len(inputs) {
+			err = fmt.Errorf("embedding model %s returned %d vectors for %d inputs", instance.config.Model, len(vectors), len(inputs))
+		}
+		if err != nil {
+			if stats.Embedded > 0 || stats.Removed > 0 {
+				instance.save()
+			}
+			return stats, err
+		}
+		for index, current := range batch {
+			current.file.vectors[current.index] = normalize(vectors[index])
+			current.file.remaining--
+			if current.file.remaining == 0 {
+				commit(current.file)
+			}
+		}
+	}
+
+	for path, entry := range instance.data.Files {
+		if inScope(path, prefix) {
+			stats.Files++
+			stats.Chunks += len(entry.Chunks)
+		}
+	}
+	if reset || stats.Embedded > 0 || stats.Removed > 0 {
+		if err := instance.save(); err != nil {
+			return stats, err
+		}
+	}
+	return stats, nil
+}
+
+// Search embeds query and returns the topK chunks under scope whose vectors
+// are closest to it by cosine similarity. Update should run first.
+func (instance *Index) Search(ctx context.Context, scope string, query string, topK int) ([]Result, error) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	instance.load()
+	prefix, err := instance.prefix(scope)
+	if err != nil {
+		return nil, err
+	}
+	vectors, err := instance.embedder.Embed(ctx, instance.config.Model, []string{query})
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("embedding model %s returned %d vectors for 1 input", instance.config.Model, len(vectors))
+	}
+	vector := normalize(vectors[0])
+
+	results := make([]Result, 0, 256)
+	for path, entry := range instance.data.Files {
+		if !inScope(path, prefix) {
+			continue
+		}
+		for _, chunk := range entry.Chunks {
+			results = append(results, Result{
+				Path:      path,
+				StartLine: chunk.StartLine,
+				EndLine:   chunk.EndLine,
+				Score:     dot(vector, chunk.Vector),
+			})
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].Path != results[j].Path {
+			return results[i].Path < results[j].Path
+		}
+		return results[i].StartLine < results[j].StartLine
+	})
+	return results[:min(topK, len(results))], nil
+}
+
+// load reads the index file once. A missing or unreadable file, or one built
+// with another model, starts an empty index; load reports whether it did so
+// for an existing file.
+func (instance *Index) load() bool {
+	if instance.data != nil {
+		return false
+	}
+	empty := &indexData{Version: IndexVersion, Model: instance.config.Model, Files: make(map[string]*FileEntry)}
+	instance.data = empty
+	file, err := os.Open(filepath.Join(instance.dir, IndexFile))
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+	data := &indexData{}
+	if err := gob.NewDecoder(file).Decode(data); err != nil || data.Version != IndexVersion || data.Model != instance.config.Model || data.Files == nil {
+		return true
+	}
+	instance.data = data
+	return false
+}
+
+// save writes the index to a temporary file and renames it over the old one,
+// so a crash never leaves a half-written index behind.
+func (instance *Index) save() error {
+	if err := os.MkdirAll(instance.dir, 0755); err != nil {
+		return fmt.Errorf("fail to create index directory: %v", err)
+	}
+	file, err := os.CreateTemp(instance.dir, IndexFile+".*")
+	if err != nil {
+		return fmt.Errorf("fail to write index: %v", err)
+	}
+	defer os.Remove(file.Name())
+	if err := gob.NewEncoder(file).Encode(instance.data); err != nil {
+		file.Close()
+		return fmt.Errorf("fail to write index: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("fail to write index: %v", err)
+	}
+	if err := os.Rename(file.Name(), filepath.Join(instance.dir, IndexFile)); err != nil {
+		return fmt.Errorf("fail to write index: %v", err)
+	}
+	return nil
+}
+
+func (instance *Index) prefix(scope string) (string, error) {
+	relative, err := filepath.Rel(instance.root, scope)
+	if err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path must be inside the workspace %s: %s", instance.root, scope)
+	}
+	if relative == "." {
+		return "", nil
+	}
+	return filepath.ToSlash(relative) + "/", nil
+}
+
+func inScope(path string, prefix string) bool {
+	return strings.HasPrefix(path, prefix)
+}
+
+// embedInput puts the path in front of the text so that file and directory
+// names take part in the match.
+func embedInput(path string, text string) string {
+	input := path + "\n" + text
+	if len(input) > MaxInputBytes {
+		return strings.ToValidUTF8(input[:MaxInputBytes], "")
+	}
+	return input
+}
+
+func normalize(vector []float32) []float32 {
+	var sum float64
+	for _, value := range vector {
+		sum += float64(value) * float64(value)
+	}
+	if sum == 0 {
+		return vector
+	}
+	norm := float32(math.Sqrt(sum))
+	normalized := make([]float32, len(vector))
+	for index, value := range vector {
+		normalized[index] = value / norm
+	}
+	return normalized
+}
+
+func dot(a []float32, b []float32) float32 {
+	var sum float32
+	for index := range min(len(a), len(b)) {
+		sum += a[index] * b[index]
+	}
+	return sum
+}
@@ -0,0 +1,138 @@
+package semantic
+
+import (
+	"DevCode/config"
+	"DevCode/constants"
+	"DevCode/events"
+	"DevCode/tools"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	SemanticSearchDescription = `Finds the functions, types and passages of text whose meaning is closest to a
+  natural-language query, using embeddings of the workspace.\n\nUsage:\n- Use this when you know
+  what code does but not what it is called, such as "where expired sessions are cleaned up";
+  use Grep when you know a name or an exact string\n- Files are cut into function- or
+  paragraph-sized chunks and embedded with an Ollama embedding model; the index is kept under
+  .devcode/index and only changed files are embedded again, so the first search in a large
+  workspace is slower than later ones\n- Results are ranked by similarity and show the path,
+  the lines and the start of each chunk; Read the file for the full context\n- Pass path to
+  search only part of the workspace\n- Files ignored by .gitignore, hidden directories, vendor,
+  node_modules, binary files and files over 256 KB are not indexed`
+	Name = "SemanticSearch"
+
+	IndexDirectory  = "index"
+	MaxSnippetLines = 12
+)
+
+func NewTool(embedder Embedder, config config.IndexConfig, root string) *Tool {
+	return &Tool{
+		config: config,
+		root:   root,
+		index:  NewIndex(root, filepath.Join(root, constants.DataDirectory, IndexDirectory), config, embedder),
+	}
+}
+
+// NewBusTool returns a Tool that embeds through OllamaModule.
+func NewBusTool(bus *events.EventBus, config config.IndexConfig, root string) *Tool {
+	return NewTool(NewBusEmbedder(bus), config, root)
+}
+
+type Tool struct {
+	config config.IndexConfig
+	root   string
+	index  *Index
+}
+
+func (*Tool) Name() string {
+	return Name
+}
+
+func (*Tool) Description() string {
+	return SemanticSearchDescription
+}
+
+func (instance *Tool) Handler() mcp.ToolHandlerFor[Input, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[Input]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		query := strings.TrimSpace(input.Query)
+		if query == "" {
+			return nil, fmt.Errorf("query must not be empty")
+		}
+		scope := input.Path
+		if scope == "" {
+			scope = instance.root
+		}
+		if !filepath.IsAbs(scope) {
+			return nil, fmt.Errorf("invalid path format: %s", scope)
+		}
+		scope = filepath.Clean(scope)
+		if info, err := os.Stat(scope); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("directory not found: %s", scope)
+		}
+		topK := input.TopK
+		if topK <= 0 {
+			topK = instance.config.TopK
+		}
+
+		stats, err := instance.index.Update(ctx, scope)
+		if err != nil {
+			return nil, fmt.Errorf("fail to update index: %v", err)
+		}
+		results, err := instance.index.Search(ctx, scope, query, topK)
+		if err != nil {
+			return nil, fmt.Errorf("fail to search index: %v", err)
+		}
+		return tools.TextReturn(instance.Render(query, stats, results))
+	}
+}
+
+// Render lists results with the first lines of each chunk as they are on
+// disk now.
+func (instance *Tool) Render(query string, stats Stats, results []Result) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%s for %q (%s, %s indexed", plural(len(results), "result", "results"), query,
+		plural(stats.Files, "file", "files"), plural(stats.Chunks, "chunk", "chunks"))
+	if stats.Embedded > 0 {
+		fmt.Fprintf(&builder, ", %d embedded now", stats.Embedded)
+	}
+	builder.WriteString(")\n")
+	for rank, result := range results {
+		fmt.Fprintf(&builder, "\n%d. %s:%d-%d (score %.3f)\n", rank+1, filepath.Join(instance.root, result.Path), result.StartLine, result.EndLine, result.Score)
+		builder.WriteString(instance.snippet(result))
+	}
+	if stats.Truncated {
+		fmt.Fprintf(&builder, "\n(Only the first %d files were indexed. Pass a sub-directory as path to search the rest.)\n", instance.config.MaxFiles)
+	}
+	return strings.TrimRight(builder.String(), "\n")
+}
+
+func (instance *Tool) snippet(result Result) string {
+	data, err := os.ReadFile(filepath.Join(instance.root, filepath.FromSlash(result.Path)))
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(string(data), "\n")
+	end := min(result.EndLine, len(lines), result.StartLine+MaxSnippetLines-1)
+	var builder strings.Builder
+	for number := result.StartLine; number <= end; number++ {
+		fmt.Fprintf(&builder, "%6d→\t%s\n", number, lines[number-1])
+	}
+	if end < result.EndLine {
+		fmt.Fprintf(&builder, "       ... %s\n", plural(result.EndLine-end, "more line", "more lines"))
+	}
+	return builder.String()
+}
+
+func plural(count int, one string, many string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, one)
+	}
+	return fmt.Sprintf("%d %s", count, many)
+}
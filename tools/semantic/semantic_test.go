@@ -0,0 +1,377 @@
+package semantic
+
+import (
+	"DevCode/config"
+	"DevCode/constants"
+	"DevCode/dto"
+	"DevCode/events"
+	"DevCode/tools"
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ollama/ollama/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const TestModule = constants.Source(999)
+
+var word = regexp.MustCompile(`[a-z]+`)
+
+// stubEmbed는 /api/embed만 흉내내는 가짜 Ollama 서버로, 단어 주머니를 해시한 벡터를 돌려준다
+type stubEmbed struct {
+	mutex  sync.Mutex
+	inputs []string
+	models []string
+	fail   bool
+}
+
+func (instance *stubEmbed) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.URL.Path != "/api/embed" {
+		http.NotFound(writer, request)
+		return
+	}
+	var embed api.EmbedRequest
+	if err := json.NewDecoder(request.Body).Decode(&embed); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	instance.mutex.Lock()
+	fail := instance.fail
+	instance.models = append(instance.models, embed.Model)
+	instance.mutex.Unlock()
+	if fail {
+		writer.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(writer).Encode(map[string]string{"error": "model not found"})
+		return
+	}
+	response := api.EmbedResponse{Model: embed.Model}
+	for _, input := range embed.Input.([]any) {
+		text := input.(string)
+		instance.mutex.Lock()
+		instance.inputs = append(instance.inputs, text)
+		instance.mutex.Unlock()
+		response.Embeddings = append(response.Embeddings, bagOfWords(text))
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(writer).Encode(response)
+}
+
+func (instance *stubEmbed) Inputs() []string {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	return append([]string(nil), instance.inputs...)
+}
+
+func (instance *stubEmbed) Reset() {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	instance.inputs = nil
+	instance.models = nil
+}
+
+func bagOfWords(text string) []float32 {
+	vector := make([]float32, 64)
+	for _, token := range word.FindAllString(strings.ToLower(text), -1) {
+		hash := fnv.New32a()
+		hash.Write([]byte(token))
+		vector[hash.Sum32()%64]++
+	}
+	return vector
+}
+
+// clientEmbedder는 OllamaModule처럼 api.Client로 임베딩을 요청한다
+type clientEmbedder struct {
+	client *api.Client
+}
+
+func (instance *clientEmbedder) Embed(ctx context.Context, model string, inputs []string) ([][]float32, error) {
+	response, err := instance.client.Embed(ctx, &api.EmbedRequest{Model: model, Input: inputs})
+	if err != nil {
+		return nil, err
+	}
+	return response.Embeddings, nil
+}
+
+func newEmbedder(t *testing.T, stub *stubEmbed) Embedder {
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+	serverUrl, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	return &clientEmbedder{client: api.NewClient(serverUrl, http.DefaultClient)}
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func testConfig() config.IndexConfig {
+	return config.IndexConfig{Model: "embed-model", TopK: 3, ChunkLines: 60, BatchSize: 2, MaxFiles: 100}
+}
+
+const sessionSource = `package session
+
+import "time"
+
+// Expire removes sessions whose deadline has passed.
+func Expire(sessions map[string]time.Time) {
+	for id, deadline := range sessions {
+		if deadline.Before(time.Now()) {
+			delete(sessions, id)
+		}
+	}
+}
+
+type Store struct{}
+`
+
+func createWorkspace(t *testing.T) string {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "session", "session.go"), sessionSource)
+	writeFile(t, filepath.Join(root, "render", "html.go"), "package render\n\n// Page writes html markup for a template.\nfunc Page() string { return \"<html>\" }\n")
+	writeFile(t, filepath.Join(root, "README.md"), "# Project\n\nA small web server.\n\nRun it with go run.\n")
+	writeFile(t, filepath.Join(root, ".gitignore"), "generated/\n")
+	writeFile(t, filepath.Join(root, "generated", "gen.go"), "package generated\n")
+	writeFile(t, filepath.Join(root, "node_modules", "lib", "index.js"), "export function x() {}\n")
+	writeFile(t, filepath.Join(root, "go.sum"), "example.com v1.0.0 h1:abc\n")
+	writeFile(t, filepath.Join(root, "image.png"), "\x89PNG\x00\x00")
+	return root
+}
+
+func TestSplitGo(t *testing.T) {
+	sections := Split("session.go", []byte(sessionSource), 60)
+	require.Len(t, sections, 2)
+	// import는 빠지고, 함수는 doc comment와 함께 잘린다
+	assert.Equal(t, 5, sections[0].StartLine)
+	assert.Equal(t, 12, sections[0].EndLine)
+	assert.True(t, strings.HasPrefix(sections[0].Text, "// Expire removes sessions"))
+	assert.Equal(t, Section{StartLine: 14, EndLine: 14, Text: "type Store struct{}"}, sections[1])
+
+	// 긴 선언은 maxLines 단위로 나뉜다
+	sections = Split("session.go", []byte(sessionSource), 3)
+	assert.Equal(t, []int{5, 8, 11, 14}, startLines(sections))
+}
+
+func TestSplitParagraphs(t *testing.T) {
+	source := "one\ntwo\n\nthree\n\n\nfour\nfive\nsix\nseven\n"
+	sections := Split("notes.md", []byte(source), 4)
+	assert.Equal(t, []Section{
+		{StartLine: 1, EndLine: 4, Text: "one\ntwo\n\nthree"},
+		{StartLine: 7, EndLine: 10, Text: "four\nfive\nsix\nseven"},
+	}, sections)
+
+	// 파싱할 수 없는 Go 파일도 문단으로 나뉜다
+	sections = Split("broken.go", []byte("package\n\nfunc {\n"), 60)
+	assert.Equal(t, []Section{{StartLine: 1, EndLine: 3, Text: "package\n\nfunc {"}}, sections)
+}
+
+func startLines(sections []Section) []int {
+	lines := make([]int, 0, len(sections))
+	for _, section := range sections {
+		lines = append(lines, section.StartLine)
+	}
+	return lines
+}
+
+func TestIndexUpdateIncremental(t *testing.T) {
+	root := createWorkspace(t)
+	stub := &stubEmbed{}
+	dir := filepath.Join(root, constants.DataDirectory, IndexDirectory)
+	index := NewIndex(root, dir, testConfig(), newEmbedder(t, stub))
+
+	stats, err := index.Update(context.Background(), root)
+	require.NoError(t, err)
+	// 무시된 파일, node_modules, lock 파일, 바이너리는 색인하지 않는다
+	assert.Equal(t, Stats{Files: 4, Chunks: 5, Embedded: 4}, stats)
+	assert.Len(t, stub.Inputs(), 5)
+	for _, input := range stub.Inputs() {
+		assert.NotContains(t, input, "package generated")
+	}
+	assert.FileExists(t, filepath.Join(dir, IndexFile))
+
+	// 바뀐 파일이 없으면 다시 임베딩하지 않는다
+	stub.Reset()
+	stats, err = index.Update(context.Background(), root)
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.Embedded)
+	assert.Empty(t, stub.Inputs())
+
+	// 바뀐 파일만 다시 임베딩하고, 지워진 파일은 색인에서 빠진다
+	writeFile(t, filepath.Join(root, "render", "html.go"), "package render\n\nfunc Page() string { return \"\" }\n")
+	require.NoError(t, os.Remove(filepath.Join(root, "README.md")))
+	stats, err = index.Update(context.Background(), root)
+	require.NoError(t, err)
+	assert.Equal(t, Stats{Files: 3, Chunks: 4, Embedded: 1, Removed: 1}, stats)
+	require.Len(t, stub.Inputs(), 1)
+	assert.True(t, strings.HasPrefix(stub.Inputs()[0], "render/html.go\n"))
+
+	// 디스크에 저장된 색인을 새 인스턴스가 그대로 쓴다
+	stub.Reset()
+	reopened := NewIndex(root, dir, testConfig(), newEmbedder(t, stub))
+	stats, err = reopened.Update(context.Background(), root)
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.Embedded)
+	assert.Empty(t, stub.Inputs())
+
+	// 모델이 바뀌면 전부 다시 임베딩한다
+	changed := testConfig()
+	changed.Model = "other-model"
+	stats, err = NewIndex(root, dir, changed, newEmbedder(t, stub)).Update(context.Background(), root)
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Embedded)
+}
+
+func TestIndexUpdateScope(t *testing.T) {
+	root := createWorkspace(t)
+	stub := &stubEmbed{}
+	index := NewIndex(root, t.TempDir(), testConfig(), newEmbedder(t, stub))
+
+	stats, err := index.Update(context.Background(), filepath.Join(root, "session"))
+	require.NoError(t, err)
+	assert.Equal(t, Stats{Files: 1, Chunks: 2, Embedded: 1}, stats)
+
+	// 다른 경로를 갱신해도 범위 밖의 항목은 지우지 않는다
+	stats, err = index.Update(context.Background(), filepath.Join(root, "render"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.Removed)
+	stats, err = index.Update(context.Background(), root)
+	require.NoError(t, err)
+	assert.Equal(t, Stats{Files: 4, Chunks: 5, Embedded: 2}, stats)
+
+	_, err = index.Update(context.Background(), filepath.Dir(root))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "path must be inside the workspace")
+}
+
+func TestIndexUpdateMaxFiles(t *testing.T) {
+	root := createWorkspace(t)
+	limited := testConfig()
+	limited.MaxFiles = 2
+	index := NewIndex(root, t.TempDir(), limited, newEmbedder(t, &stubEmbed{}))
+
+	stats, err := index.Update(context.Background(), root)
+	require.NoError(t, err)
+	assert.True(t, stats.Truncated)
+	assert.Equal(t, 2, stats.Files)
+}
+
+func TestIndexSearch(t *testing.T) {
+	root := createWorkspace(t)
+	index := NewIndex(root, t.TempDir(), testConfig(), newEmbedder(t, &stubEmbed{}))
+	_, err := index.Update(context.Background(), root)
+	require.NoError(t, err)
+
+	results, err := index.Search(context.Background(), root, "remove expired sessions past their deadline", 2)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "session/session.go", results[0].Path)
+	assert.Equal(t, 5, results[0].StartLine)
+	assert.Greater(t, results[0].Score, results[1].Score)
+
+	results, err = index.Search(context.Background(), filepath.Join(root, "render"), "remove expired sessions", 5)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "render/html.go", results[0].Path)
+}
+
+func TestIndexEmbedError(t *testing.T) {
+	root := createWorkspace(t)
+	stub := &stubEmbed{fail: true}
+	index := NewIndex(root, t.TempDir(), testConfig(), newEmbedder(t, stub))
+
+	_, err := index.Update(context.Background(), root)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "model not found")
+}
+
+func TestTool(t *testing.T) {
+	root := createWorkspace(t)
+	tool := NewTool(newEmbedder(t, &stubEmbed{}), testConfig(), root)
+
+	text, err := tools.CallText(context.Background(), tool, Input{Query: "remove expired sessions past their deadline", TopK: 1})
+	require.NoError(t, err)
+	lines := strings.Split(text, "\n")
+	assert.Equal(t, `1 result for "remove expired sessions past their deadline" (4 files, 5 chunks indexed, 4 embedded now)`, lines[0])
+	assert.True(t, strings.HasPrefix(lines[2], "1. "+filepath.Join(root, "session", "session.go")+":5-12 (score "))
+	assert.Equal(t, "     5→\t// Expire removes sessions whose deadline has passed.", lines[3])
+	assert.Equal(t, "    12→\t}", lines[10])
+
+	// 두 번째 검색은 다시 임베딩하지 않는다
+	text, err = tools.CallText(context.Background(), tool, Input{Query: "html markup"})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(text, `3 results for "html markup" (4 files, 5 chunks indexed)`))
+	assert.Contains(t, text, "1. "+filepath.Join(root, "render", "html.go")+":3-4")
+
+	// 긴 chunk는 앞부분만 보여준다
+	writeFile(t, filepath.Join(root, "long.txt"), strings.Repeat("line\n", 20))
+	snippet := tool.snippet(Result{Path: "long.txt", StartLine: 1, EndLine: 20})
+	assert.True(t, strings.HasSuffix(snippet, "    12→\tline\n       ... 8 more lines\n"))
+}
+
+func TestToolRejects(t *testing.T) {
+	root := createWorkspace(t)
+	tool := NewTool(newEmbedder(t, &stubEmbed{}), testConfig(), root)
+	tests := []struct {
+		name  string
+		input Input
+		err   string
+	}{
+		{name: "empty query", input: Input{Query: "  "}, err: "query must not be empty"},
+		{name: "relative path", input: Input{Query: "a", Path: "session"}, err: "invalid path format: session"},
+		{name: "missing path", input: Input{Query: "a", Path: filepath.Join(root, "missing")}, err: "directory not found"},
+		{name: "outside workspace", input: Input{Query: "a", Path: os.TempDir()}, err: "path must be inside the workspace"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tools.CallText(context.Background(), tool, tt.input)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestBusEmbedder(t *testing.T) {
+	bus, err := events.NewEventBus(config.EventBusConfig{PoolSize: 10}, zap.NewNop())
+	require.NoError(t, err)
+	t.Cleanup(bus.Close)
+	embedder := NewBusEmbedder(bus)
+
+	// OllamaModule 대신 요청에 답한다
+	requests := make(chan dto.EmbedRequestData, 1)
+	events.Subscribe(bus, bus.EmbedRequestEvent, TestModule, func(event events.Event[dto.EmbedRequestData]) {
+		requests <- event.Data
+		events.Publish(bus, bus.EmbedResultEvent, events.Event[dto.EmbedResultData]{
+			Data:      dto.EmbedResultData{ID: event.Data.ID, Embeddings: [][]float32{{1, 2}}},
+			TimeStamp: time.Now(),
+			Source:    TestModule,
+		})
+	})
+
+	vectors, err := embedder.Embed(context.Background(), "embed-model", []string{"query"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 2}}, vectors)
+	request := <-requests
+	assert.Equal(t, "embed-model", request.Model)
+	assert.Equal(t, []string{"query"}, request.Inputs)
+
+	// 답이 없으면 context가 끝날 때 돌아온다
+	events.UnSubscribe(bus, bus.EmbedRequestEvent, TestModule)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = embedder.Embed(ctx, "embed-model", []string{"query"})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,44 @@
+package semantic
+
+type Input struct {
+	Query string `json:"query" jsonschema:"description:A natural-language description of the code or text to find, such as \"where expired sessions are cleaned up\""`
+	TopK  int    `json:"top_k,omitempty" jsonschema:"description:The number of chunks to return. Defaults to the configured top_k"`
+	Path  string `json:"path,omitempty" jsonschema:"description:The absolute path of a directory inside the workspace to search in. Defaults to the workspace root"`
+}
+
+// Section is a piece of a file that is embedded on its own, such as a
+// function with its doc comment or a few paragraphs of text.
+type Section struct {
+	StartLine int
+	EndLine   int
+	Text      string
+}
+
+// Chunk is a stored Section. The text is read back from the file when a
+// chunk is returned, so only its lines and vector are kept.
+type Chunk struct {
+	StartLine int
+	EndLine   int
+	Vector    []float32
+}
+
+type FileEntry struct {
+	Hash   string
+	Chunks []Chunk
+}
+
+// Stats describes an Update of the files under a path.
+type Stats struct {
+	Files     int
+	Chunks    int
+	Embedded  int
+	Removed   int
+	Truncated bool
+}
+
+type Result struct {
+	Path      string
+	StartLine int
+	EndLine   int
+	Score     float32
+}
@@ -0,0 +1,89 @@
+package task
+
+import (
+	"DevCode/constants"
+	"DevCode/dto"
+	"DevCode/events"
+	"DevCode/tools"
+	"DevCode/types"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	TaskDescription = `Launches a sub-agent that works on a task in its own conversation and returns
+  only its final answer.\n\nWhen to use:\n- Open-ended searches across the codebase, such as
+  "find where sessions are created and how they expire", that would otherwise take many Grep
+  and Read calls\n- Research whose intermediate results you do not need to keep in context\n\n
+  Usage:\n- The sub-agent does not see this conversation; write a detailed prompt with every
+  path, name and constraint it needs, and say exactly what its answer should contain\n- The
+  sub-agent has a restricted toolset (read-only by default) and cannot edit files, run
+  commands or ask the user anything\n- Its answer is not shown to the user; summarise the parts
+  that matter in your reply\n- Launch several tasks in one message when they are independent`
+	Name = "Task"
+)
+
+func NewTool(bus *events.EventBus) *Tool {
+	tool := &Tool{
+		bus:     bus,
+		waiting: tools.NewWaiter[types.ToolCallID, dto.SubAgentResultData](),
+	}
+	events.Subscribe(bus, bus.SubAgentResultEvent, constants.TaskTool, func(event events.Event[dto.SubAgentResultData]) {
+		tool.Complete(event.Data)
+	})
+	return tool
+}
+
+type Tool struct {
+	bus     *events.EventBus
+	waiting *tools.Waiter[types.ToolCallID, dto.SubAgentResultData]
+}
+
+func (*Tool) Name() string {
+	return Name
+}
+
+func (*Tool) Description() string {
+	return TaskDescription
+}
+
+func (instance *Tool) Handler() mcp.ToolHandlerFor[Input, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[Input]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		if strings.TrimSpace(input.Prompt) == "" {
+			return nil, fmt.Errorf("prompt must not be empty")
+		}
+		requestID, toolCallID := tools.CallIDs(params.Meta)
+
+		data, err := instance.waiting.Wait(ctx, toolCallID, func() {
+			events.Publish(instance.bus, instance.bus.SubAgentRequestEvent, events.Event[dto.SubAgentRequestData]{
+				Data: dto.SubAgentRequestData{
+					ParentRequestID:  requestID,
+					ParentToolCallID: toolCallID,
+					Description:      input.Description,
+					Prompt:           input.Prompt,
+					Model:            input.Model,
+					Tools:            input.Tools,
+				},
+				TimeStamp: time.Now(),
+				Source:    constants.TaskTool,
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+		if data.Error != nil {
+			return nil, data.Error
+		}
+		return tools.TextReturn(data.Result)
+	}
+}
+
+// Complete hands a sub-agent's final answer to the Task call waiting for it.
+func (instance *Tool) Complete(data dto.SubAgentResultData) {
+	instance.waiting.Complete(data.ParentToolCallID, data)
+}
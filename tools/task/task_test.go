@@ -0,0 +1,107 @@
+package task
+
+import (
+	"DevCode/config"
+	"DevCode/constants"
+	"DevCode/dto"
+	"DevCode/events"
+	"DevCode/tools"
+	"DevCode/types"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newBus(t *testing.T) *events.EventBus {
+	bus, err := events.NewEventBus(config.EventBusConfig{PoolSize: 10}, zap.NewNop())
+	require.NoError(t, err)
+	t.Cleanup(bus.Close)
+	return bus
+}
+
+// answer는 SubAgentRequestEvent를 받으면 주어진 결과로 응답하는 가짜 sub-agent
+func answer(bus *events.EventBus, requests chan<- dto.SubAgentRequestData, result string, err error) {
+	events.Subscribe(bus, bus.SubAgentRequestEvent, constants.LLMModule, func(event events.Event[dto.SubAgentRequestData]) {
+		requests <- event.Data
+		events.Publish(bus, bus.SubAgentResultEvent, events.Event[dto.SubAgentResultData]{
+			Data: dto.SubAgentResultData{
+				ParentToolCallID: event.Data.ParentToolCallID,
+				Result:           result,
+				Error:            err,
+			},
+			TimeStamp: time.Now(),
+			Source:    constants.LLMModule,
+		})
+	})
+}
+
+func TestTaskReturnsSubAgentAnswer(t *testing.T) {
+	bus := newBus(t)
+	requests := make(chan dto.SubAgentRequestData, 1)
+	answer(bus, requests, "Sessions are created in session/store.go:42", nil)
+
+	requestID := types.NewRequestID()
+	toolCallID := types.NewToolCallID()
+	tool := NewTool(bus)
+	result, err := tool.Handler()(context.Background(), nil, &mcp.CallToolParamsFor[Input]{
+		Meta: mcp.Meta{
+			tools.MetaRequestID:  requestID.String(),
+			tools.MetaToolCallID: toolCallID.String(),
+		},
+		Arguments: Input{
+			Description: "Find sessions",
+			Prompt:      "Find where sessions are created",
+			Model:       "qwen3:4b",
+			Tools:       []string{"Grep", "Read"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Sessions are created in session/store.go:42", result.Content[0].(*mcp.TextContent).Text)
+
+	request := <-requests
+	assert.Equal(t, requestID, request.ParentRequestID)
+	assert.Equal(t, toolCallID, request.ParentToolCallID)
+	assert.Equal(t, "Find where sessions are created", request.Prompt)
+	assert.Equal(t, "qwen3:4b", request.Model)
+	assert.Equal(t, []string{"Grep", "Read"}, request.Tools)
+}
+
+func TestTaskReturnsSubAgentError(t *testing.T) {
+	bus := newBus(t)
+	requests := make(chan dto.SubAgentRequestData, 1)
+	answer(bus, requests, "", errors.New("task was cancelled"))
+
+	tool := NewTool(bus)
+	_, err := tool.Handler()(context.Background(), nil, &mcp.CallToolParamsFor[Input]{
+		Arguments: Input{Description: "Search", Prompt: "Search"},
+	})
+	assert.EqualError(t, err, "task was cancelled")
+}
+
+func TestTaskStopsWhenContextIsDone(t *testing.T) {
+	bus := newBus(t)
+	tool := NewTool(bus)
+
+	// 응답하는 sub-agent가 없으므로 context 만료까지 기다린다
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := tool.Handler()(ctx, nil, &mcp.CallToolParamsFor[Input]{
+		Arguments: Input{Description: "Search", Prompt: "Search"},
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Zero(t, tool.waiting.Len())
+}
+
+func TestTaskRejectsEmptyPrompt(t *testing.T) {
+	tool := NewTool(newBus(t))
+	_, err := tool.Handler()(context.Background(), nil, &mcp.CallToolParamsFor[Input]{
+		Arguments: Input{Description: "Search", Prompt: "  "},
+	})
+	assert.EqualError(t, err, "prompt must not be empty")
+}
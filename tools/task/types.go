@@ -0,0 +1,8 @@
+package task
+
+type Input struct {
+	Description string   `json:"description" jsonschema:"description:A short (3-5 word) description of the task"`
+	Prompt      string   `json:"prompt" jsonschema:"description:The complete task for the sub-agent. It does not see this conversation, so include every detail it needs and say what the final answer should contain"`
+	Model       string   `json:"model,omitempty" jsonschema:"description:Optional Ollama model for the sub-agent. Defaults to the configured task model"`
+	Tools       []string `json:"tools,omitempty" jsonschema:"description:Optional subset of the sub-agent tools to allow, such as [\"Grep\", \"Read\"]. Defaults to every read-only tool configured for sub-agents"`
+}
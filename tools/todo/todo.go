@@ -0,0 +1,107 @@
+package todo
+
+import (
+	"DevCode/constants"
+	"DevCode/dto"
+	"DevCode/events"
+	"DevCode/tools"
+	"DevCode/types"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	TodoWriteDescription = `Use this tool to create and manage a structured task list for your
+  current coding session. This helps you track progress, organize complex tasks, and
+  demonstrate thoroughness to the user.\n\nWhen to use:\n- Complex multi-step tasks that
+  require 3 or more distinct steps\n- When the user provides multiple tasks to be done\n-
+  After receiving new instructions, to capture the requirements as todos\n\nUsage:\n- Always
+  send the complete list; it replaces the previous one\n- Each todo has content (imperative
+  form, e.g. "Run tests"), activeForm (present continuous form, e.g. "Running tests") and
+  status (pending, in_progress, completed)\n- Exactly one task should be in_progress at any
+  time\n- Mark tasks completed IMMEDIATELY after finishing them; do not batch up
+  completions\n- Only mark a task completed when it is fully accomplished`
+	Name = "TodoWrite"
+)
+
+func NewTool(bus *events.EventBus) *Tool {
+	return &Tool{
+		bus:   bus,
+		todos: make([]types.Todo, 0),
+	}
+}
+
+type Tool struct {
+	bus   *events.EventBus
+	todos []types.Todo
+	mutex sync.RWMutex
+}
+
+func (*Tool) Name() string {
+	return Name
+}
+
+func (*Tool) Description() string {
+	return TodoWriteDescription
+}
+
+func (instance *Tool) Handler() mcp.ToolHandlerFor[Input, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[Input]) (*mcp.CallToolResultFor[any], error) {
+		todos, err := Validate(params.Arguments.Todos)
+		if err != nil {
+			return nil, err
+		}
+		instance.mutex.Lock()
+		instance.todos = todos
+		instance.mutex.Unlock()
+		events.Publish(instance.bus, instance.bus.UpdateTodoEvent, events.Event[dto.TodoUpdateData]{
+			Data: dto.TodoUpdateData{
+				Todos: todos,
+			},
+			TimeStamp: time.Now(),
+			Source:    constants.McpModule,
+		})
+		return tools.TextReturn("Todos have been modified successfully. Ensure that you continue to use the todo list to track your progress. Please proceed with the current tasks if applicable")
+	}
+}
+
+func (instance *Tool) Todos() []types.Todo {
+	instance.mutex.RLock()
+	defer instance.mutex.RUnlock()
+	return append([]types.Todo(nil), instance.todos...)
+}
+
+func Validate(items []Item) ([]types.Todo, error) {
+	todos := make([]types.Todo, 0, len(items))
+	inProgress := 0
+	for index, item := range items {
+		if strings.TrimSpace(item.Content) == "" {
+			return nil, fmt.Errorf("todo %d: content must not be empty", index+1)
+		}
+		switch item.Status {
+		case constants.TodoPending, constants.TodoCompleted:
+		case constants.TodoInProgress:
+			inProgress++
+		default:
+			return nil, fmt.Errorf("todo %d: invalid status %q, must be one of pending, in_progress, completed", index+1, item.Status)
+		}
+		activeForm := item.ActiveForm
+		if strings.TrimSpace(activeForm) == "" {
+			activeForm = item.Content
+		}
+		todos = append(todos, types.Todo{
+			Content:    item.Content,
+			Status:     item.Status,
+			ActiveForm: activeForm,
+		})
+	}
+	if inProgress > 1 {
+		return nil, fmt.Errorf("only one todo can be in_progress at a time, found %d", inProgress)
+	}
+	return todos, nil
+}
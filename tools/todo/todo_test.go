@@ -0,0 +1,63 @@
+package todo
+
+import (
+	"DevCode/config"
+	"DevCode/constants"
+	"DevCode/dto"
+	"DevCode/events"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTodoWritePublishesUpdate(t *testing.T) {
+	bus, err := events.NewEventBus(config.EventBusConfig{PoolSize: 10}, zap.NewNop())
+	require.NoError(t, err)
+	defer bus.Close()
+
+	received := make(chan dto.TodoUpdateData, 1)
+	events.Subscribe(bus, bus.UpdateTodoEvent, constants.Model, func(event events.Event[dto.TodoUpdateData]) {
+		received <- event.Data
+	})
+
+	tool := NewTool(bus)
+	_, err = tool.Handler()(context.Background(), nil, &mcp.CallToolParamsFor[Input]{Arguments: Input{Todos: []Item{
+		{Content: "Run tests", Status: constants.TodoInProgress, ActiveForm: "Running tests"},
+		{Content: "Fix build", Status: constants.TodoPending},
+	}}})
+	require.NoError(t, err)
+
+	select {
+	case data := <-received:
+		require.Len(t, data.Todos, 2)
+		assert.Equal(t, "Running tests", data.Todos[0].ActiveForm)
+		// activeForm이 없으면 content를 사용
+		assert.Equal(t, "Fix build", data.Todos[1].ActiveForm)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected UpdateTodoEvent was not received within timeout")
+	}
+	assert.Len(t, tool.Todos(), 2)
+}
+
+func TestValidate(t *testing.T) {
+	_, err := Validate([]Item{{Content: "", Status: constants.TodoPending}})
+	assert.Error(t, err)
+
+	_, err = Validate([]Item{{Content: "a", Status: "doing"}})
+	assert.Error(t, err)
+
+	_, err = Validate([]Item{
+		{Content: "a", Status: constants.TodoInProgress},
+		{Content: "b", Status: constants.TodoInProgress},
+	})
+	assert.Error(t, err)
+
+	todos, err := Validate([]Item{})
+	require.NoError(t, err)
+	assert.Empty(t, todos)
+}
@@ -0,0 +1,11 @@
+package todo
+
+type Input struct {
+	Todos []Item `json:"todos" jsonschema:"description:The updated todo list"`
+}
+
+type Item struct {
+	Content    string `json:"content" jsonschema:"description:The imperative form describing what needs to be done (e.g. Run tests)"`
+	Status     string `json:"status" jsonschema:"description:The task status: pending, in_progress or completed"`
+	ActiveForm string `json:"activeForm,omitempty" jsonschema:"description:The present continuous form shown during execution (e.g. Running tests)"`
+}
@@ -1,6 +1,10 @@
 package tools
 
 import (
+	"DevCode/types"
+	"context"
+	"strings"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -12,3 +16,37 @@ func TextReturn(input string) (*mcp.CallToolResultFor[any], error) {
 		},
 	}, nil
 }
+
+func ImageReturn(data []byte, mimeType string) (*mcp.CallToolResultFor[any], error) {
+	content := mcp.ImageContent{Data: data, MIMEType: mimeType}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&content,
+		},
+	}, nil
+}
+
+// Call runs a tool's handler in process, without an MCP session.
+func Call[In any](ctx context.Context, tool types.Tool[In], input In) (*mcp.CallToolResultFor[any], error) {
+	return tool.Handler()(ctx, nil, &mcp.CallToolParamsFor[In]{Arguments: input})
+}
+
+// CallText runs a tool like Call and returns the text of its result.
+func CallText[In any](ctx context.Context, tool types.Tool[In], input In) (string, error) {
+	result, err := Call(ctx, tool, input)
+	if err != nil {
+		return "", err
+	}
+	return Text(result), nil
+}
+
+// Text joins the text contents of a result, skipping images.
+func Text(result *mcp.CallToolResultFor[any]) string {
+	texts := make([]string, 0, len(result.Content))
+	for _, content := range result.Content {
+		if text, ok := content.(*mcp.TextContent); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"context"
+	"sync"
+)
+
+// Waiter pairs a call that publishes a request on the bus with the event that
+// answers it. Both carry the same ID, and the answer goes to whoever waits on it.
+type Waiter[K comparable, V any] struct {
+	waiting map[K]chan V
+	mutex   sync.Mutex
+}
+
+func NewWaiter[K comparable, V any]() *Waiter[K, V] {
+	return &Waiter[K, V]{waiting: make(map[K]chan V)}
+}
+
+// Wait registers id, calls publish and blocks until Complete hands over the
+// answer for id or ctx is done.
+func (instance *Waiter[K, V]) Wait(ctx context.Context, id K, publish func()) (V, error) {
+	result := make(chan V, 1)
+	instance.mutex.Lock()
+	instance.waiting[id] = result
+	instance.mutex.Unlock()
+	defer func() {
+		instance.mutex.Lock()
+		delete(instance.waiting, id)
+		instance.mutex.Unlock()
+	}()
+
+	publish()
+
+	select {
+	case value := <-result:
+		return value, nil
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// Complete hands value to the call waiting on id. An answer nobody waits for,
+// such as one arriving after the call was cancelled, is dropped.
+func (instance *Waiter[K, V]) Complete(id K, value V) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	if result, exists := instance.waiting[id]; exists {
+		result <- value
+		delete(instance.waiting, id)
+	}
+}
+
+// Len reports how many calls are waiting.
+func (instance *Waiter[K, V]) Len() int {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	return len(instance.waiting)
+}
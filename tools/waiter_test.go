@@ -0,0 +1,39 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaiter(t *testing.T) {
+	waiter := NewWaiter[int, string]()
+
+	// publish 안에서 바로 답이 와도 놓치지 않는다
+	value, err := waiter.Wait(context.Background(), 1, func() {
+		waiter.Complete(2, "other")
+		waiter.Complete(1, "answer")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "answer", value)
+	assert.Zero(t, waiter.Len())
+
+	// 기다리는 호출이 없으면 답은 버려진다
+	waiter.Complete(1, "late")
+	assert.Zero(t, waiter.Len())
+}
+
+func TestWaiterCancelled(t *testing.T) {
+	waiter := NewWaiter[int, string]()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := waiter.Wait(ctx, 1, func() {
+		assert.Equal(t, 1, waiter.Len())
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Zero(t, waiter.Len())
+}
@@ -0,0 +1,47 @@
+package webfetch
+
+import (
+	"sync"
+	"time"
+)
+
+type Cache struct {
+	ttl     time.Duration
+	entries map[string]cacheEntry
+	mutex   sync.Mutex
+	now     func() time.Time
+}
+
+func NewCache(ttl time.Duration) *Cache {
+	return &Cache{
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+		now:     time.Now,
+	}
+}
+
+func (instance *Cache) Get(key string) (string, bool) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	entry, ok := instance.entries[key]
+	if !ok {
+		return "", false
+	}
+	if instance.now().After(entry.expires) {
+		delete(instance.entries, key)
+		return "", false
+	}
+	return entry.content, true
+}
+
+func (instance *Cache) Put(key string, content string) {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	now := instance.now()
+	for existing, entry := range instance.entries {
+		if now.After(entry.expires) {
+			delete(instance.entries, existing)
+		}
+	}
+	instance.entries[key] = cacheEntry{content: content, expires: now.Add(instance.ttl)}
+}
@@ -0,0 +1,425 @@
+package webfetch
+
+import (
+	"io"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// DroppedElements never contribute text to the converted document.
+var DroppedElements = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Template: true,
+	atom.Nav:      true,
+	atom.Svg:      true,
+	atom.Iframe:   true,
+	atom.Form:     true,
+	atom.Button:   true,
+	atom.Select:   true,
+	atom.Input:    true,
+	atom.Textarea: true,
+	atom.Link:     true,
+	atom.Meta:     true,
+}
+
+var (
+	whitespacePattern = regexp.MustCompile(`\s+`)
+	blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+)
+
+type Document struct {
+	Title    string
+	Markdown string
+}
+
+func ToMarkdown(reader io.Reader, base *url.URL) (Document, error) {
+	root, err := html.Parse(reader)
+	if err != nil {
+		return Document{}, err
+	}
+	converter := &converter{base: base}
+	document := Document{Title: strings.TrimSpace(whitespacePattern.ReplaceAllString(findTitle(root), " "))}
+	body := findElement(root, atom.Body)
+	if body == nil {
+		body = root
+	}
+	converter.children(body)
+	document.Markdown = converter.String()
+	return document, nil
+}
+
+type converter struct {
+	base   *url.URL
+	buffer []byte
+	tight  bool
+}
+
+func (instance *converter) sub() *converter {
+	return &converter{base: instance.base}
+}
+
+func (instance *converter) String() string {
+	return strings.TrimSpace(blankLinesPattern.ReplaceAllString(string(instance.buffer), "\n\n"))
+}
+
+func (instance *converter) lastByte() byte {
+	if len(instance.buffer) == 0 {
+		return '\n'
+	}
+	return instance.buffer[len(instance.buffer)-1]
+}
+
+func (instance *converter) text(text string) {
+	text = whitespacePattern.ReplaceAllString(text, " ")
+	if last := instance.lastByte(); last == '\n' || last == ' ' {
+		text = strings.TrimLeft(text, " ")
+	}
+	instance.buffer = append(instance.buffer, text...)
+}
+
+func (instance *converter) raw(text string) {
+	instance.buffer = append(instance.buffer, text...)
+}
+
+// newline trims trailing spaces and ends the buffer with count line breaks.
+func (instance *converter) newline(count int) {
+	if len(instance.buffer) == 0 {
+		return
+	}
+	for len(instance.buffer) > 0 && (instance.lastByte() == ' ' || instance.lastByte() == '\t') {
+		instance.buffer = instance.buffer[:len(instance.buffer)-1]
+	}
+	existing := 0
+	for index := len(instance.buffer) - 1; index >= 0 && instance.buffer[index] == '\n'; index-- {
+		existing++
+	}
+	for ; existing < count; existing++ {
+		instance.buffer = append(instance.buffer, '\n')
+	}
+}
+
+func (instance *converter) block(render func()) {
+	if instance.tight {
+		instance.newline(1)
+	} else {
+		instance.newline(2)
+	}
+	render()
+	if instance.tight {
+		instance.newline(1)
+	} else {
+		instance.newline(2)
+	}
+}
+
+func (instance *converter) children(node *html.Node) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		instance.node(child)
+	}
+}
+
+func (instance *converter) inline(node *html.Node) string {
+	sub := instance.sub()
+	sub.children(node)
+	return strings.Join(strings.Fields(sub.String()), " ")
+}
+
+func (instance *converter) node(node *html.Node) {
+	switch node.Type {
+	case html.TextNode:
+		instance.text(node.Data)
+		return
+	case html.ElementNode:
+	case html.DocumentNode:
+		instance.children(node)
+		return
+	default:
+		return
+	}
+	if DroppedElements[node.DataAtom] || hasAttribute(node, "hidden") || attribute(node, "aria-hidden") == "true" {
+		return
+	}
+	switch node.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(node.Data[1] - '0')
+		if text := instance.inline(node); text != "" {
+			instance.block(func() { instance.raw(strings.Repeat("#", level) + " " + text) })
+		}
+	case atom.P, atom.Div, atom.Section, atom.Article, atom.Main, atom.Header, atom.Footer, atom.Aside,
+		atom.Figure, atom.Figcaption, atom.Dl, atom.Details, atom.Summary, atom.Address:
+		instance.block(func() { instance.children(node) })
+	case atom.Dt:
+		instance.newline(1)
+		if text := instance.inline(node); text != "" {
+			instance.raw("**" + text + "**")
+		}
+		instance.newline(1)
+	case atom.Dd:
+		instance.newline(1)
+		instance.raw(": ")
+		instance.children(node)
+		instance.newline(1)
+	case atom.Br:
+		instance.newline(1)
+	case atom.Hr:
+		instance.block(func() { instance.raw("---") })
+	case atom.Ul, atom.Ol:
+		instance.list(node)
+	case atom.Li:
+		// li outside of a list is rendered as a bullet
+		instance.item(node, "- ")
+	case atom.Blockquote:
+		sub := instance.sub()
+		sub.children(node)
+		if content := sub.String(); content != "" {
+			instance.block(func() { instance.raw(prefixLines(content, "> ", ">")) })
+		}
+	case atom.Pre:
+		instance.block(func() {
+			code := strings.TrimRight(strings.TrimPrefix(textContent(node), "\n"), "\n ")
+			fence := "```"
+			for strings.Contains(code, fence) {
+				fence += "`"
+			}
+			instance.raw(fence + codeLanguage(node) + "\n" + code + "\n" + fence)
+		})
+	case atom.Code, atom.Kbd, atom.Samp, atom.Tt:
+		if code := whitespacePattern.ReplaceAllString(textContent(node), " "); strings.TrimSpace(code) != "" {
+			fence := "`"
+			if strings.Contains(code, "`") {
+				fence = "``"
+			}
+			instance.text(fence + code + fence)
+		}
+	case atom.Strong, atom.B:
+		instance.wrap(node, "**")
+	case atom.Em, atom.I, atom.Cite:
+		instance.wrap(node, "_")
+	case atom.Del, atom.S, atom.Strike:
+		instance.wrap(node, "~~")
+	case atom.A:
+		instance.link(node)
+	case atom.Img:
+		if source := instance.resolve(attribute(node, "src")); source != "" && !strings.HasPrefix(source, "data:") {
+			instance.text("![" + strings.TrimSpace(attribute(node, "alt")) + "](" + source + ")")
+		}
+	case atom.Table:
+		instance.table(node)
+	default:
+		instance.children(node)
+	}
+}
+
+func (instance *converter) wrap(node *html.Node, marker string) {
+	if text := instance.inline(node); text != "" {
+		instance.text(marker + text + marker)
+	}
+}
+
+func (instance *converter) link(node *html.Node) {
+	text := instance.inline(node)
+	href := attribute(node, "href")
+	if text == "" {
+		return
+	}
+	if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+		instance.text(text)
+		return
+	}
+	instance.text("[" + text + "](" + instance.resolve(href) + ")")
+}
+
+func (instance *converter) resolve(reference string) string {
+	reference = strings.TrimSpace(reference)
+	if reference == "" || instance.base == nil {
+		return reference
+	}
+	parsed, err := url.Parse(reference)
+	if err != nil {
+		return reference
+	}
+	return instance.base.ResolveReference(parsed).String()
+}
+
+func (instance *converter) list(node *html.Node) {
+	number := 1
+	if start, err := strconv.Atoi(attribute(node, "start")); err == nil {
+		number = start
+	}
+	instance.block(func() {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode || child.DataAtom != atom.Li {
+				continue
+			}
+			marker := "- "
+			if node.DataAtom == atom.Ol {
+				marker = strconv.Itoa(number) + ". "
+				number++
+			}
+			instance.item(child, marker)
+		}
+	})
+}
+
+func (instance *converter) item(node *html.Node, marker string) {
+	sub := instance.sub()
+	sub.tight = true
+	sub.children(node)
+	content := sub.String()
+	if content == "" {
+		return
+	}
+	instance.newline(1)
+	lines := strings.Split(content, "\n")
+	indent := strings.Repeat(" ", len(marker))
+	for index, line := range lines {
+		switch {
+		case index == 0:
+			instance.raw(marker + line)
+		case line == "":
+			instance.raw("\n")
+		default:
+			instance.raw("\n" + indent + line)
+		}
+	}
+	instance.newline(1)
+}
+
+func (instance *converter) table(node *html.Node) {
+	var rows [][]string
+	columns := 0
+	var collect func(*html.Node)
+	collect = func(current *html.Node) {
+		for child := current.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			switch child.DataAtom {
+			case atom.Tr:
+				var row []string
+				for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.Type == html.ElementNode && (cell.DataAtom == atom.Td || cell.DataAtom == atom.Th) {
+						row = append(row, strings.ReplaceAll(instance.inline(cell), "|", `\|`))
+					}
+				}
+				if len(row) > 0 {
+					rows = append(rows, row)
+					columns = max(columns, len(row))
+				}
+			case atom.Thead, atom.Tbody, atom.Tfoot:
+				collect(child)
+			}
+		}
+	}
+	collect(node)
+	if len(rows) == 0 {
+		return
+	}
+	instance.block(func() {
+		for index, row := range rows {
+			for len(row) < columns {
+				row = append(row, "")
+			}
+			instance.raw("| " + strings.Join(row, " | ") + " |\n")
+			if index == 0 {
+				instance.raw("|" + strings.Repeat(" --- |", columns) + "\n")
+			}
+		}
+	})
+}
+
+func prefixLines(content string, prefix string, empty string) string {
+	lines := strings.Split(content, "\n")
+	for index, line := range lines {
+		if line == "" {
+			lines[index] = empty
+		} else {
+			lines[index] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func codeLanguage(node *html.Node) string {
+	candidates := []*html.Node{node}
+	if node.FirstChild != nil && node.FirstChild.DataAtom == atom.Code {
+		candidates = append(candidates, node.FirstChild)
+	}
+	for _, candidate := range candidates {
+		for _, class := range strings.Fields(attribute(candidate, "class")) {
+			for _, prefix := range []string{"language-", "lang-"} {
+				if strings.HasPrefix(class, prefix) {
+					return strings.TrimPrefix(class, prefix)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func textContent(node *html.Node) string {
+	var builder strings.Builder
+	var walk func(*html.Node)
+	walk = func(current *html.Node) {
+		if current.Type == html.TextNode {
+			builder.WriteString(current.Data)
+			return
+		}
+		if current.DataAtom == atom.Br {
+			builder.WriteString("\n")
+		}
+		for child := current.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(node)
+	return builder.String()
+}
+
+func findElement(node *html.Node, target atom.Atom) *html.Node {
+	if node.Type == html.ElementNode && node.DataAtom == target {
+		return node
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if found := findElement(child, target); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func findTitle(root *html.Node) string {
+	head := findElement(root, atom.Head)
+	if head == nil {
+		return ""
+	}
+	if title := findElement(head, atom.Title); title != nil {
+		return textContent(title)
+	}
+	return ""
+}
+
+func attribute(node *html.Node, key string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+func hasAttribute(node *html.Node, key string) bool {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return true
+		}
+	}
+	return false
+}
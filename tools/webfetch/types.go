@@ -0,0 +1,12 @@
+package webfetch
+
+import "time"
+
+type Input struct {
+	URL string `json:"url" jsonschema:"description:The fully qualified http or https URL to fetch content from"`
+}
+
+type cacheEntry struct {
+	content string
+	expires time.Time
+}
@@ -0,0 +1,200 @@
+package webfetch
+
+import (
+	"DevCode/tools"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/net/html/charset"
+)
+
+const (
+	WebFetchDescription = `- Fetches content from a specified URL and returns it as Markdown\n- Takes a
+  fully qualified http or https URL as input\n- HTML pages are converted to Markdown; scripts,
+  styles, navigation and forms are dropped. Plain text and JSON responses are returned as
+  they are\n- Redirects are only followed within the same host. When a URL redirects to a
+  different host, the tool tells you the redirect URL and you should make a new WebFetch
+  request with it\n- Large pages are truncated\n- Responses are cached for 15 minutes, so
+  repeated requests for the same URL are fast\n- This tool is read-only and does not modify
+  any files`
+	Name = "WebFetch"
+
+	MaxBodySize      = 5 * 1024 * 1024
+	MaxContentLength = 100 * 1000
+	MaxRedirects     = 10
+	RequestTimeout   = 30 * time.Second
+	CacheTTL         = 15 * time.Minute
+	UserAgent        = "DevCode-WebFetch/1.0"
+)
+
+func NewTool() *Tool {
+	return &Tool{
+		client: &http.Client{
+			Timeout:       RequestTimeout,
+			CheckRedirect: CheckRedirect,
+		},
+		cache: NewCache(CacheTTL),
+	}
+}
+
+type Tool struct {
+	client *http.Client
+	cache  *Cache
+}
+
+func (*Tool) Name() string {
+	return Name
+}
+
+func (*Tool) Description() string {
+	return WebFetchDescription
+}
+
+func (instance *Tool) Handler() mcp.ToolHandlerFor[Input, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[Input]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		target, err := url.Parse(strings.TrimSpace(input.URL))
+		if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+			return nil, fmt.Errorf("invalid url: %s", input.URL)
+		}
+		key := target.String()
+		if content, ok := instance.cache.Get(key); ok {
+			return tools.TextReturn(content)
+		}
+		content, cacheable, err := instance.Fetch(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+		if cacheable {
+			instance.cache.Put(key, content)
+		}
+		return tools.TextReturn(content)
+	}
+}
+
+// CheckRedirect follows redirects only while they stay on the original host.
+func CheckRedirect(request *http.Request, via []*http.Request) error {
+	if len(via) >= MaxRedirects {
+		return fmt.Errorf("stopped after %d redirects", MaxRedirects)
+	}
+	if request.URL.Host != via[0].URL.Host {
+		return http.ErrUseLastResponse
+	}
+	return nil
+}
+
+func (instance *Tool) Fetch(ctx context.Context, target *url.URL) (string, bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid url: %s", target)
+	}
+	request.Header.Set("User-Agent", UserAgent)
+	request.Header.Set("Accept", "text/html,application/xhtml+xml,text/plain;q=0.9,*/*;q=0.8")
+	response, err := instance.client.Do(request)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to fetch %s: %w", target, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 300 && response.StatusCode < 400 {
+		location, err := response.Location()
+		if err != nil {
+			return "", false, fmt.Errorf("failed to fetch %s: %s without a valid Location header", target, response.Status)
+		}
+		return fmt.Sprintf("REDIRECT DETECTED: %s redirects to a different host.\nRedirect URL: %s\nStatus: %s\n\nTo read the redirected page, call %s again with the redirect URL.",
+			response.Request.URL, location, response.Status, Name), false, nil
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return "", false, fmt.Errorf("failed to fetch %s: %s", target, response.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, MaxBodySize+1))
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read %s: %w", target, err)
+	}
+	truncated := len(body) > MaxBodySize
+	if truncated {
+		body = body[:MaxBodySize]
+	}
+
+	final := response.Request.URL
+	contentType := response.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	}
+
+	var content strings.Builder
+	fmt.Fprintf(&content, "URL: %s\n", final)
+	switch {
+	case IsHTML(mediaType):
+		reader, err := charset.NewReader(bytes.NewReader(body), contentType)
+		if err != nil {
+			reader = bytes.NewReader(body)
+		}
+		document, err := ToMarkdown(reader, final)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to parse %s: %w", target, err)
+		}
+		if document.Title != "" {
+			fmt.Fprintf(&content, "Title: %s\n", document.Title)
+		}
+		content.WriteString("\n")
+		content.WriteString(document.Markdown)
+	case IsText(mediaType):
+		reader, err := charset.NewReader(bytes.NewReader(body), contentType)
+		if err != nil {
+			reader = bytes.NewReader(body)
+		}
+		decoded, err := io.ReadAll(reader)
+		if err != nil {
+			decoded = body
+		}
+		content.WriteString("\n")
+		content.WriteString(strings.ToValidUTF8(string(decoded), "�"))
+	default:
+		return "", false, fmt.Errorf("unsupported content type %q at %s", mediaType, final)
+	}
+
+	result := content.String()
+	if len(result) > MaxContentLength {
+		result = truncateUTF8(result, MaxContentLength) + fmt.Sprintf("\n\n[content truncated: showing the first %d of %d bytes]", MaxContentLength, len(result))
+	} else if truncated {
+		result += fmt.Sprintf("\n\n[content truncated: the response was larger than %d bytes]", MaxBodySize)
+	}
+	return result, true, nil
+}
+
+func IsHTML(mediaType string) bool {
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
+
+func IsText(mediaType string) bool {
+	if strings.HasPrefix(mediaType, "text/") {
+		return true
+	}
+	switch mediaType {
+	case "application/json", "application/xml", "application/javascript", "application/x-yaml", "application/yaml", "application/toml":
+		return true
+	}
+	return strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml")
+}
+
+func truncateUTF8(text string, limit int) string {
+	for limit > 0 && !utf8.RuneStart(text[limit]) {
+		limit--
+	}
+	return text[:limit]
+}
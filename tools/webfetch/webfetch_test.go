@@ -0,0 +1,210 @@
+package webfetch
+
+import (
+	"DevCode/tools"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const page = `<!DOCTYPE html>
+<html>
+<head>
+  <title>Deploy   Guide</title>
+  <style>body { color: red; }</style>
+  <script>alert("tracking")</script>
+</head>
+<body>
+  <nav><a href="/home">Home</a> | <a href="/about">About</a></nav>
+  <main>
+    <h1>Deploying <em>DevCode</em></h1>
+    <p>Read the <a href="/docs/setup">setup page</a> first.
+       It is <strong>important</strong>.</p>
+    <ul>
+      <li>Build the binary</li>
+      <li>Copy <code>env.toml</code>
+        <ol start="3"><li>Edit the model</li><li>Save</li></ol>
+      </li>
+    </ul>
+    <pre><code class="language-bash">go build ./...
+./DevCode</code></pre>
+    <blockquote><p>Never deploy on Friday.</p></blockquote>
+    <table>
+      <tr><th>Key</th><th>Value</th></tr>
+      <tr><td>model</td><td>llama3|8b</td></tr>
+    </table>
+    <img src="diagram.png" alt="Diagram">
+  </main>
+  <footer hidden>Copyright</footer>
+</body>
+</html>`
+
+func TestToMarkdown(t *testing.T) {
+	base, err := url.Parse("http://wiki.local/docs/deploy")
+	require.NoError(t, err)
+
+	document, err := ToMarkdown(strings.NewReader(page), base)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Deploy Guide", document.Title)
+	expected := "# Deploying _DevCode_\n\n" +
+		"Read the [setup page](http://wiki.local/docs/setup) first. It is **important**.\n\n" +
+		"- Build the binary\n" +
+		"- Copy `env.toml`\n" +
+		"  3. Edit the model\n" +
+		"  4. Save\n\n" +
+		"```bash\ngo build ./...\n./DevCode\n```\n\n" +
+		"> Never deploy on Friday.\n\n" +
+		"| Key | Value |\n| --- | --- |\n| model | llama3\\|8b |\n\n" +
+		"![Diagram](http://wiki.local/docs/diagram.png)"
+	assert.Equal(t, expected, document.Markdown)
+	assert.NotContains(t, document.Markdown, "tracking")
+	assert.NotContains(t, document.Markdown, "color")
+	assert.NotContains(t, document.Markdown, "About")
+	assert.NotContains(t, document.Markdown, "Copyright")
+}
+
+func TestWebFetchHTML(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+		writer.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	output, err := tools.CallText(context.Background(), NewTool(), Input{URL: server.URL + "/docs/deploy"})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(output, "URL: "+server.URL+"/docs/deploy\nTitle: Deploy Guide\n\n# Deploying _DevCode_"))
+	assert.Contains(t, output, "[setup page]("+server.URL+"/docs/setup)")
+}
+
+func TestWebFetchCharset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "text/html; charset=iso-8859-1")
+		writer.Write([]byte("<p>caf\xe9</p>"))
+	}))
+	defer server.Close()
+
+	output, err := tools.CallText(context.Background(), NewTool(), Input{URL: server.URL})
+	require.NoError(t, err)
+	assert.Contains(t, output, "café")
+}
+
+func TestWebFetchPlainText(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(`{"status": "<ok>"}`))
+	}))
+	defer server.Close()
+
+	output, err := tools.CallText(context.Background(), NewTool(), Input{URL: server.URL})
+	require.NoError(t, err)
+	assert.Equal(t, "URL: "+server.URL+"\n\n"+`{"status": "<ok>"}`, output)
+}
+
+func TestWebFetchUnsupportedContentType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/zip")
+		writer.Write([]byte("PK\x03\x04"))
+	}))
+	defer server.Close()
+
+	_, err := tools.CallText(context.Background(), NewTool(), Input{URL: server.URL})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported content type")
+}
+
+func TestWebFetchSameHostRedirect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(writer http.ResponseWriter, request *http.Request) {
+		http.Redirect(writer, request, "/new", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/new", func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "text/plain")
+		writer.Write([]byte("moved here"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	output, err := tools.CallText(context.Background(), NewTool(), Input{URL: server.URL + "/old"})
+	require.NoError(t, err)
+	assert.Equal(t, "URL: "+server.URL+"/new\n\nmoved here", output)
+}
+
+func TestWebFetchCrossHostRedirect(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		t.Error("redirect to another host must not be followed")
+	}))
+	defer other.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		http.Redirect(writer, request, other.URL+"/landing", http.StatusFound)
+	}))
+	defer server.Close()
+
+	output, err := tools.CallText(context.Background(), NewTool(), Input{URL: server.URL})
+	require.NoError(t, err)
+	assert.Contains(t, output, "REDIRECT DETECTED")
+	assert.Contains(t, output, "Redirect URL: "+other.URL+"/landing")
+}
+
+func TestWebFetchErrors(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	tool := NewTool()
+
+	_, err := tools.CallText(context.Background(), tool, Input{URL: server.URL + "/missing"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404 Not Found")
+
+	for _, input := range []string{"", "ftp://example.com/file", "not a url", "/relative/path"} {
+		_, err := tools.CallText(context.Background(), tool, Input{URL: input})
+		require.Error(t, err, input)
+		assert.Contains(t, err.Error(), "invalid url")
+	}
+}
+
+func TestWebFetchCache(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests.Add(1)
+		writer.Header().Set("Content-Type", "text/plain")
+		writer.Write([]byte("cached body"))
+	}))
+	defer server.Close()
+	tool := NewTool()
+	now := time.Now()
+	tool.cache.now = func() time.Time { return now }
+
+	for range 3 {
+		output, err := tools.CallText(context.Background(), tool, Input{URL: server.URL})
+		require.NoError(t, err)
+		assert.Contains(t, output, "cached body")
+	}
+	assert.Equal(t, int32(1), requests.Load())
+
+	// TTL이 지나면 다시 요청해야 함
+	now = now.Add(CacheTTL + time.Second)
+	_, err := tools.CallText(context.Background(), tool, Input{URL: server.URL})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestWebFetchTruncatesLargePages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "text/plain")
+		writer.Write([]byte(strings.Repeat("가", MaxContentLength)))
+	}))
+	defer server.Close()
+
+	output, err := tools.CallText(context.Background(), NewTool(), Input{URL: server.URL})
+	require.NoError(t, err)
+	assert.Contains(t, output, "[content truncated: showing the first 100000 of")
+	assert.Less(t, len(output), MaxContentLength+200)
+}
@@ -0,0 +1,6 @@
+package write
+
+type Input struct {
+	FilePath string `json:"file_path" jsonschema:"description:The absolute path to the file to write (must be absolute, not relative)"`
+	Content  string `json:"content" jsonschema:"description:The content to write to the file"`
+}
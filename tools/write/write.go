@@ -0,0 +1,98 @@
+package write
+
+import (
+	"DevCode/tools"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	WriteDescription = `Writes a file to the local filesystem.\n\nUsage:\n- This tool will
+  overwrite the existing file if there is one at the provided path.\n- The file_path
+  parameter must be an absolute path, not a relative path\n- Missing parent directories
+  are created automatically\n- If this is an existing file, you MUST use the Read tool
+  first to read the file's contents.\n- ALWAYS prefer editing existing files in the
+  codebase. NEVER write new files unless explicitly required.\n- NEVER proactively
+  create documentation files (*.md) or README files. Only create documentation files if
+  explicitly requested by the User.`
+	Name = "Write"
+
+	DefaultFileMode = os.FileMode(0644)
+	DefaultDirMode  = os.FileMode(0755)
+)
+
+type Tool struct {
+}
+
+func (*Tool) Name() string {
+	return Name
+}
+
+func (*Tool) Description() string {
+	return WriteDescription
+}
+
+func (instance *Tool) Handler() mcp.ToolHandlerFor[Input, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[Input]) (*mcp.CallToolResultFor[any], error) {
+		input := params.Arguments
+		if input.FilePath == "" || !filepath.IsAbs(input.FilePath) {
+			return nil, fmt.Errorf("invalid path format: %s", input.FilePath)
+		}
+		mode := DefaultFileMode
+		exist := false
+		if info, err := os.Stat(input.FilePath); err == nil {
+			if info.IsDir() {
+				return nil, fmt.Errorf("path is a directory: %s", input.FilePath)
+			}
+			mode = info.Mode().Perm()
+			exist = true
+		}
+		if err := os.MkdirAll(filepath.Dir(input.FilePath), DefaultDirMode); err != nil {
+			if os.IsPermission(err) {
+				return nil, fmt.Errorf("permission denied: %s", filepath.Dir(input.FilePath))
+			}
+			return nil, fmt.Errorf("fail to create directory: %s", filepath.Dir(input.FilePath))
+		}
+		if err := WriteFile(input.FilePath, []byte(input.Content), mode); err != nil {
+			return nil, err
+		}
+		if exist {
+			return tools.TextReturn(fmt.Sprintf("The file %s has been updated successfully.", input.FilePath))
+		}
+		return tools.TextReturn(fmt.Sprintf("File created successfully at: %s", input.FilePath))
+	}
+}
+
+func WriteFile(path string, data []byte, mode os.FileMode) error {
+	temp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		if os.IsPermission(err) {
+			return fmt.Errorf("permission denied: %s", path)
+		}
+		return fmt.Errorf("fail to create temp file: %s", path)
+	}
+	tempPath := temp.Name()
+	defer os.Remove(tempPath)
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return fmt.Errorf("fail to write file: %s", path)
+	}
+	if err := temp.Sync(); err != nil {
+		temp.Close()
+		return fmt.Errorf("fail to write file: %s", path)
+	}
+	if err := temp.Close(); err != nil {
+		return fmt.Errorf("fail to write file: %s", path)
+	}
+	if err := os.Chmod(tempPath, mode); err != nil {
+		return fmt.Errorf("fail to set file mode: %s", path)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("fail to replace file: %s", path)
+	}
+	return nil
+}
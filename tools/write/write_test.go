@@ -0,0 +1,60 @@
+package write
+
+import (
+	"DevCode/tools"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCreatesFileAndDirectories(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a", "b", "file.txt")
+
+	result, err := tools.Call(context.Background(), &Tool{}, Input{FilePath: path, Content: "hello\n"})
+	require.NoError(t, err)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "created")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", string(data))
+
+	// 임시 파일이 남아있지 않아야 함
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestWriteOverwriteKeepsMode(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "script.sh")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0755))
+	require.NoError(t, os.Chmod(path, 0755))
+
+	result, err := tools.Call(context.Background(), &Tool{}, Input{FilePath: path, Content: "new"})
+	require.NoError(t, err)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "updated")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+}
+
+func TestWriteRejectsRelativePath(t *testing.T) {
+	_, err := tools.Call(context.Background(), &Tool{}, Input{FilePath: "relative/file.txt", Content: "x"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid path format")
+}
+
+func TestWriteRejectsDirectory(t *testing.T) {
+	_, err := tools.Call(context.Background(), &Tool{}, Input{FilePath: t.TempDir(), Content: "x"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,7 @@
+package types
+
+type Todo struct {
+	Content    string
+	Status     string
+	ActiveForm string
+}
@@ -7,17 +7,29 @@ import (
 type ToolManager interface {
 	IsPending() bool
 	ChangedActiveTool() []*ActiveTool
+	Pending() *PendingTool
 	Select(selectIndex int)
+	Answer(answer string)
 	Quit()
 }
 
 type ActiveTool struct {
-	ToolCallID ToolCallID
-	ToolInfo   string
-	ToolStatus constants.ToolStatus
+	ToolCallID       ToolCallID
+	ParentToolCallID ToolCallID
+	ToolInfo         string
+	ToolStatus       constants.ToolStatus
 }
 
 type PendingTool struct {
 	RequestID  RequestID
 	ToolCallID ToolCallID
+	// Question is set when the model asks the user something rather than
+	// waiting for a tool call to be approved.
+	Question *Question
+}
+
+type Question struct {
+	Text    string
+	Options []string
+	Other   bool
 }
@@ -15,5 +15,10 @@ func EnvironmentUpdateDataToString(data dto.EnvironmentUpdateData) string {
 	builder.WriteString(fmt.Sprintf("OS Version: %s\n", data.OSVersion))
 	builder.WriteString(fmt.Sprintf("Today's date: %s\n", data.TodayDate))
 	builder.WriteString("</env>\n")
+	if data.RepoMap != "" {
+		builder.WriteString("<repository_map>\n")
+		builder.WriteString(data.RepoMap + "\n")
+		builder.WriteString("</repository_map>\n")
+	}
 	return builder.String()
 }
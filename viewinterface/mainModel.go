@@ -7,6 +7,8 @@ import (
 	"DevCode/events"
 	"DevCode/types"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -18,6 +20,10 @@ import (
 	"go.uber.org/zap"
 )
 
+const OtherChoice = "Other (type your own answer)"
+
+var ApprovalChoices = []string{"yes", "no"}
+
 type StreamUpdate struct {
 	Content    string
 	IsComplete bool
@@ -40,7 +46,7 @@ func NewMainModel(bus *events.EventBus, config config.ViewConfig, logger *zap.Lo
 	view := viewport.New(1, 0)
 
 	selectModel := NewSelectModel(
-		[]string{"yes", "no"},
+		ApprovalChoices,
 		nil,
 		nil,
 		DefaultStyles.Select,
@@ -57,8 +63,9 @@ func NewMainModel(bus *events.EventBus, config config.ViewConfig, logger *zap.Lo
 		logger:      logger,
 		toolManager: toolManager,
 		toolModels:  make(map[types.ToolCallID]*ToolModel, 10),
+		TodoModel:   NewTodoModel(),
 	}
-	model.SelectModel.SelectCallBack = model.toolManager.Select
+	model.SelectModel.SelectCallBack = model.Select
 	model.SelectModel.QuitCallBack = model.toolManager.Quit
 	model.Subscribe()
 	return model
@@ -75,10 +82,15 @@ type MainModel struct {
 	AssistantMessage string
 	Keys             MainKeyMap
 	SelectModel      *SelectModel
+	TodoModel        *TodoModel
 	Config           config.ViewConfig
 	logger           *zap.Logger
 	toolManager      types.ToolManager
 	toolModels       map[types.ToolCallID]*ToolModel
+	// decision is the pending call whose choices SelectModel shows.
+	decision types.ToolCallID
+	// OtherAnswer is set while the user types their own answer to a question.
+	OtherAnswer bool
 }
 
 func (instance *MainModel) SetProgram(program *tea.Program) {
@@ -108,6 +120,26 @@ func (instance *MainModel) Subscribe() {
 	events.Subscribe(instance.Bus, instance.Bus.UpdateViewEvent, constants.Model, func(event events.Event[dto.UpdateViewData]) {
 		instance.Program.Send(event.Data)
 	})
+	events.Subscribe(instance.Bus, instance.Bus.UpdateTodoEvent, constants.Model, func(event events.Event[dto.TodoUpdateData]) {
+		if instance.Program != nil {
+			instance.Program.Send(event.Data)
+		}
+	})
+	events.Subscribe(instance.Bus, instance.Bus.RestoreResultEvent, constants.Model, func(event events.Event[dto.RestoreResultData]) {
+		if instance.Program != nil {
+			instance.Program.Send(event.Data)
+		}
+	})
+}
+
+// RestoreArgument reports whether the input is the /restore command and
+// returns what follows it.
+func RestoreArgument(input string) (string, bool) {
+	fields := strings.Fields(input)
+	if len(fields) == 0 || fields[0] != constants.RestoreCommand {
+		return "", false
+	}
+	return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(input), constants.RestoreCommand)), true
 }
 
 func (instance *MainModel) Init() tea.Cmd {
@@ -124,10 +156,33 @@ func (instance *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch {
 		case key.Matches(msg, instance.Keys.Exit):
 			return instance, tea.Quit
+		case key.Matches(msg, instance.Keys.Choice) && instance.OtherAnswer:
+			if answer := strings.TrimSpace(instance.InputPort.Value()); answer != "" {
+				instance.OtherAnswer = false
+				instance.InputPort.Reset()
+				instance.toolManager.Answer(answer)
+			}
+			return instance, nil
+		case key.Matches(msg, instance.Keys.Cancel) && instance.OtherAnswer:
+			instance.OtherAnswer = false
+			instance.InputPort.Reset()
+			return instance, nil
 		case key.Matches(msg, instance.Keys.Choice) && instance.Status != constants.ToolDecision:
 			if instance.Status == constants.UserInput {
-				instance.MessageID = types.NewRequestID()
 				userMessage := instance.InputPort.Value()
+				if argument, ok := RestoreArgument(userMessage); ok {
+					events.Publish(instance.Bus, instance.Bus.RestoreRequestEvent, events.Event[dto.RestoreRequestData]{
+						Data: dto.RestoreRequestData{
+							Argument: argument,
+						},
+						TimeStamp: time.Now(),
+						Source:    constants.Model,
+					})
+					cmd = tea.Println(userMessage)
+					instance.InputPort.Reset()
+					return instance, cmd
+				}
+				instance.MessageID = types.NewRequestID()
 				events.Publish(instance.Bus, instance.Bus.UserInputEvent,
 					events.Event[dto.UserRequestData]{
 						Data: dto.UserRequestData{
@@ -163,6 +218,10 @@ func (instance *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			instance.MessagePort.Height = 0
 			instance.Status = constants.UserInput
 		}
+	case dto.TodoUpdateData:
+		instance.TodoModel.Update(msg)
+	case dto.RestoreResultData:
+		cmds = append(cmds, tea.Println(msg.Message))
 	case dto.UpdateViewData:
 		list := instance.toolManager.ChangedActiveTool()
 
@@ -171,7 +230,9 @@ func (instance *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if model, exist := instance.toolModels[activeTool.ToolCallID]; exist {
 					model.ToolInfo = activeTool.ToolInfo
 				} else {
-					instance.toolModels[activeTool.ToolCallID] = NewToolModel(activeTool.ToolInfo, instance.Config)
+					model := NewToolModel(activeTool.ToolInfo, instance.Config)
+					model.Parent = activeTool.ParentToolCallID
+					instance.toolModels[activeTool.ToolCallID] = model
 				}
 			} else {
 				if model, exist := instance.toolModels[activeTool.ToolCallID]; exist {
@@ -191,14 +252,19 @@ func (instance *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if cmd != nil {
 		cmds = append(cmds, cmd)
 	}
-	if instance.toolManager.IsPending() {
+	answering := instance.OtherAnswer
+	if pending := instance.toolManager.Pending(); pending != nil {
 		instance.Status = constants.ToolDecision
-		instance.SelectModel.Update(msg)
+		instance.ShowDecision(pending)
+		if !instance.OtherAnswer {
+			instance.SelectModel.Update(msg)
+		}
 	}
 	height := (instance.InputPort.Length()+1)/instance.InputPort.Width() + 1
 	height = min(height, 5)
 	instance.InputPort.SetHeight(height)
-	if instance.Status != constants.ToolDecision {
+	// the key that chose to type an answer does not go to the input
+	if instance.Status != constants.ToolDecision || (answering && instance.OtherAnswer) {
 		instance.InputPort, cmd = instance.InputPort.Update(msg)
 		if cmd != nil {
 			cmds = append(cmds, cmd)
@@ -213,17 +279,83 @@ func (instance *MainModel) View() string {
 		list = append(list, instance.MessagePort.View())
 	}
 	if len(instance.toolModels) > 0 {
-		for _, toolview := range instance.toolModels {
-			list = append(list, toolview.View())
-		}
+		list = append(list, instance.ToolViews()...)
+	}
+	if instance.TodoModel.IsVisible() {
+		list = append(list, instance.TodoModel.View())
 	}
 	if instance.Status == constants.ToolDecision {
-		list = append(list, instance.SelectModel.View())
+		list = append(list, instance.DecisionView())
 	}
 	list = append(list, instance.InputPort.View())
 	return lipgloss.JoinVertical(lipgloss.Left, list...)
 }
 
+// ShowDecision puts the choices for a newly pending call in SelectModel:
+// yes or no for a tool call, and the options of a question followed by
+// OtherChoice when the question allows other answers.
+func (instance *MainModel) ShowDecision(pending *types.PendingTool) {
+	if pending.ToolCallID == instance.decision {
+		return
+	}
+	instance.decision = pending.ToolCallID
+	instance.OtherAnswer = false
+	if pending.Question == nil {
+		instance.SelectModel.SetChoices(ApprovalChoices)
+		instance.SelectModel.Style = DefaultStyles.Select
+		return
+	}
+	choices := slices.Clone(pending.Question.Options)
+	if pending.Question.Other {
+		choices = append(choices, OtherChoice)
+	}
+	instance.SelectModel.SetChoices(choices)
+	instance.SelectModel.Style = DefaultStyles.Question
+}
+
+// Select answers the pending call with the choice at index. Choosing
+// OtherChoice lets the user type the answer in the input instead.
+func (instance *MainModel) Select(index int) {
+	pending := instance.toolManager.Pending()
+	if pending == nil {
+		return
+	}
+	if pending.Question != nil && index >= len(pending.Question.Options) {
+		instance.OtherAnswer = true
+		return
+	}
+	instance.toolManager.Select(index)
+}
+
+func (instance *MainModel) DecisionView() string {
+	pending := instance.toolManager.Pending()
+	if pending == nil || pending.Question == nil {
+		return instance.SelectModel.View()
+	}
+	if instance.OtherAnswer {
+		return DefaultStyles.Question.Render(pending.Question.Text + "\n\nType your answer and press enter, or esc to go back to the options.")
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, pending.Question.Text, instance.SelectModel.View())
+}
+
+// ToolViews renders running tools with the calls made by a Task sub-agent
+// listed under the Task call that started them.
+func (instance *MainModel) ToolViews() []string {
+	views := make([]string, 0, len(instance.toolModels))
+	for toolCallID, model := range instance.toolModels {
+		if _, nested := instance.toolModels[model.Parent]; nested && !model.Parent.IsNil() {
+			continue
+		}
+		views = append(views, model.View())
+		for _, child := range instance.toolModels {
+			if child.Parent == toolCallID {
+				views = append(views, child.View())
+			}
+		}
+	}
+	return views
+}
+
 func (instance *MainModel) AddToAssistantMessage(newContent string) {
 	if len(instance.AssistantMessage) == 0 {
 		instance.AssistantMessage = instance.Config.Dot + " " + newContent
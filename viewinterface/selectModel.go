@@ -30,6 +30,12 @@ type SelectModel struct {
 	SelectChar     string
 }
 
+// SetChoices replaces the choices and moves the cursor back to the first one.
+func (instance *SelectModel) SetChoices(choices []string) {
+	instance.Choices = choices
+	instance.SecltedIndex = 0
+}
+
 func (instance *SelectModel) Init() tea.Cmd {
 	return nil
 }
@@ -7,11 +7,17 @@ import (
 type Styles struct {
 	Input       lipgloss.Style
 	Select      lipgloss.Style
+	Question    lipgloss.Style
 	Message     lipgloss.Style
 	ToolPending lipgloss.Style
 	ToolError   lipgloss.Style
 	ToolSuccess lipgloss.Style
 	ToolDefault lipgloss.Style
+
+	Todo           lipgloss.Style
+	TodoPending    lipgloss.Style
+	TodoInProgress lipgloss.Style
+	TodoCompleted  lipgloss.Style
 }
 
 func NewStyles() *Styles {
@@ -28,6 +34,12 @@ func NewStyles() *Styles {
 			Height(10).
 			Width(20),
 
+		Question: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.ANSIColor(32)).
+			PaddingLeft(1).
+			PaddingRight(2),
+
 		Message: lipgloss.NewStyle().
 			Width(0),
 
@@ -42,6 +54,22 @@ func NewStyles() *Styles {
 
 		ToolDefault: lipgloss.NewStyle().
 			Foreground(lipgloss.ANSIColor(11)),
+
+		Todo: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.ANSIColor(8)).
+			PaddingLeft(1).
+			PaddingRight(1),
+
+		TodoPending: lipgloss.NewStyle(),
+
+		TodoInProgress: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.ANSIColor(12)),
+
+		TodoCompleted: lipgloss.NewStyle().
+			Strikethrough(true).
+			Foreground(lipgloss.ANSIColor(8)),
 	}
 }
 
@@ -0,0 +1,64 @@
+package viewinterface
+
+import (
+	"DevCode/constants"
+	"DevCode/dto"
+	"DevCode/types"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	TodoPendingMark    = "☐"
+	TodoInProgressMark = "◐"
+	TodoCompletedMark  = "☒"
+)
+
+func NewTodoModel() *TodoModel {
+	return &TodoModel{
+		Todos: make([]types.Todo, 0),
+	}
+}
+
+type TodoModel struct {
+	Todos []types.Todo
+}
+
+func (instance *TodoModel) Init() tea.Cmd {
+	return nil
+}
+
+func (instance *TodoModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(dto.TodoUpdateData); ok {
+		instance.Todos = msg.Todos
+	}
+	return instance, nil
+}
+
+func (instance *TodoModel) IsVisible() bool {
+	for _, todo := range instance.Todos {
+		if todo.Status != constants.TodoCompleted {
+			return true
+		}
+	}
+	return false
+}
+
+func (instance *TodoModel) View() string {
+	if !instance.IsVisible() {
+		return ""
+	}
+	lines := make([]string, 0, len(instance.Todos))
+	for _, todo := range instance.Todos {
+		switch todo.Status {
+		case constants.TodoCompleted:
+			lines = append(lines, DefaultStyles.TodoCompleted.Render(TodoCompletedMark+" "+todo.Content))
+		case constants.TodoInProgress:
+			lines = append(lines, DefaultStyles.TodoInProgress.Render(TodoInProgressMark+" "+todo.ActiveForm))
+		default:
+			lines = append(lines, DefaultStyles.TodoPending.Render(TodoPendingMark+" "+todo.Content))
+		}
+	}
+	return DefaultStyles.Todo.Render(strings.Join(lines, "\n"))
+}
@@ -3,6 +3,7 @@ package viewinterface
 import (
 	"DevCode/config"
 	"DevCode/constants"
+	"DevCode/types"
 
 	"github.com/charmbracelet/bubbles/cursor"
 	tea "github.com/charmbracelet/bubbletea"
@@ -27,6 +28,8 @@ type ToolModel struct {
 	Status   cursor.Model
 	ToolInfo string
 	Config   config.ViewConfig
+	// Parent is the Task call this tool was made for, if any.
+	Parent types.ToolCallID
 }
 
 func (instance *ToolModel) Init() tea.Cmd {
@@ -56,5 +59,8 @@ func (instance *ToolModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (instance *ToolModel) View() string {
+	if !instance.Parent.IsNil() {
+		return lipgloss.JoinHorizontal(lipgloss.Left, "  ⎿ ", instance.Status.View(), " ", instance.ToolInfo, "\n")
+	}
 	return lipgloss.JoinHorizontal(lipgloss.Left, instance.Status.View(), " ", instance.ToolInfo, "\n")
 }